@@ -0,0 +1,42 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/generator"
+)
+
+const generateCommand = "generate"
+
+// runGenerate writes deterministic synthetic catalog in importer fixtures format
+func runGenerate(args []string) error {
+	defaults := generator.DefaultOptions()
+
+	fs := flag.NewFlagSet(generateCommand, flag.ContinueOnError)
+	out := fs.String("out", "", "directory to write fixtures to")
+	seed := fs.Int64("seed", defaults.Seed, "random seed, the same seed always produces the same dataset")
+	movies := fs.Int("movies", defaults.Movies, "number of movies")
+	persons := fs.Int("persons", defaults.Persons, "number of persons")
+	staff := fs.Int("staff", defaults.StaffPerMovie, "number of persons linked to every movie")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *out == "" {
+		fs.Usage()
+		return fmt.Errorf("-out is required")
+	}
+
+	opts := defaults
+	opts.Seed, opts.Movies, opts.Persons, opts.StaffPerMovie = *seed, *movies, *persons, *staff
+
+	dataset := generator.Generate(opts)
+	if err := dataset.WriteFixtures(*out); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(os.Stdout, "generated %d movies, %d persons into %s\n",
+		len(dataset.Catalog.Movies), len(dataset.Catalog.Persons), *out)
+	return err
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/importer"
+	repoCollection "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/collection/repository"
+	repoGenre "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/genre/repository"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	repoMovie "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/repository"
+	repoStaff "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/staff_person/repository"
+	"github.com/rs/zerolog/log"
+)
+
+const importCommand = "import"
+
+// runImport loads fixtures, validates them and prints what would change in the built-in catalog.
+// Catalog is in-memory, so command never writes anything: server applies the same fixtures
+// on startup when catalog.fixtures_dir is configured
+func runImport(args []string) error {
+	fs := flag.NewFlagSet(importCommand, flag.ContinueOnError)
+	dir := fs.String("dir", "", "directory with catalog fixtures (manifest.json, genres, persons, movies, staff, collections)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *dir == "" {
+		fs.Usage()
+		return fmt.Errorf("-dir is required")
+	}
+
+	catalog, err := importer.Load(*dir)
+	if err != nil {
+		return err
+	}
+
+	catalogImporter := importer.New(
+		repoGenre.NewGenreRepository(&mocks.ExistingGenres),
+		repoMovie.NewMovieRepository(&mocks.ExistingMovies),
+		repoStaff.NewStaffPersonRepository(&mocks.ExistingActors),
+		repoCollection.NewCollectionRepository(&mocks.MainPageCollections),
+	)
+
+	report, err := catalogImporter.Import(log.Logger.WithContext(context.Background()), catalog, true)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(os.Stdout, report.String())
+	return err
+}
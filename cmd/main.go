@@ -15,6 +15,20 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == importCommand {
+		if err := runImport(os.Args[2:]); err != nil {
+			log.Fatal().Err(errors.Wrap(err, errs.ErrImportCatalog)).Msg(errors.Wrap(err, errs.ErrImportCatalog).Error())
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == generateCommand {
+		if err := runGenerate(os.Args[2:]); err != nil {
+			log.Fatal().Err(errors.Wrap(err, errs.ErrWriteFixtures)).Msg(errors.Wrap(err, errs.ErrWriteFixtures).Error())
+		}
+		return
+	}
+
 	cfg, err := config.New()
 	if err != nil {
 		log.Fatal().Err(errors.Wrap(err, errs.ErrLoadConfig)).Msg(errors.Wrap(err, errs.ErrLoadConfig).Error())
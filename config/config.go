@@ -18,8 +18,16 @@ const (
 )
 
 type Config struct {
-  Server Server `yaml:"server" mapstructure:"server"`
-  Cookie Cookie `yaml:"cookie" mapstructure:"cookie"`
+  Server   Server   `yaml:"server" mapstructure:"server"`
+  Cookie   Cookie   `yaml:"cookie" mapstructure:"cookie"`
+  Catalog  Catalog  `yaml:"catalog" mapstructure:"catalog"`
+  Snapshot Snapshot `yaml:"snapshot" mapstructure:"snapshot"`
+  Admin    Admin    `yaml:"admin" mapstructure:"admin"`
+
+  Recommendations Recommendations `yaml:"recommendations" mapstructure:"recommendations"`
+  Trending        Trending        `yaml:"trending" mapstructure:"trending"`
+  Ranking         Ranking         `yaml:"ranking" mapstructure:"ranking"`
+  Premieres       Premieres       `yaml:"premieres" mapstructure:"premieres"`
 }
 
 type Server struct {
@@ -41,6 +49,93 @@ type Cookie struct {
   ExpirationAge int           `yaml:"expiration_age" mapstructure:"expiration_age"`
 }
 
+type Catalog struct {
+  FixturesDir string    `yaml:"fixtures_dir" mapstructure:"fixtures_dir"`
+  Generator   Generator `yaml:"generator" mapstructure:"generator"`
+}
+
+type Generator struct {
+  Enabled bool  `yaml:"enabled" mapstructure:"enabled"`
+  Seed    int64 `yaml:"seed" mapstructure:"seed"`
+  Movies  int   `yaml:"movies" mapstructure:"movies"`
+  Persons int   `yaml:"persons" mapstructure:"persons"`
+  Users   int   `yaml:"users" mapstructure:"users"`
+}
+
+type Snapshot struct {
+  Path           string        `yaml:"path" mapstructure:"path"`
+  Interval       time.Duration `yaml:"interval" mapstructure:"interval"`
+  RestoreOnStart bool          `yaml:"restore_on_start" mapstructure:"restore_on_start"`
+}
+
+type Admin struct {
+  Accounts []AdminAccount `yaml:"accounts" mapstructure:"accounts"`
+}
+
+// AdminAccount user allowed to use admin endpoints. Account is created on startup with bcrypt hash of password,
+// so its login can not be taken by registration when users are lost on restart
+type AdminAccount struct {
+  Login        string `yaml:"login" mapstructure:"login"`
+  PasswordHash string `yaml:"password_hash" mapstructure:"password_hash"`
+}
+
+// Logins returns logins of admin accounts
+func (a Admin) Logins() []string {
+  logins := make([]string, 0, len(a.Accounts))
+  for _, account := range a.Accounts {
+    logins = append(logins, account.Login)
+  }
+  return logins
+}
+
+type Recommendations struct {
+  Similar  Similar  `yaml:"similar" mapstructure:"similar"`
+  Personal Personal `yaml:"personal" mapstructure:"personal"`
+}
+
+// Similar weights of likeness kinds similar movies are ranked by
+type Similar struct {
+  GenreWeight   float64 `yaml:"genre_weight" mapstructure:"genre_weight"`
+  StaffWeight   float64 `yaml:"staff_weight" mapstructure:"staff_weight"`
+  EraWeight     float64 `yaml:"era_weight" mapstructure:"era_weight"`
+  CountryWeight float64 `yaml:"country_weight" mapstructure:"country_weight"`
+  EraSpan       int     `yaml:"era_span" mapstructure:"era_span"`
+}
+
+// Personal recommendations by user ratings
+type Personal struct {
+  RecomputeInterval time.Duration `yaml:"recompute_interval" mapstructure:"recompute_interval"`
+  ColdStartRatings  int           `yaml:"cold_start_ratings" mapstructure:"cold_start_ratings"`
+}
+
+// Trending weights of user actions and view counting of "Популярно сейчас"
+type Trending struct {
+  ViewWeight        float64       `yaml:"view_weight" mapstructure:"view_weight"`
+  RatingWeight      float64       `yaml:"rating_weight" mapstructure:"rating_weight"`
+  ReviewWeight      float64       `yaml:"review_weight" mapstructure:"review_weight"`
+  WatchlistWeight   float64       `yaml:"watchlist_weight" mapstructure:"watchlist_weight"`
+  CacheTTL          time.Duration `yaml:"cache_ttl" mapstructure:"cache_ttl"`
+  ViewBuffer        int           `yaml:"view_buffer" mapstructure:"view_buffer"`
+  ViewFlushInterval time.Duration `yaml:"view_flush_interval" mapstructure:"view_flush_interval"`
+}
+
+// Ranking Top-250 by weighted rating of user ratings
+type Ranking struct {
+  Size              int           `yaml:"size" mapstructure:"size"`
+  MinVotes          int           `yaml:"min_votes" mapstructure:"min_votes"`
+  RecomputeInterval time.Duration `yaml:"recompute_interval" mapstructure:"recompute_interval"`
+  MovementPeriod    time.Duration `yaml:"movement_period" mapstructure:"movement_period"`
+  HistoryRetention  time.Duration `yaml:"history_retention" mapstructure:"history_retention"`
+}
+
+// Premieres release calendar and "Скоро в кино" collection
+type Premieres struct {
+  DefaultDays       int    `yaml:"default_days" mapstructure:"default_days"`
+  MaxDays           int    `yaml:"max_days" mapstructure:"max_days"`
+  ComingSoonDays    int    `yaml:"coming_soon_days" mapstructure:"coming_soon_days"`
+  ComingSoonCountry string `yaml:"coming_soon_country" mapstructure:"coming_soon_country"`
+}
+
 func New() (*Config, error) {
   log.Info().Msg("Initializing config")
 
@@ -78,6 +173,60 @@ func setupCookie() {
   viper.SetDefault("cookie.expiration_age", defaults.ExpirationAge)
 }
 
+func setupCatalog() {
+  viper.SetDefault("catalog.fixtures_dir", defaults.FixturesDir)
+  viper.SetDefault("catalog.generator.enabled", defaults.GeneratorEnabled)
+  viper.SetDefault("catalog.generator.seed", defaults.GeneratorSeed)
+  viper.SetDefault("catalog.generator.movies", defaults.GeneratorMovies)
+  viper.SetDefault("catalog.generator.persons", defaults.GeneratorPersons)
+  viper.SetDefault("catalog.generator.users", defaults.GeneratorUsers)
+}
+
+func setupSnapshot() {
+  viper.SetDefault("snapshot.path", defaults.SnapshotPath)
+  viper.SetDefault("snapshot.interval", defaults.SnapshotInterval)
+  viper.SetDefault("snapshot.restore_on_start", defaults.SnapshotRestoreOnStart)
+}
+
+func setupAdmin() {
+  viper.SetDefault("admin.accounts", []AdminAccount{})
+}
+
+func setupRecommendations() {
+  viper.SetDefault("recommendations.similar.genre_weight", defaults.SimilarGenreWeight)
+  viper.SetDefault("recommendations.similar.staff_weight", defaults.SimilarStaffWeight)
+  viper.SetDefault("recommendations.similar.era_weight", defaults.SimilarEraWeight)
+  viper.SetDefault("recommendations.similar.country_weight", defaults.SimilarCountryWeight)
+  viper.SetDefault("recommendations.similar.era_span", defaults.SimilarEraSpan)
+  viper.SetDefault("recommendations.personal.recompute_interval", defaults.PersonalRecomputeInterval)
+  viper.SetDefault("recommendations.personal.cold_start_ratings", defaults.PersonalColdStartRatings)
+}
+
+func setupTrending() {
+  viper.SetDefault("trending.view_weight", defaults.TrendingViewWeight)
+  viper.SetDefault("trending.rating_weight", defaults.TrendingRatingWeight)
+  viper.SetDefault("trending.review_weight", defaults.TrendingReviewWeight)
+  viper.SetDefault("trending.watchlist_weight", defaults.TrendingWatchlistWeight)
+  viper.SetDefault("trending.cache_ttl", defaults.TrendingCacheTTL)
+  viper.SetDefault("trending.view_buffer", defaults.TrendingViewBuffer)
+  viper.SetDefault("trending.view_flush_interval", defaults.TrendingViewFlushInterval)
+}
+
+func setupRanking() {
+  viper.SetDefault("ranking.size", defaults.RankingSize)
+  viper.SetDefault("ranking.min_votes", defaults.RankingMinVotes)
+  viper.SetDefault("ranking.recompute_interval", defaults.RankingRecomputeInterval)
+  viper.SetDefault("ranking.movement_period", defaults.RankingMovementPeriod)
+  viper.SetDefault("ranking.history_retention", defaults.RankingHistoryRetention)
+}
+
+func setupPremieres() {
+  viper.SetDefault("premieres.default_days", defaults.PremieresDefaultDays)
+  viper.SetDefault("premieres.max_days", defaults.PremieresMaxDays)
+  viper.SetDefault("premieres.coming_soon_days", defaults.PremieresComingSoonDays)
+  viper.SetDefault("premieres.coming_soon_country", defaults.PremieresComingSoonCountry)
+}
+
 func findEnvDir() (string, error) {
   log.Info().Msg("Finding environment dir")
   currentDir, err := os.Getwd()
@@ -128,6 +277,13 @@ func setupViper() error {
 
   setupServer()
   setupCookie()
+  setupCatalog()
+  setupSnapshot()
+  setupAdmin()
+  setupRecommendations()
+  setupTrending()
+  setupRanking()
+  setupPremieres()
 
   if err := viper.MergeInConfig(); err != nil {
     wrapped := errors.Wrap(err, errs.ErrReadConfig)
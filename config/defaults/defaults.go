@@ -25,3 +25,62 @@ const (
 	Path          = "/"
 	ExpirationAge = -1
 )
+
+// catalog constants
+const (
+	FixturesDir      = ""
+	GeneratorEnabled = false
+	GeneratorSeed    = 42
+	GeneratorMovies  = 20000
+	GeneratorPersons = 10000
+	GeneratorUsers   = 2000
+)
+
+// snapshot constants
+const (
+	SnapshotPath           = ""
+	SnapshotInterval       = time.Minute * 5
+	SnapshotRestoreOnStart = true
+)
+
+// recommendation constants
+const (
+	SimilarGenreWeight   = 3.0
+	SimilarStaffWeight   = 2.0
+	SimilarEraWeight     = 1.0
+	SimilarCountryWeight = 1.0
+	// SimilarEraSpan years
+	SimilarEraSpan = 10
+
+	PersonalRecomputeInterval = time.Minute * 10
+	PersonalColdStartRatings  = 10
+)
+
+// trending constants
+const (
+	TrendingViewWeight      = 1.0
+	TrendingRatingWeight    = 3.0
+	TrendingReviewWeight    = 5.0
+	TrendingWatchlistWeight = 4.0
+
+	TrendingCacheTTL          = time.Minute
+	TrendingViewBuffer        = 1024
+	TrendingViewFlushInterval = time.Second * 10
+)
+
+// ranking constants
+const (
+	RankingSize              = 250
+	RankingMinVotes          = 10
+	RankingRecomputeInterval = time.Hour
+	RankingMovementPeriod    = time.Hour * 24
+	RankingHistoryRetention  = time.Hour * 24 * 30
+)
+
+// premieres constants
+const (
+	PremieresDefaultDays       = 30
+	PremieresMaxDays           = 366
+	PremieresComingSoonDays    = 90
+	PremieresComingSoonCountry = "RU"
+)
@@ -30,6 +30,7 @@ const (
 	ErrBcrypt                        = "Error hashing password"
 	ErrSendJSON                      = "Error sending JSON"
 	ErrIncorrectLogin                = "user with this login does not exist"
+	ErrUserIDNotFound                = "user with this id does not exist"
 	ErrIncorrectPassword             = "provided password is incorrect"
 	ErrIncorrectLoginOrPassword      = "Incorrect login or password"
 	ErrIncorrectLoginOrPasswordShort = "not_found"
@@ -66,6 +67,65 @@ const (
 	ErrEmptyPassword    = "Empty password"
 )
 
+// validation/review
+const (
+	ErrEmptyReviewText    = "Empty review text"
+	ErrReviewTextTooShort = "Review text too short"
+	ErrReviewTextTooLong  = "Review text too long"
+	ErrInvalidScore       = "Score must be 1-10"
+)
+
+// validation/diary
+const (
+	ErrFutureWatchDate  = "Watch date is in the future"
+	ErrDiaryNoteTooLong = "Diary note too long"
+	ErrInvalidMonth     = "Month must be 1-12 and requires year"
+)
+
+// validation/collection
+const (
+	ErrEmptyListTitle         = "Empty list title"
+	ErrListTitleTooLong       = "List title too long"
+	ErrListDescriptionTooLong = "List description too long"
+	ErrListNoteTooLong        = "List note too long"
+	ErrInvalidVisibility      = "Visibility must be public, unlisted or private"
+	ErrTooManyListEntries     = "Too many movies in list"
+	ErrDuplicateListEntry     = "Movie is in list twice"
+	ErrEmptyCollectionName    = "Empty collection name"
+	ErrCollectionNameTooLong  = "Collection name too long"
+	ErrInvalidSlug            = "Slug must consist of latin letters, digits and hyphens and must not be a number"
+	ErrInvalidRuleSort        = "Rule sort must be rating, year, popularity or title"
+	ErrInvalidRuleLimit       = "Rule limit out of range"
+	ErrInvalidRuleRange       = "Rule range is empty"
+)
+
+// validation/movie
+const (
+	ErrEmptyMovieName         = "Empty movie name"
+	ErrMovieTextTooLong       = "Movie text field too long"
+	ErrInvalidReleaseYear     = "Release year out of range"
+	ErrInvalidDuration        = "Duration out of range"
+	ErrInvalidMovieRating     = "Rating must be 0-10"
+	ErrInvalidMoney           = "Money amount must not be negative and currency must be USD, EUR or RUB"
+	ErrInvalidURL             = "URL must be absolute http(s) or start with /"
+	ErrInvalidPremiereDate    = "Premiere date out of range"
+	ErrInvalidPremiereCountry = "Premiere country must be world or ISO 3166-1 alpha-2 code"
+	ErrDuplicatePremiere      = "Movie has two premieres in the same country"
+	ErrDuplicateID            = "Same id is given twice"
+	ErrInvalidStaffRole       = "Role must be actor, director, writer, producer, composer or operator"
+	ErrCharacterNotActor      = "Only actor may have character"
+	ErrDuplicateCredit        = "Person has the same role or character twice"
+)
+
+// validation/person
+const (
+	ErrEmptyPersonName   = "Empty person name"
+	ErrPersonTextTooLong = "Person text field too long"
+	ErrInvalidGrowth     = "Growth out of range"
+	ErrInvalidBirthday   = "Birthday is in the future"
+	ErrInvalidDeathDate  = "Death date is before birthday or in the future"
+)
+
 // tests
 const (
 	ErrWrongHeaders      = "Wrong headers"
@@ -84,12 +144,113 @@ const (
 	ErrMsgFailedToGetSession      = "failed to get session"
 )
 
+// importer
+const (
+	ErrReadManifest  = "Error reading fixtures manifest"
+	ErrReadFixture   = "Error reading fixture"
+	ErrImportCatalog = "Error importing catalog"
+)
+
+// generator
+const (
+	ErrWriteFixtures = "Error writing fixtures"
+	ErrSeedDataset   = "Error seeding dataset"
+)
+
+// backup
+const (
+	ErrMsgBackup       = "Error saving snapshot"
+	ErrMsgBackupShort  = "backup_error"
+	ErrMsgRestore      = "Error restoring snapshot"
+	ErrMsgRestoreShort = "restore_error"
+	ErrForbidden       = "Forbidden"
+	ErrForbiddenShort  = "forbidden"
+)
+
+// search
+const (
+	ErrEmptySearchQuery      = "Empty search query"
+	ErrEmptySearchQueryShort = "empty_query"
+)
+
+// review
+const (
+	ErrInvalidReview      = "Invalid review"
+	ErrInvalidReviewShort = "invalid_review"
+)
+
+// diary
+const (
+	ErrInvalidDiaryEntry      = "Invalid diary entry"
+	ErrInvalidDiaryEntryShort = "invalid_diary_entry"
+)
+
+// collection
+const (
+	ErrInvalidUserList        = "Invalid list"
+	ErrInvalidUserListShort   = "invalid_list"
+	ErrInvalidCollection      = "Invalid collection"
+	ErrInvalidCollectionShort = "invalid_collection"
+)
+
+// movie
+const (
+	ErrInvalidMovie      = "Invalid movie"
+	ErrInvalidMovieShort = "invalid_movie"
+)
+
+// person
+const (
+	ErrInvalidPerson      = "Invalid person"
+	ErrInvalidPersonShort = "invalid_person"
+)
+
 // error types
 var (
-	ErrPersonNotFound = errors.New("person by this id not found")
-	ErrMovieNotFound  = errors.New("movie by this id not found")
+	ErrPersonNotFound  = errors.New("person by this id not found")
+	ErrMergeSamePerson = errors.New("person can not be merged into itself")
+	ErrMovieNotFound   = errors.New("movie by this id not found")
+	ErrGenreNotFound   = errors.New("genre by this id not found")
+
+	ErrCollectionNotExist       = errors.New("collection does not exist")
+	ErrCollectionSlugTaken      = errors.New("collection with this slug already exists")
+	ErrInvalidCollectionRequest = errors.New("invalid collection request")
+	ErrSmartCollection          = errors.New("movies of smart collection are selected by its rule")
+	ErrBuiltinCollection        = errors.New("movies of built-in collection are computed by service")
+	ErrUserListNotFound         = errors.New("list by this id not found")
+	ErrNotListOwner             = errors.New("list belongs to other user")
+	ErrInvalidListRequest       = errors.New("invalid list request")
+
+	ErrUnsupportedFixtures = errors.New("unsupported fixtures version")
+	ErrAmbiguousFixture    = errors.New("fixture exists both in json and csv")
+	ErrInvalidFixtures     = errors.New("invalid fixtures")
+
+	ErrSnapshotDisabled    = errors.New("snapshot path is not configured")
+	ErrSnapshotNotFound    = errors.New("snapshot does not exist")
+	ErrUnsupportedSnapshot = errors.New("unsupported snapshot version")
+
+	ErrReviewExists   = errors.New("user has already reviewed this movie")
+	ErrReviewNotFound = errors.New("user has not reviewed this movie")
+	ErrRatingNotFound = errors.New("user has not rated this movie")
+	ErrReviewNotExist = errors.New("review by this id not found")
+
+	ErrOwnReviewReaction = errors.New("user can not react to own review")
+	ErrInvalidReaction   = errors.New("invalid reaction")
+	ErrReactionNotFound  = errors.New("user has not reacted to this review")
+
+	ErrInvalidCursor         = errors.New("invalid cursor")
+	ErrInvalidCatalogRequest = errors.New("invalid catalog request")
+	ErrInvalidReviewRequest  = errors.New("invalid review list request")
+
+	ErrInvalidWatchlistRequest = errors.New("invalid watchlist request")
+
+	ErrInvalidFilmographyRequest = errors.New("invalid filmography request")
+
+	ErrInvalidTrendingPeriod  = errors.New("invalid trending period")
+	ErrRankingNotFound        = errors.New("ranking has not been computed")
+	ErrInvalidPremiereRequest = errors.New("invalid release calendar request")
 
-	ErrCollectionNotExist = errors.New("collection does not exist")
+	ErrDiaryEntryNotFound = errors.New("diary entry by this id not found")
 
 	ErrGenerateSession  = errors.New(ErrMsgGenerateSession)
 	ErrSessionNotExists = errors.New(ErrMsgSessionNotExists)
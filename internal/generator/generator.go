@@ -0,0 +1,345 @@
+package generator
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/importer"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
+)
+
+const (
+	// IDOffset first id of generated movies, persons and users, so built-in catalog is not overwritten
+	IDOffset = 1000
+
+	// GeneratedPassword password of every generated user
+	GeneratedPassword = "password"
+
+	minReleaseYear  = 1930
+	maxReleaseYear  = 2025
+	minDuration     = 70
+	maxDuration     = 200
+	topCollectionSz = 10
+)
+
+// TopRatedCollection name of generated collection with best rated movies
+const TopRatedCollection = "Высокий рейтинг"
+
+// Options controls size and randomness of generated dataset
+type Options struct {
+	Seed            int64
+	Movies          int
+	Persons         int
+	Users           int
+	StaffPerMovie   int
+	ReviewsPerMovie int
+}
+
+// DefaultOptions returns options for a dataset suitable for load tests
+func DefaultOptions() Options {
+	return Options{
+		Seed:            42,
+		Movies:          20000,
+		Persons:         10000,
+		Users:           2000,
+		StaffPerMovie:   8,
+		ReviewsPerMovie: 5,
+	}
+}
+
+// Dataset generated catalog with users and their reviews
+type Dataset struct {
+	Catalog *importer.Catalog
+	Users   []models.User
+	// Reviews by movie id
+	Reviews map[int][]models.Review
+}
+
+type generator struct {
+	rnd  *rand.Rand
+	opts Options
+}
+
+// Generate builds dataset. The same options always produce the same dataset
+func Generate(opts Options) *Dataset {
+	g := &generator{
+		rnd:  rand.New(rand.NewSource(opts.Seed)),
+		opts: opts,
+	}
+
+	ds := &Dataset{
+		Catalog: &importer.Catalog{Manifest: importer.Manifest{Version: importer.FixturesVersion}},
+		Reviews: make(map[int][]models.Review),
+	}
+
+	ds.Catalog.Genres = g.genres()
+	ds.Catalog.Persons = g.persons()
+	ds.Users = g.users()
+	ds.Catalog.Movies, ds.Catalog.Staff = g.movies(ds.Catalog.Genres, ds.Catalog.Persons)
+	g.reviews(ds)
+	ds.Catalog.Collections = topRated(ds.Catalog.Movies)
+
+	return ds
+}
+
+func (g *generator) pick(n int) int {
+	return g.rnd.Intn(n)
+}
+
+func (g *generator) between(from, to int) int {
+	return from + g.rnd.Intn(to-from+1)
+}
+
+// genres returns built-in genres, so generated movies share ids with the rest of the catalog
+func (g *generator) genres() []importer.GenreFixture {
+	res := make([]importer.GenreFixture, 0, len(mocks.ExistingGenres))
+	for _, genre := range mocks.ExistingGenres {
+		res = append(res, importer.GenreFixture{
+			ID:            genre.ID,
+			Name:          genre.Name,
+			EnName:        genre.EnName,
+			Description:   genre.Description,
+			EnDescription: genre.EnDescription,
+		})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ID < res[j].ID
+	})
+	return res
+}
+
+func (g *generator) persons() []importer.PersonFixture {
+	res := make([]importer.PersonFixture, 0, g.opts.Persons)
+	for i := 0; i < g.opts.Persons; i++ {
+		female := g.rnd.Intn(3) == 0
+
+		var first namePair
+		sex := "Мужчина"
+		if female {
+			first = femaleFirstNames[g.pick(len(femaleFirstNames))]
+			sex = "Женщина"
+		} else {
+			first = maleFirstNames[g.pick(len(maleFirstNames))]
+		}
+
+		last := lastNames[g.pick(len(lastNames))]
+		ruLast := last.ru
+		if female {
+			ruLast = femaleLastName(ruLast)
+		}
+
+		birthday := time.Date(g.between(1920, 2010), time.Month(g.between(1, 12)), g.between(1, 28), 0, 0, 0, 0, time.UTC)
+		person := importer.PersonFixture{
+			ID:         IDOffset + i,
+			FullName:   first.ru + " " + ruLast,
+			EnFullName: first.en + " " + last.en,
+			Photo:      "/static/avatars/avatar_default_picture.svg",
+			About:      "Информация по этому человеку не указана",
+			Sex:        sex,
+			Growth:     g.between(150, 200),
+			Birthday:   birthday.Format(l10n.DateLayout),
+		}
+		if birthday.Year() < 1950 && g.rnd.Intn(2) == 0 {
+			death := birthday.AddDate(g.between(40, 90), g.between(0, 11), 0)
+			person.Death = death.Format(l10n.DateLayout)
+		}
+
+		res = append(res, person)
+	}
+	return res
+}
+
+func femaleLastName(name string) string {
+	for _, suffix := range []string{"ов", "ев", "ёв", "ин"} {
+		if strings.HasSuffix(name, suffix) {
+			return name + "а"
+		}
+	}
+	return name
+}
+
+func (g *generator) users() []models.User {
+	createdFrom := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	res := make([]models.User, 0, g.opts.Users)
+	for i := 0; i < g.opts.Users; i++ {
+		createdAt := createdFrom.Add(time.Duration(g.rnd.Int63n(int64(5 * 365 * 24 * time.Hour))))
+		res = append(res, models.User{
+			// reviews refer to users by id, so it is fixed instead of assigned by repository.
+			// It starts at offset not to take ids of authors of built-in reviews
+			ID:        IDOffset + i,
+			Username:  fmt.Sprintf("%s_%d", loginWords[g.pick(len(loginWords))], i),
+			Avatar:    "/static/avatars/avatar_default_picture.svg",
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		})
+	}
+	return res
+}
+
+func (g *generator) title() (string, string) {
+	adj := titleAdjectives[g.pick(len(titleAdjectives))]
+	noun := titleNouns[g.pick(len(titleNouns))]
+	suffix := titleSuffixes[g.pick(len(titleSuffixes))]
+
+	ru := adj.ru + " " + noun.ru
+	en := "The " + adj.en + " " + noun.en
+	if suffix.ru != "" {
+		sep := " "
+		if strings.HasPrefix(suffix.ru, ":") {
+			sep = ""
+		}
+		ru += sep + suffix.ru
+		en += sep + suffix.en
+	}
+	return ru, en
+}
+
+func (g *generator) movies(genres []importer.GenreFixture, persons []importer.PersonFixture) ([]importer.MovieFixture, []importer.StaffFixture) {
+	movies := make([]importer.MovieFixture, 0, g.opts.Movies)
+	staff := make([]importer.StaffFixture, 0, g.opts.Movies*g.opts.StaffPerMovie)
+
+	for i := 0; i < g.opts.Movies; i++ {
+		name, originalName := g.title()
+		budget := int64(g.between(1, 300)) * 1000000
+		year := g.between(minReleaseYear, maxReleaseYear)
+
+		movie := importer.MovieFixture{
+			ID:              IDOffset + i,
+			Name:            name,
+			OriginalName:    originalName,
+			About:           fmt.Sprintf("Фильм «%s» (%d).", name, year),
+			Poster:          fmt.Sprintf("/static/img/%d.webp", IDOffset+i),
+			ReleaseYear:     year,
+			Country:         countries[g.pick(len(countries))],
+			Slogan:          slogans[g.pick(len(slogans))],
+			Budget:          models.Money{Amount: budget, Currency: models.USD},
+			BoxOfficeGlobal: models.Money{Amount: budget * int64(g.between(0, 500)) / 100, Currency: models.USD},
+			Premieres: map[string]string{
+				models.PremiereWorld: time.Date(year, time.Month(g.between(1, 12)), g.between(1, 28), 0, 0, 0, 0, time.UTC).Format(l10n.DateLayout),
+			},
+			DurationMinutes: g.between(minDuration, maxDuration),
+		}
+
+		genreCount := g.between(1, 3)
+		for _, idx := range g.rnd.Perm(len(genres))[:genreCount] {
+			movie.GenreIDs = append(movie.GenreIDs, genres[idx].ID)
+		}
+		sort.Ints(movie.GenreIDs)
+
+		if len(persons) > 0 {
+			staffCount := g.opts.StaffPerMovie
+			if staffCount > len(persons) {
+				staffCount = len(persons)
+			}
+			used := make(map[int]struct{}, staffCount)
+			for len(used) < staffCount {
+				person := persons[g.pick(len(persons))]
+				if _, ok := used[person.ID]; ok {
+					continue
+				}
+				// the first person directs movie, the rest play in it
+				role := models.RoleActor
+				if len(used) == 0 {
+					role = models.RoleDirector
+				}
+				used[person.ID] = struct{}{}
+				staff = append(staff, importer.StaffFixture{MovieID: movie.ID, PersonID: person.ID, Role: role})
+			}
+		}
+
+		movies = append(movies, movie)
+	}
+
+	return movies, staff
+}
+
+func (g *generator) score(quality float64) int {
+	score := int(quality + g.rnd.NormFloat64()*1.5 + 0.5)
+	if score < 1 {
+		return 1
+	}
+	if score > 10 {
+		return 10
+	}
+	return score
+}
+
+func reviewText(score int) []string {
+	switch {
+	case score >= 8:
+		return reviewTexts[8]
+	case score >= 5:
+		return reviewTexts[5]
+	default:
+		return reviewTexts[1]
+	}
+}
+
+func (g *generator) reviews(ds *Dataset) {
+	if len(ds.Users) == 0 {
+		return
+	}
+
+	reviewID := 1
+	for i := range ds.Catalog.Movies {
+		movie := &ds.Catalog.Movies[i]
+		// every movie has its own quality so ratings are spread realistically
+		quality := 3 + g.rnd.Float64()*6
+
+		count := g.between(0, 2*g.opts.ReviewsPerMovie)
+		if count > len(ds.Users) {
+			count = len(ds.Users)
+		}
+
+		total := 0
+		for _, userIdx := range g.rnd.Perm(len(ds.Users))[:count] {
+			user := ds.Users[userIdx]
+			score := g.score(quality)
+			texts := reviewText(score)
+
+			createdAt := time.Date(movie.ReleaseYear, 1, 1, 0, 0, 0, 0, time.UTC)
+			if createdAt.Before(user.CreatedAt) {
+				createdAt = user.CreatedAt
+			}
+			createdAt = createdAt.AddDate(0, 0, g.between(0, 365))
+
+			ds.Reviews[movie.ID] = append(ds.Reviews[movie.ID], models.Review{
+				ID:        reviewID,
+				User:      models.ReviewAuthor{ID: user.ID, Login: user.Username, Avatar: user.Avatar},
+				Text:      texts[g.pick(len(texts))],
+				Score:     score,
+				CreatedAt: createdAt,
+			})
+			reviewID++
+			total += score
+		}
+
+		if count > 0 {
+			movie.Rating = float64(total*10/count) / 10
+		}
+	}
+}
+
+func topRated(movies []importer.MovieFixture) []importer.CollectionFixture {
+	sorted := make([]importer.MovieFixture, len(movies))
+	copy(sorted, movies)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Rating > sorted[j].Rating
+	})
+
+	if len(sorted) > topCollectionSz {
+		sorted = sorted[:topCollectionSz]
+	}
+
+	res := make([]importer.CollectionFixture, 0, len(sorted))
+	for i, movie := range sorted {
+		res = append(res, importer.CollectionFixture{Collection: TopRatedCollection, Position: i, MovieID: movie.ID})
+	}
+	return res
+}
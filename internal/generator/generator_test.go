@@ -0,0 +1,98 @@
+package generator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/importer"
+	repoCollection "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/collection/repository"
+	repoGenre "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/genre/repository"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	repoMovie "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/repository"
+	repoStaff "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/staff_person/repository"
+	repoUsers "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/user/repository"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/validation/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func smallOptions(seed int64) Options {
+	return Options{Seed: seed, Movies: 200, Persons: 100, Users: 50, StaffPerMovie: 5, ReviewsPerMovie: 3}
+}
+
+func TestGenerate_Deterministic(t *testing.T) {
+	first := Generate(smallOptions(1))
+	second := Generate(smallOptions(1))
+	other := Generate(smallOptions(2))
+
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first.Catalog.Movies, other.Catalog.Movies)
+}
+
+func TestGenerate_Valid(t *testing.T) {
+	ds := Generate(smallOptions(7))
+
+	require.NoError(t, importer.Validate(ds.Catalog))
+	assert.Len(t, ds.Catalog.Movies, 200)
+	assert.Len(t, ds.Catalog.Persons, 100)
+	assert.Len(t, ds.Catalog.Staff, 200*5)
+	assert.Len(t, ds.Users, 50)
+	assert.Len(t, ds.Catalog.Collections, topCollectionSz)
+
+	directors := make(map[int]int)
+	for _, link := range ds.Catalog.Staff {
+		if link.Role == models.RoleDirector {
+			directors[link.MovieID]++
+		}
+	}
+	for _, movie := range ds.Catalog.Movies {
+		assert.Equal(t, 1, directors[movie.ID], "movie %d", movie.ID)
+	}
+
+	for _, user := range ds.Users {
+		assert.NoError(t, auth.IsValidLogin(user.Username), user.Username)
+	}
+	for movieID, reviews := range ds.Reviews {
+		for _, review := range reviews {
+			assert.True(t, review.Score >= 1 && review.Score <= 10, "movie %d", movieID)
+		}
+	}
+}
+
+func TestDataset_WriteFixtures(t *testing.T) {
+	ds := Generate(smallOptions(3))
+	dir := t.TempDir()
+
+	require.NoError(t, ds.WriteFixtures(dir))
+
+	catalog, err := importer.Load(dir)
+	require.NoError(t, err)
+	assert.Equal(t, ds.Catalog, catalog)
+}
+
+func TestDataset_Seed(t *testing.T) {
+	ctx := context.Background()
+	ds := Generate(smallOptions(5))
+
+	movies := mocks.Movies{}
+	persons := mocks.Persons{}
+	collections := mocks.Collections{}
+	genres := mocks.Genres{}
+	movieRepo := repoMovie.NewMovieRepository(&movies)
+	userRepo := repoUsers.NewUserRepository()
+	im := importer.New(repoGenre.NewGenreRepository(&genres), movieRepo, repoStaff.NewStaffPersonRepository(&persons),
+		repoCollection.NewCollectionRepository(&collections))
+
+	require.NoError(t, ds.Seed(ctx, im, movieRepo, userRepo))
+	assert.Len(t, movies, 200)
+	assert.Len(t, persons, 100)
+	assert.Equal(t, mocks.ExistingGenres, genres)
+
+	_, err := userRepo.GetUser(ctx, ds.Users[0].Username)
+	assert.NoError(t, err)
+
+	for movieID, reviews := range ds.Reviews {
+		assert.Equal(t, reviews, movies[movieID].Reviews)
+	}
+}
@@ -0,0 +1,98 @@
+package generator
+
+// name pairs keep russian and english spelling of the same person consistent
+type namePair struct {
+	ru string
+	en string
+}
+
+var maleFirstNames = []namePair{
+	{"Александр", "Alexander"}, {"Алексей", "Alexey"}, {"Андрей", "Andrey"}, {"Артём", "Artem"},
+	{"Борис", "Boris"}, {"Вадим", "Vadim"}, {"Виктор", "Victor"}, {"Владимир", "Vladimir"},
+	{"Георгий", "Georgy"}, {"Дмитрий", "Dmitry"}, {"Евгений", "Evgeny"}, {"Иван", "Ivan"},
+	{"Игорь", "Igor"}, {"Кирилл", "Kirill"}, {"Михаил", "Mikhail"}, {"Никита", "Nikita"},
+	{"Олег", "Oleg"}, {"Павел", "Pavel"}, {"Сергей", "Sergey"}, {"Тимофей", "Timofey"},
+	{"Джон", "John"}, {"Джеймс", "James"}, {"Роберт", "Robert"}, {"Майкл", "Michael"},
+	{"Уильям", "William"}, {"Дэвид", "David"}, {"Ричард", "Richard"}, {"Томас", "Thomas"},
+	{"Кристофер", "Christopher"}, {"Дэниел", "Daniel"}, {"Мэттью", "Matthew"}, {"Энтони", "Anthony"},
+}
+
+var femaleFirstNames = []namePair{
+	{"Анна", "Anna"}, {"Алина", "Alina"}, {"Валерия", "Valeria"}, {"Дарья", "Darya"},
+	{"Екатерина", "Ekaterina"}, {"Елена", "Elena"}, {"Ирина", "Irina"}, {"Ксения", "Ksenia"},
+	{"Мария", "Maria"}, {"Наталья", "Natalya"}, {"Ольга", "Olga"}, {"Полина", "Polina"},
+	{"Светлана", "Svetlana"}, {"Татьяна", "Tatyana"}, {"Юлия", "Yulia"}, {"Вера", "Vera"},
+	{"Мэри", "Mary"}, {"Патриция", "Patricia"}, {"Дженнифер", "Jennifer"}, {"Линда", "Linda"},
+	{"Элизабет", "Elizabeth"}, {"Барбара", "Barbara"}, {"Сьюзан", "Susan"}, {"Джессика", "Jessica"},
+	{"Сара", "Sarah"}, {"Карен", "Karen"}, {"Эмма", "Emma"}, {"Оливия", "Olivia"},
+}
+
+// last names are stored in male form, russian female form is derived by suffix
+var lastNames = []namePair{
+	{"Иванов", "Ivanov"}, {"Смирнов", "Smirnov"}, {"Кузнецов", "Kuznetsov"}, {"Попов", "Popov"},
+	{"Васильев", "Vasiliev"}, {"Петров", "Petrov"}, {"Соколов", "Sokolov"}, {"Михайлов", "Mikhailov"},
+	{"Новиков", "Novikov"}, {"Фёдоров", "Fedorov"}, {"Морозов", "Morozov"}, {"Волков", "Volkov"},
+	{"Алексеев", "Alekseev"}, {"Лебедев", "Lebedev"}, {"Семёнов", "Semenov"}, {"Егоров", "Egorov"},
+	{"Смит", "Smith"}, {"Джонсон", "Johnson"}, {"Уильямс", "Williams"}, {"Браун", "Brown"},
+	{"Джонс", "Jones"}, {"Миллер", "Miller"}, {"Дэвис", "Davis"}, {"Уилсон", "Wilson"},
+	{"Андерсон", "Anderson"}, {"Тейлор", "Taylor"}, {"Томас", "Thomas"}, {"Мур", "Moore"},
+	{"Джексон", "Jackson"}, {"Мартин", "Martin"}, {"Томпсон", "Thompson"}, {"Уайт", "White"},
+}
+
+var titleAdjectives = []namePair{
+	{"Тёмный", "Dark"}, {"Последний", "Last"}, {"Красный", "Red"}, {"Тихий", "Silent"},
+	{"Золотой", "Golden"}, {"Забытый", "Forgotten"}, {"Далёкий", "Distant"}, {"Холодный", "Cold"},
+	{"Вечный", "Eternal"}, {"Ночной", "Night"}, {"Стальной", "Steel"}, {"Бесконечный", "Endless"},
+	{"Потерянный", "Lost"}, {"Дикий", "Wild"}, {"Белый", "White"}, {"Сломанный", "Broken"},
+}
+
+var titleNouns = []namePair{
+	{"город", "City"}, {"рыцарь", "Knight"}, {"горизонт", "Horizon"}, {"берег", "Shore"},
+	{"сигнал", "Signal"}, {"маршрут", "Route"}, {"остров", "Island"}, {"лабиринт", "Labyrinth"},
+	{"свидетель", "Witness"}, {"клуб", "Club"}, {"патруль", "Patrol"}, {"экспресс", "Express"},
+	{"код", "Code"}, {"рубеж", "Frontier"}, {"сад", "Garden"}, {"шторм", "Storm"},
+}
+
+var titleSuffixes = []namePair{
+	{"", ""}, {"", ""}, {"", ""}, {"2", "2"}, {"3", "3"},
+	{": Возвращение", ": The Return"}, {": Начало", ": Origins"}, {": Наследие", ": Legacy"},
+}
+
+var countries = []string{
+	"США", "Россия", "СССР", "Великобритания", "Франция", "Германия", "Италия", "Япония",
+	"Южная Корея", "Испания", "Канада", "Индия", "Китай", "Швеция", "Австралия",
+}
+
+var slogans = []string{
+	"Некоторые истории лучше не рассказывать",
+	"Правда где-то рядом",
+	"Каждый выбор имеет цену",
+	"Один шанс. Одна ночь",
+	"Прошлое всегда возвращается",
+	"Он не искал славы",
+	"Никто не уйдёт просто так",
+	"",
+}
+
+var reviewTexts = map[int][]string{
+	// keys are score ranges lower bounds: 1..4, 5..7, 8..10
+	1: {
+		"Скучно и предсказуемо, еле досмотрел до конца.",
+		"Слабый сценарий и невыразительная игра актёров.",
+		"Не понимаю восторгов, потраченное впустую время.",
+	},
+	5: {
+		"Неплохо, но ничего выдающегося. Один раз посмотреть можно.",
+		"Хорошая идея, но местами затянуто.",
+		"Интересный фильм с неровным темпом повествования.",
+	},
+	8: {
+		"Отличный фильм! Буду пересматривать.",
+		"Потрясающая атмосфера и сильная актёрская игра.",
+		"Один из лучших фильмов, что я видел за последнее время.",
+	},
+}
+
+var loginWords = []string{
+	"kino", "film", "movie", "critic", "viewer", "fan", "cinema", "night", "popcorn", "screen",
+}
@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/importer"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type UserRepositoryInterface interface {
+	CreateUser(ctx context.Context, user *models.User) error
+}
+
+type MovieRepositoryInterface interface {
+	GetMovieFromRepoByID(ctx context.Context, movieID int) (*models.Movie, error)
+	UpsertMovie(ctx context.Context, movie models.Movie) error
+}
+
+// WriteFixtures writes catalog part of dataset in importer json format.
+// Users and reviews are runtime data and are written only by Seed
+func (ds *Dataset) WriteFixtures(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return errors.Wrap(err, errs.ErrWriteFixtures)
+	}
+
+	files := map[string]interface{}{
+		"manifest.json":    ds.Catalog.Manifest,
+		"genres.json":      ds.Catalog.Genres,
+		"persons.json":     ds.Catalog.Persons,
+		"movies.json":      ds.Catalog.Movies,
+		"staff.json":       ds.Catalog.Staff,
+		"collections.json": ds.Catalog.Collections,
+	}
+
+	for name, data := range files {
+		content, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, errs.ErrWriteFixtures)
+		}
+		if err = os.WriteFile(filepath.Join(dir, name), content, 0o644); err != nil {
+			return errors.Wrap(err, errs.ErrWriteFixtures)
+		}
+	}
+
+	return nil
+}
+
+// Seed writes whole dataset directly into repositories
+func (ds *Dataset) Seed(ctx context.Context, catalogImporter *importer.Importer,
+	movieRepo MovieRepositoryInterface, userRepo UserRepositoryInterface) error {
+	logger := log.Ctx(ctx)
+
+	if _, err := catalogImporter.Import(ctx, ds.Catalog, false); err != nil {
+		return errors.Wrap(err, errs.ErrSeedDataset)
+	}
+
+	// hashing is slow, so every generated user shares the same cheap hash
+	hashedPass, err := bcrypt.GenerateFromPassword([]byte(GeneratedPassword), bcrypt.MinCost)
+	if err != nil {
+		return errors.Wrap(err, errs.ErrSeedDataset)
+	}
+
+	for i := range ds.Users {
+		user := ds.Users[i]
+		user.HashedPassword = string(hashedPass)
+		if err = userRepo.CreateUser(ctx, &user); err != nil {
+			return errors.Wrapf(err, "%s: user %s", errs.ErrSeedDataset, user.Username)
+		}
+	}
+
+	for movieID, reviews := range ds.Reviews {
+		movie, err := movieRepo.GetMovieFromRepoByID(ctx, movieID)
+		if err != nil {
+			return errors.Wrap(err, errs.ErrSeedDataset)
+		}
+		movie.Reviews = reviews
+		if err = movieRepo.UpsertMovie(ctx, *movie); err != nil {
+			return errors.Wrap(err, errs.ErrSeedDataset)
+		}
+	}
+
+	logger.Info().Msgf("seeded %d movies, %d persons, %d users", len(ds.Catalog.Movies), len(ds.Catalog.Persons), len(ds.Users))
+	return nil
+}
@@ -0,0 +1,269 @@
+package importer
+
+import (
+	"encoding/csv"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/pkg/errors"
+)
+
+const (
+	// listSeparator separates values inside one csv cell, e.g. genre ids "1;2"
+	listSeparator = ";"
+	// keySeparator separates key from value inside list item, e.g. premieres "world=1999-09-10;RU=2000-01-13"
+	keySeparator = "="
+)
+
+// csvRecord one csv row accessed by header names
+type csvRecord struct {
+	line   int
+	values map[string]string
+}
+
+func readCSVFile(path string) ([]csvRecord, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	header := rows[0]
+	records := make([]csvRecord, 0, len(rows)-1)
+	for i, row := range rows[1:] {
+		values := make(map[string]string, len(header))
+		for j, column := range header {
+			values[strings.TrimSpace(column)] = row[j]
+		}
+		records = append(records, csvRecord{line: i + 2, values: values})
+	}
+
+	return records, nil
+}
+
+func (r csvRecord) str(column string) string {
+	return r.values[column]
+}
+
+func (r csvRecord) int(column string) (int, error) {
+	val := strings.TrimSpace(r.values[column])
+	if val == "" {
+		return 0, nil
+	}
+
+	res, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, errors.Wrapf(err, "line %d column %s", r.line, column)
+	}
+	return res, nil
+}
+
+// money parses "<amount> <currency>" cell, e.g. "63000000 USD"
+func (r csvRecord) money(column string) (models.Money, error) {
+	val := strings.TrimSpace(r.values[column])
+	if val == "" {
+		return models.Money{}, nil
+	}
+
+	parts := strings.Fields(val)
+	if len(parts) != 2 {
+		return models.Money{}, errors.Errorf("line %d column %s: money must be \"<amount> <currency>\"", r.line, column)
+	}
+
+	amount, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return models.Money{}, errors.Wrapf(err, "line %d column %s", r.line, column)
+	}
+	return models.Money{Amount: amount, Currency: models.Currency(strings.ToUpper(parts[1]))}, nil
+}
+
+func (r csvRecord) float(column string) (float64, error) {
+	val := strings.TrimSpace(r.values[column])
+	if val == "" {
+		return 0, nil
+	}
+
+	res, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "line %d column %s", r.line, column)
+	}
+	return res, nil
+}
+
+func (r csvRecord) ints(column string) ([]int, error) {
+	val := strings.TrimSpace(r.values[column])
+	if val == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(val, listSeparator)
+	res := make([]int, 0, len(parts))
+	for _, part := range parts {
+		id, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d column %s", r.line, column)
+		}
+		res = append(res, id)
+	}
+	return res, nil
+}
+
+// strMap parses "<key>=<value>" list cell
+func (r csvRecord) strMap(column string) (map[string]string, error) {
+	val := strings.TrimSpace(r.values[column])
+	if val == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(val, listSeparator)
+	res := make(map[string]string, len(parts))
+	for _, part := range parts {
+		key, value, ok := strings.Cut(part, keySeparator)
+		if !ok {
+			return nil, errors.Errorf("line %d column %s: item must be \"<key>%s<value>\"", r.line, column, keySeparator)
+		}
+		res[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return res, nil
+}
+
+func parseGenresCSV(records []csvRecord) ([]GenreFixture, error) {
+	res := make([]GenreFixture, 0, len(records))
+	for _, rec := range records {
+		id, err := rec.int("id")
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, GenreFixture{
+			ID:            id,
+			Name:          rec.str("name"),
+			EnName:        rec.str("en_name"),
+			Description:   rec.str("description"),
+			EnDescription: rec.str("en_description"),
+		})
+	}
+	return res, nil
+}
+
+func parsePersonsCSV(records []csvRecord) ([]PersonFixture, error) {
+	res := make([]PersonFixture, 0, len(records))
+	for _, rec := range records {
+		id, err := rec.int("id")
+		if err != nil {
+			return nil, err
+		}
+		growth, err := rec.int("growth")
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, PersonFixture{
+			ID:         id,
+			FullName:   rec.str("full_name"),
+			EnFullName: rec.str("en_full_name"),
+			Photo:      rec.str("photo"),
+			About:      rec.str("about"),
+			Sex:        rec.str("sex"),
+			Growth:     growth,
+			Birthday:   rec.str("birthday"),
+			Death:      rec.str("death"),
+		})
+	}
+	return res, nil
+}
+
+func parseMoviesCSV(records []csvRecord) ([]MovieFixture, error) {
+	res := make([]MovieFixture, 0, len(records))
+	for _, rec := range records {
+		movie := MovieFixture{
+			Name:         rec.str("name"),
+			OriginalName: rec.str("original_name"),
+			About:        rec.str("about"),
+			Poster:       rec.str("poster"),
+			Country:      rec.str("country"),
+			Slogan:       rec.str("slogan"),
+			Director:     rec.str("director"),
+		}
+
+		var err error
+		if movie.ID, err = rec.int("id"); err != nil {
+			return nil, err
+		}
+		if movie.ReleaseYear, err = rec.int("release_year"); err != nil {
+			return nil, err
+		}
+		if movie.Budget, err = rec.money("budget"); err != nil {
+			return nil, err
+		}
+		if movie.BoxOfficeUS, err = rec.money("box_office_us"); err != nil {
+			return nil, err
+		}
+		if movie.BoxOfficeGlobal, err = rec.money("box_office_global"); err != nil {
+			return nil, err
+		}
+		if movie.BoxOfficeRussia, err = rec.money("box_office_russia"); err != nil {
+			return nil, err
+		}
+		if movie.DurationMinutes, err = rec.int("duration_minutes"); err != nil {
+			return nil, err
+		}
+		if movie.Rating, err = rec.float("rating"); err != nil {
+			return nil, err
+		}
+		if movie.GenreIDs, err = rec.ints("genre_ids"); err != nil {
+			return nil, err
+		}
+		if movie.Premieres, err = rec.strMap("premieres"); err != nil {
+			return nil, err
+		}
+
+		res = append(res, movie)
+	}
+	return res, nil
+}
+
+func parseStaffCSV(records []csvRecord) ([]StaffFixture, error) {
+	res := make([]StaffFixture, 0, len(records))
+	for _, rec := range records {
+		movieID, err := rec.int("movie_id")
+		if err != nil {
+			return nil, err
+		}
+		personID, err := rec.int("person_id")
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, StaffFixture{
+			MovieID:   movieID,
+			PersonID:  personID,
+			Role:      models.StaffRole(rec.str("role")),
+			Character: rec.str("character"),
+		})
+	}
+	return res, nil
+}
+
+func parseCollectionsCSV(records []csvRecord) ([]CollectionFixture, error) {
+	res := make([]CollectionFixture, 0, len(records))
+	for _, rec := range records {
+		position, err := rec.int("position")
+		if err != nil {
+			return nil, err
+		}
+		movieID, err := rec.int("movie_id")
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, CollectionFixture{Collection: rec.str("collection"), Position: position, MovieID: movieID})
+	}
+	return res, nil
+}
@@ -0,0 +1,130 @@
+package importer
+
+import (
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
+)
+
+// FixturesVersion is the current catalog fixtures format version, older fixtures are upgraded to it on load
+const FixturesVersion = 3
+
+// fixture file names without extension, each of them may be stored as .json or .csv
+const (
+	manifestFile    = "manifest.json"
+	genresFile      = "genres"
+	personsFile     = "persons"
+	moviesFile      = "movies"
+	staffFile       = "staff"
+	collectionsFile = "collections"
+)
+
+// Manifest describes fixtures directory
+type Manifest struct {
+	Version int `json:"version"`
+}
+
+// GenreFixture genre record with russian and english texts
+type GenreFixture struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	EnName        string `json:"en_name,omitempty"`
+	Description   string `json:"description,omitempty"`
+	EnDescription string `json:"en_description,omitempty"`
+}
+
+// PersonFixture staff person record, dates are ISO-8601 "YYYY-MM-DD"
+type PersonFixture struct {
+	ID         int    `json:"id"`
+	FullName   string `json:"full_name"`
+	EnFullName string `json:"en_full_name,omitempty"`
+	Photo      string `json:"photo,omitempty"`
+	About      string `json:"about,omitempty"`
+	Sex        string `json:"sex,omitempty"`
+	Growth     int    `json:"growth,omitempty"` // centimeters
+	Birthday   string `json:"birthday,omitempty"`
+	Death      string `json:"death,omitempty"`
+}
+
+// MovieFixture movie record, genres are referenced by id.
+// Money is {"amount": 63000000, "currency": "USD"}, premieres are ISO-8601 "YYYY-MM-DD" dates by country,
+// e.g. {"world": "1999-09-10", "RU": "2000-01-13"}.
+// Director is left for older fixtures, it becomes director credit of person with the same full_name
+// unless movie has director in staff fixture
+type MovieFixture struct {
+	ID              int               `json:"id"`
+	Name            string            `json:"name"`
+	OriginalName    string            `json:"original_name,omitempty"`
+	About           string            `json:"about,omitempty"`
+	Poster          string            `json:"poster,omitempty"`
+	ReleaseYear     int               `json:"release_year,omitempty"`
+	Country         string            `json:"country,omitempty"`
+	Slogan          string            `json:"slogan,omitempty"`
+	Director        string            `json:"director,omitempty"`
+	Budget          models.Money      `json:"budget,omitzero"`
+	BoxOfficeUS     models.Money      `json:"box_office_us,omitzero"`
+	BoxOfficeGlobal models.Money      `json:"box_office_global,omitzero"`
+	BoxOfficeRussia models.Money      `json:"box_office_russia,omitzero"`
+	Premieres       map[string]string `json:"premieres,omitempty"`
+	Rating          float64           `json:"rating,omitempty"`
+	DurationMinutes int               `json:"duration_minutes,omitempty"`
+	GenreIDs        []int             `json:"genre_ids,omitempty"`
+}
+
+// premieres returns premieres ordered by date, invalid dates are skipped as they are reported by Validate
+func (f MovieFixture) premieres() []models.Premiere {
+	var res []models.Premiere
+	for country, value := range f.Premieres {
+		if date, err := parseDate(value); err == nil && date != nil {
+			res = append(res, models.Premiere{Country: country, Date: *date})
+		}
+	}
+	models.SortPremieres(res)
+	return res
+}
+
+// StaffFixture links person to movie in role, role defaults to actor
+type StaffFixture struct {
+	MovieID   int              `json:"movie_id"`
+	PersonID  int              `json:"person_id"`
+	Role      models.StaffRole `json:"role,omitempty"`
+	Character string           `json:"character,omitempty"`
+}
+
+func (f StaffFixture) role() models.StaffRole {
+	if f.Role == "" {
+		return models.RoleActor
+	}
+	return f.Role
+}
+
+// CollectionFixture places movie in named collection at given position
+type CollectionFixture struct {
+	Collection string `json:"collection"`
+	Position   int    `json:"position"`
+	MovieID    int    `json:"movie_id"`
+}
+
+// Catalog all data loaded from fixtures directory
+type Catalog struct {
+	Manifest    Manifest
+	Genres      []GenreFixture
+	Persons     []PersonFixture
+	Movies      []MovieFixture
+	Staff       []StaffFixture
+	Collections []CollectionFixture
+}
+
+// parseDate parses optional fixture date, empty string means date is unknown
+func parseDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse(l10n.DateLayout, value)
+	if err != nil {
+		return nil, err
+	}
+	return &date, nil
+}
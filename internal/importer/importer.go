@@ -0,0 +1,267 @@
+package importer
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strconv"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type MovieRepositoryInterface interface {
+	GetStoredMovie(ctx context.Context, movieID int) (*models.Movie, error)
+	UpsertMovie(ctx context.Context, movie models.Movie) error
+}
+
+type GenreRepositoryInterface interface {
+	GetGenreByID(ctx context.Context, genreID int) (*models.Genre, error)
+	UpsertGenre(ctx context.Context, genre models.Genre) error
+}
+
+type StaffPersonRepositoryInterface interface {
+	GetPersonFromRepoByID(ctx context.Context, personID int) (*models.Person, error)
+	UpsertPerson(ctx context.Context, person models.Person) error
+}
+
+type CollectionRepositoryInterface interface {
+	GetCollections(ctx context.Context) ([]models.Collection, error)
+	UpsertCollection(ctx context.Context, collection models.Collection) error
+}
+
+// Importer upserts catalog fixtures into repositories
+type Importer struct {
+	genreRepo      GenreRepositoryInterface
+	movieRepo      MovieRepositoryInterface
+	personRepo     StaffPersonRepositoryInterface
+	collectionRepo CollectionRepositoryInterface
+}
+
+// New returns new instance of Importer
+func New(genreRepo GenreRepositoryInterface, movieRepo MovieRepositoryInterface, personRepo StaffPersonRepositoryInterface,
+	collectionRepo CollectionRepositoryInterface) *Importer {
+	return &Importer{
+		genreRepo:      genreRepo,
+		movieRepo:      movieRepo,
+		personRepo:     personRepo,
+		collectionRepo: collectionRepo,
+	}
+}
+
+// Import validates catalog and upserts it into repositories. With dryRun nothing is written,
+// but returned report still describes what would have changed
+func (im *Importer) Import(ctx context.Context, catalog *Catalog, dryRun bool) (*Report, error) {
+	logger := log.Ctx(ctx)
+
+	if err := Validate(catalog); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	report := &Report{DryRun: dryRun}
+
+	for _, fixture := range catalog.Genres {
+		genre := genreFromFixture(fixture)
+
+		existing, err := im.genreRepo.GetGenreByID(ctx, genre.ID)
+		if err != nil && !errors.Is(err, errs.ErrGenreNotFound) {
+			return nil, errors.Wrap(err, errs.ErrImportCatalog)
+		}
+
+		change := report.Genres.track(strconv.Itoa(genre.ID), existing != nil, existing != nil && *existing == genre)
+		if dryRun || change == changeUnchanged {
+			continue
+		}
+		if err = im.genreRepo.UpsertGenre(ctx, genre); err != nil {
+			return nil, errors.Wrap(err, errs.ErrImportCatalog)
+		}
+	}
+
+	persons := make(map[int]models.Person, len(catalog.Persons))
+	for _, fixture := range catalog.Persons {
+		person := personFromFixture(fixture)
+		persons[person.ID] = person
+
+		existing, err := im.personRepo.GetPersonFromRepoByID(ctx, person.ID)
+		if err != nil && !errors.Is(err, errs.ErrPersonNotFound) {
+			return nil, errors.Wrap(err, errs.ErrImportCatalog)
+		}
+
+		change := report.Persons.track(strconv.Itoa(person.ID), existing != nil, existing != nil && reflect.DeepEqual(*existing, person))
+		if dryRun || change == changeUnchanged {
+			continue
+		}
+		if err = im.personRepo.UpsertPerson(ctx, person); err != nil {
+			return nil, errors.Wrap(err, errs.ErrImportCatalog)
+		}
+	}
+
+	for _, movie := range buildMovies(catalog, persons) {
+		existing, err := im.movieRepo.GetStoredMovie(ctx, movie.ID)
+		if err != nil && !errors.Is(err, errs.ErrMovieNotFound) {
+			return nil, errors.Wrap(err, errs.ErrImportCatalog)
+		}
+		// movie deleted by admin is not brought back by fixtures
+		if existing != nil && existing.IsDeleted() {
+			report.Movies.Skipped = append(report.Movies.Skipped, strconv.Itoa(movie.ID))
+			continue
+		}
+		if existing != nil {
+			// reviews are written by users and are not part of the catalog
+			movie.Reviews = existing.Reviews
+		}
+		if len(movie.Reviews) > 0 {
+			// rating from fixtures is kept only until users review movie
+			movie.UpdateRating()
+		}
+
+		change := report.Movies.track(strconv.Itoa(movie.ID), existing != nil, existing != nil && reflect.DeepEqual(*existing, movie))
+		if dryRun || change == changeUnchanged {
+			continue
+		}
+		if err = im.movieRepo.UpsertMovie(ctx, movie); err != nil {
+			return nil, errors.Wrap(err, errs.ErrImportCatalog)
+		}
+	}
+
+	collections, err := im.collectionRepo.GetCollections(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, errs.ErrImportCatalog)
+	}
+	existingCollections := make(map[string]models.Collection, len(collections))
+	for _, collection := range collections {
+		existingCollections[collection.Name] = collection
+	}
+
+	for _, collection := range buildCollections(catalog) {
+		existing, ok := existingCollections[collection.Name]
+
+		// editors may change everything but movies of imported collection
+		change := report.Collections.track(collection.Name, ok, ok && reflect.DeepEqual(existing.Entries, collection.Entries))
+		if dryRun || change == changeUnchanged {
+			continue
+		}
+		if err = im.collectionRepo.UpsertCollection(ctx, collection); err != nil {
+			return nil, errors.Wrap(err, errs.ErrImportCatalog)
+		}
+	}
+
+	report.sort()
+	logger.Info().Msg(report.String())
+	return report, nil
+}
+
+func genreFromFixture(fixture GenreFixture) models.Genre {
+	return models.Genre{
+		ID:            fixture.ID,
+		Name:          fixture.Name,
+		EnName:        fixture.EnName,
+		Description:   fixture.Description,
+		EnDescription: fixture.EnDescription,
+	}
+}
+
+// personFromFixture expects fixture to be validated, so dates are well-formed
+func personFromFixture(fixture PersonFixture) models.Person {
+	birthday, _ := parseDate(fixture.Birthday)
+	death, _ := parseDate(fixture.Death)
+
+	return models.Person{
+		ID:         fixture.ID,
+		FullName:   fixture.FullName,
+		EnFullName: fixture.EnFullName,
+		Photo:      fixture.Photo,
+		About:      fixture.About,
+		Sex:        fixture.Sex,
+		Growth:     fixture.Growth,
+		Birthday:   birthday,
+		Death:      death,
+	}
+}
+
+func buildMovies(catalog *Catalog, persons map[int]models.Person) []models.Movie {
+	genres := make(map[int]models.Genre, len(catalog.Genres))
+	for _, genre := range catalog.Genres {
+		// movie page needs only genre names
+		genres[genre.ID] = models.Genre{ID: genre.ID, Name: genre.Name, EnName: genre.EnName}
+	}
+
+	staff := make(map[int][]models.StaffMember)
+	directors := make(map[int]bool)
+	for _, link := range catalog.Staff {
+		// movie page needs only short person info
+		staff[link.MovieID] = append(staff[link.MovieID], models.StaffMember{
+			Person:    persons[link.PersonID].Short(),
+			Role:      link.role(),
+			Character: link.Character,
+		})
+		if link.role() == models.RoleDirector {
+			directors[link.MovieID] = true
+		}
+	}
+
+	byName := make(map[string]models.Person, len(persons))
+	for _, person := range persons {
+		byName[person.FullName] = person
+	}
+
+	res := make([]models.Movie, 0, len(catalog.Movies))
+	for _, fixture := range catalog.Movies {
+		movie := models.Movie{
+			ID:              fixture.ID,
+			Name:            fixture.Name,
+			OriginalName:    fixture.OriginalName,
+			About:           fixture.About,
+			Poster:          fixture.Poster,
+			ReleaseYear:     fixture.ReleaseYear,
+			Country:         fixture.Country,
+			Slogan:          fixture.Slogan,
+			Budget:          fixture.Budget,
+			BoxOfficeUS:     fixture.BoxOfficeUS,
+			BoxOfficeGlobal: fixture.BoxOfficeGlobal,
+			BoxOfficeRussia: fixture.BoxOfficeRussia,
+			Premieres:       fixture.premieres(),
+			Rating:          fixture.Rating,
+			Duration:        fixture.DurationMinutes,
+			Staff:           staff[fixture.ID],
+		}
+		if person, ok := byName[fixture.Director]; ok && !directors[fixture.ID] {
+			movie.Staff = append(movie.Staff, models.StaffMember{Person: person.Short(), Role: models.RoleDirector})
+		}
+		for _, genreID := range fixture.GenreIDs {
+			movie.Genres = append(movie.Genres, genres[genreID])
+		}
+		res = append(res, movie)
+	}
+
+	return res
+}
+
+func buildCollections(catalog *Catalog) []models.Collection {
+	entries := make([]CollectionFixture, len(catalog.Collections))
+	copy(entries, catalog.Collections)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Position < entries[j].Position
+	})
+
+	var res []models.Collection
+	indexes := make(map[string]int)
+	for _, entry := range entries {
+		idx, ok := indexes[entry.Collection]
+		if !ok {
+			idx = len(res)
+			indexes[entry.Collection] = idx
+			res = append(res, models.Collection{Name: entry.Collection})
+		}
+
+		res[idx].Entries = append(res[idx].Entries, models.CollectionEntry{
+			Position: entry.Position,
+			MovieID:  entry.MovieID,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,278 @@
+package importer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	repoCollection "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/collection/repository"
+	repoGenre "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/genre/repository"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	repoMovie "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/repository"
+	repoStaff "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/staff_person/repository"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name string
+		dir  string
+	}{
+		{name: "json fixtures", dir: "testdata/json"},
+		{name: "csv fixtures", dir: "testdata/csv"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			catalog, err := Load(tt.dir)
+			require.NoError(t, err)
+
+			assert.Len(t, catalog.Genres, 2)
+			assert.Len(t, catalog.Persons, 2)
+			assert.Len(t, catalog.Movies, 2)
+			assert.Len(t, catalog.Staff, 2)
+			assert.Equal(t, StaffFixture{MovieID: 0, PersonID: 1, Role: models.RoleActor, Character: "Тайлер Дёрден"}, catalog.Staff[0])
+			assert.Len(t, catalog.Collections, 2)
+			assert.Equal(t, []int{1, 2}, catalog.Movies[0].GenreIDs)
+			assert.Equal(t, models.Money{Amount: 63000000, Currency: models.USD}, catalog.Movies[0].Budget)
+			assert.Equal(t, 139, catalog.Movies[0].DurationMinutes)
+			assert.Equal(t, map[string]string{"world": "1999-09-10", "RU": "2000-01-13"}, catalog.Movies[0].Premieres)
+			assert.Equal(t, 183, catalog.Persons[1].Growth)
+			assert.Equal(t, GenreFixture{ID: 1, Name: "триллер", EnName: "thriller", Description: "Напряжённые истории"}, catalog.Genres[0])
+			assert.NoError(t, Validate(catalog))
+		})
+	}
+}
+
+func TestLoad_UnsupportedVersion(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, manifestFile), []byte(`{"version": 100}`), 0o600))
+
+	_, err := Load(dir)
+	assert.True(t, errors.Is(err, errs.ErrUnsupportedFixtures))
+}
+
+func TestLoad_OlderVersions(t *testing.T) {
+	files := map[string]map[string]string{
+		"json version 1": {
+			manifestFile:          `{"version": 1}`,
+			personsFile + extJSON: `[{"id": 2, "full_name": "Эдвард Нортон", "growth": "1,83 м"}]`,
+			moviesFile + extJSON:  `[{"id": 0, "name": "Бойцовский клуб", "budget": 63000000, "duration": "2ч 19м"}]`,
+		},
+		"csv version 1": {
+			manifestFile:         `{"version": 1}`,
+			personsFile + extCSV: "id,full_name,growth\n2,Эдвард Нортон,183\n",
+			moviesFile + extCSV:  "id,name,budget,duration\n0,Бойцовский клуб,63000000,2ч 19м\n",
+		},
+		"json version 2": {
+			manifestFile:         `{"version": 2}`,
+			moviesFile + extJSON: `[{"id": 0, "name": "Бойцовский клуб", "premier_global": "1999-09-10", "premier_russia": "2000-01-13"}]`,
+		},
+		"csv version 2": {
+			manifestFile:        `{"version": 2}`,
+			moviesFile + extCSV: "id,name,premier_global,premier_russia\n0,Бойцовский клуб,1999-09-10,2000-01-13\n",
+		},
+	}
+
+	for name, fixtures := range files {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			for file, content := range fixtures {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(content), 0o600))
+			}
+
+			catalog, err := Load(dir)
+			require.NoError(t, err)
+			require.Len(t, catalog.Movies, 1)
+			movie := catalog.Movies[0]
+			assert.Equal(t, "Бойцовский клуб", movie.Name)
+
+			if catalog.Manifest.Version == 1 {
+				assert.Equal(t, models.Money{Amount: 63000000, Currency: models.USD}, movie.Budget)
+				assert.Equal(t, 139, movie.DurationMinutes)
+				require.Len(t, catalog.Persons, 1)
+				assert.Equal(t, 183, catalog.Persons[0].Growth)
+			} else {
+				assert.Equal(t, map[string]string{"world": "1999-09-10", "RU": "2000-01-13"}, movie.Premieres)
+			}
+			assert.NoError(t, Validate(catalog))
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	catalog := &Catalog{
+		Genres: []GenreFixture{{ID: 1, Name: "драма"}},
+		Persons: []PersonFixture{
+			{ID: 1, FullName: "Брэд Питт", Birthday: "18.12.1963"},
+			{ID: 1, FullName: "Эдвард Нортон", Birthday: "1969-08-18", Death: "1960-01-01"},
+		},
+		Movies: []MovieFixture{{
+			ID: 1, Name: "Бойцовский клуб", GenreIDs: []int{1, 5},
+			Budget: models.Money{Amount: 63000000}, Premieres: map[string]string{"RU": "13.01.2000", "Россия": "2000-01-13"},
+		}},
+		Staff: []StaffFixture{
+			{MovieID: 1, PersonID: 3},
+			{MovieID: 1, PersonID: 1, Role: "stuntman"},
+			{MovieID: 1, PersonID: 1, Role: models.RoleDirector, Character: "Тайлер Дёрден"},
+			// actor may play several characters but each of them once
+			{MovieID: 1, PersonID: 1, Character: "Тайлер Дёрден"},
+			{MovieID: 1, PersonID: 1, Character: "Рассказчик"},
+			{MovieID: 1, PersonID: 1, Character: "Тайлер Дёрден"},
+		},
+		Collections: []CollectionFixture{{Collection: "Лучшие", Position: 0, MovieID: 2}},
+	}
+
+	err := Validate(catalog)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errs.ErrInvalidFixtures))
+
+	var verr *ValidationError
+	require.True(t, errors.As(err, &verr))
+	assert.ElementsMatch(t, []string{
+		`person 1 has invalid birthday "18.12.1963"`,
+		"duplicate person id 1",
+		"person 1 died before birth",
+		"movie 1 has budget without currency",
+		`movie 1 has invalid premiere date "13.01.2000" in RU`,
+		`movie 1 has premiere in unknown country "Россия"`,
+		"movie 1 references unknown genre 5",
+		"staff link references unknown person 3",
+		`staff link movie 1 person 1 has unknown role "stuntman"`,
+		"staff link movie 1 person 1 has character but is not actor",
+		`duplicate staff link movie 1 person 1 role actor character "Тайлер Дёрден"`,
+		`collection "Лучшие" references unknown movie 2`,
+	}, verr.Problems)
+}
+
+func TestImporter_Import(t *testing.T) {
+	ctx := context.Background()
+
+	movies := mocks.Movies{
+		0: {ID: 0, Name: "Бойцовский клуб", Reviews: []models.Review{{ID: 1, Score: 10}}},
+	}
+	persons := mocks.Persons{
+		1: {ID: 1, FullName: "Брэд Питт", EnFullName: "Brad Pitt", Photo: "/static/img/brad_pitt.webp"},
+	}
+	collections := mocks.Collections{}
+	genres := mocks.Genres{
+		2: {ID: 2, Name: "драма", EnName: "drama"},
+	}
+
+	movieRepo := repoMovie.NewMovieRepository(&movies)
+	im := New(repoGenre.NewGenreRepository(&genres), movieRepo, repoStaff.NewStaffPersonRepository(&persons),
+		repoCollection.NewCollectionRepository(&collections))
+
+	catalog, err := Load("testdata/json")
+	require.NoError(t, err)
+
+	report, err := im.Import(ctx, catalog, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1"}, report.Genres.Created)
+	assert.Equal(t, []string{"2"}, report.Genres.Unchanged)
+	assert.Equal(t, []string{"2"}, report.Persons.Created)
+	assert.Equal(t, []string{"1"}, report.Persons.Unchanged)
+	assert.Equal(t, []string{"7"}, report.Movies.Created)
+	assert.Equal(t, []string{"0"}, report.Movies.Updated)
+	assert.Len(t, movies, 1, "dry run must not write")
+
+	report, err = im.Import(ctx, catalog, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Лучшие за всё время"}, report.Collections.Created)
+
+	movie, err := movieRepo.GetMovieFromRepoByID(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "Fight Club", movie.OriginalName)
+	assert.Equal(t, []models.Genre{{ID: 1, Name: "триллер", EnName: "thriller"}, {ID: 2, Name: "драма", EnName: "drama"}}, movie.Genres)
+	assert.Equal(t, "Напряжённые истории", genres[1].Description)
+	assert.Equal(t, []models.StaffMember{
+		{Person: models.Person{ID: 1, FullName: "Брэд Питт", EnFullName: "Brad Pitt", Photo: "/static/img/brad_pitt.webp"},
+			Role: models.RoleActor, Character: "Тайлер Дёрден"},
+		{Person: models.Person{ID: 2, FullName: "Эдвард Нортон", EnFullName: "Edward Norton"}, Role: models.RoleActor},
+	}, movie.Staff)
+	assert.Len(t, movie.Reviews, 1, "reviews must be preserved")
+	assert.Equal(t, 10.0, movie.Rating, "rating must be computed from preserved reviews")
+	assert.Equal(t, 139, movie.Duration)
+	assert.Equal(t, []models.Premiere{
+		{Country: models.PremiereWorld, Date: time.Date(1999, time.September, 10, 0, 0, 0, 0, time.UTC)},
+		{Country: models.PremiereRussia, Date: time.Date(2000, time.January, 13, 0, 0, 0, 0, time.UTC)},
+	}, movie.Premieres)
+	require.Len(t, collections, 1)
+	assert.Equal(t, "luchshie-za-vse-vremya", collections[1].Slug)
+	assert.True(t, collections[1].Published)
+	assert.Equal(t, models.CollectionEntry{Position: 1, MovieID: 7}, collections[1].Entries[1])
+
+	report, err = im.Import(ctx, catalog, false)
+	require.NoError(t, err)
+	assert.Empty(t, report.Movies.Created)
+	assert.Empty(t, report.Movies.Updated)
+	assert.Len(t, report.Movies.Unchanged, 2)
+}
+
+func TestImporter_ImportDeletedMovie(t *testing.T) {
+	ctx := context.Background()
+
+	deletedAt := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	movies := mocks.Movies{
+		0: {ID: 0, Name: "Бойцовский клуб", DeletedAt: &deletedAt, Reviews: []models.Review{{ID: 1, Score: 10}}},
+	}
+	persons := mocks.Persons{}
+	collections := mocks.Collections{}
+	genres := mocks.Genres{}
+
+	movieRepo := repoMovie.NewMovieRepository(&movies)
+	im := New(repoGenre.NewGenreRepository(&genres), movieRepo, repoStaff.NewStaffPersonRepository(&persons),
+		repoCollection.NewCollectionRepository(&collections))
+
+	catalog, err := Load("testdata/json")
+	require.NoError(t, err)
+
+	report, err := im.Import(ctx, catalog, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0"}, report.Movies.Skipped)
+	assert.Equal(t, []string{"7"}, report.Movies.Created)
+
+	// movie deleted by admin stays deleted with its reviews
+	_, err = movieRepo.GetMovieFromRepoByID(ctx, 0)
+	require.ErrorIs(t, err, errs.ErrMovieNotFound)
+	stored, err := movieRepo.GetStoredMovie(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, &deletedAt, stored.DeletedAt)
+	assert.Equal(t, "Бойцовский клуб", stored.Name)
+	assert.Len(t, stored.Reviews, 1)
+}
+
+func TestBuildMovies_Director(t *testing.T) {
+	catalog := &Catalog{
+		Persons: []PersonFixture{{ID: 1, FullName: "Дэвид Финчер"}, {ID: 2, FullName: "Брэд Питт"}},
+		Movies: []MovieFixture{
+			{ID: 1, Name: "Бойцовский клуб", Director: "Дэвид Финчер"},
+			{ID: 2, Name: "Семь", Director: "Дэвид Финчер"},
+			{ID: 3, Name: "Неизвестный", Director: "Неизвестный режиссёр"},
+		},
+		Staff: []StaffFixture{
+			{MovieID: 1, PersonID: 2},
+			{MovieID: 2, PersonID: 2, Role: models.RoleDirector},
+		},
+	}
+	persons := make(map[int]models.Person)
+	for _, fixture := range catalog.Persons {
+		persons[fixture.ID] = personFromFixture(fixture)
+	}
+
+	movies := buildMovies(catalog, persons)
+	require.Len(t, movies, 3)
+	assert.Equal(t, []models.StaffMember{
+		{Person: models.Person{ID: 2, FullName: "Брэд Питт"}, Role: models.RoleActor},
+		{Person: models.Person{ID: 1, FullName: "Дэвид Финчер"}, Role: models.RoleDirector},
+	}, movies[0].Staff)
+	// director from staff fixture wins over free text
+	assert.Equal(t, []models.StaffMember{{Person: models.Person{ID: 2, FullName: "Брэд Питт"}, Role: models.RoleDirector}}, movies[1].Staff)
+	assert.Empty(t, movies[2].Staff)
+}
@@ -0,0 +1,99 @@
+package importer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/pkg/errors"
+)
+
+const (
+	extJSON = ".json"
+	extCSV  = ".csv"
+)
+
+// Load reads catalog fixtures from dir. Every fixture file may be either json or csv,
+// missing files are treated as empty. Fixtures of older versions are upgraded to FixturesVersion
+func Load(dir string) (*Catalog, error) {
+	catalog := &Catalog{}
+
+	if err := readJSONFile(filepath.Join(dir, manifestFile), &catalog.Manifest); err != nil {
+		return nil, errors.Wrap(err, errs.ErrReadManifest)
+	}
+	version := catalog.Manifest.Version
+	if version < MinFixturesVersion || version > FixturesVersion {
+		return nil, errors.Wrapf(errs.ErrUnsupportedFixtures, "version %d", catalog.Manifest.Version)
+	}
+
+	if err := loadFixture(dir, version, genresFile, &catalog.Genres, parseGenresCSV); err != nil {
+		return nil, err
+	}
+	if err := loadFixture(dir, version, personsFile, &catalog.Persons, parsePersonsCSV); err != nil {
+		return nil, err
+	}
+	if err := loadFixture(dir, version, moviesFile, &catalog.Movies, parseMoviesCSV); err != nil {
+		return nil, err
+	}
+	if err := loadFixture(dir, version, staffFile, &catalog.Staff, parseStaffCSV); err != nil {
+		return nil, err
+	}
+	if err := loadFixture(dir, version, collectionsFile, &catalog.Collections, parseCollectionsCSV); err != nil {
+		return nil, err
+	}
+
+	return catalog, nil
+}
+
+func loadFixture[T any](dir string, version int, name string, dst *[]T, parseCSV func(records []csvRecord) ([]T, error)) error {
+	jsonPath := filepath.Join(dir, name+extJSON)
+	csvPath := filepath.Join(dir, name+extCSV)
+
+	_, jsonErr := os.Stat(jsonPath)
+	_, csvErr := os.Stat(csvPath)
+
+	switch {
+	case jsonErr == nil && csvErr == nil:
+		return errors.Wrapf(errs.ErrAmbiguousFixture, "%s", name)
+	case jsonErr == nil:
+		if err := readJSONFixture(jsonPath, name, version, dst); err != nil {
+			return errors.Wrapf(err, "%s %s", errs.ErrReadFixture, jsonPath)
+		}
+	case csvErr == nil:
+		records, err := readCSVFile(csvPath)
+		if err != nil {
+			return errors.Wrapf(err, "%s %s", errs.ErrReadFixture, csvPath)
+		}
+		upgradeCSV(name, version, records)
+		parsed, err := parseCSV(records)
+		if err != nil {
+			return errors.Wrapf(err, "%s %s", errs.ErrReadFixture, csvPath)
+		}
+		*dst = parsed
+	}
+
+	return nil
+}
+
+// readJSONFixture reads json fixture file upgrading it from older version
+func readJSONFixture(path, name string, version int, dst interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if data, err = upgradeJSON(name, version, data); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
+
+func readJSONFile(path string, dst interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, dst)
+}
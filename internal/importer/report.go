@@ -0,0 +1,107 @@
+package importer
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxReportedKeys limits keys printed per change kind, big imports are reported by counts
+const maxReportedKeys = 10
+
+type change int
+
+const (
+	changeCreated change = iota
+	changeUpdated
+	changeUnchanged
+)
+
+// EntityDiff keys of entities grouped by what import did with them
+type EntityDiff struct {
+	Created   []string `json:"created,omitempty"`
+	Updated   []string `json:"updated,omitempty"`
+	Unchanged []string `json:"unchanged,omitempty"`
+	// Skipped entities import must not touch, e.g. movies deleted by admin
+	Skipped []string `json:"skipped,omitempty"`
+}
+
+func (d *EntityDiff) track(key string, exists, equal bool) change {
+	switch {
+	case !exists:
+		d.Created = append(d.Created, key)
+		return changeCreated
+	case equal:
+		d.Unchanged = append(d.Unchanged, key)
+		return changeUnchanged
+	default:
+		d.Updated = append(d.Updated, key)
+		return changeUpdated
+	}
+}
+
+func (d *EntityDiff) sort() {
+	sortKeys(d.Created)
+	sortKeys(d.Updated)
+	sortKeys(d.Unchanged)
+	sortKeys(d.Skipped)
+}
+
+// sortKeys orders numeric ids by value and other keys alphabetically
+func sortKeys(keys []string) {
+	sort.Slice(keys, func(i, j int) bool {
+		left, errLeft := strconv.Atoi(keys[i])
+		right, errRight := strconv.Atoi(keys[j])
+		if errLeft == nil && errRight == nil {
+			return left < right
+		}
+		return keys[i] < keys[j]
+	})
+}
+
+func (d *EntityDiff) String() string {
+	res := fmt.Sprintf("created %d %s, updated %d %s, unchanged %d",
+		len(d.Created), reportedKeys(d.Created), len(d.Updated), reportedKeys(d.Updated), len(d.Unchanged))
+	if len(d.Skipped) > 0 {
+		res += fmt.Sprintf(", skipped %d %s", len(d.Skipped), reportedKeys(d.Skipped))
+	}
+	return res
+}
+
+func reportedKeys(keys []string) string {
+	if len(keys) <= maxReportedKeys {
+		return fmt.Sprint(keys)
+	}
+	return fmt.Sprintf("[%s ...]", strings.Join(keys[:maxReportedKeys], " "))
+}
+
+// Report describes changes made by import
+type Report struct {
+	DryRun      bool       `json:"dry_run"`
+	Genres      EntityDiff `json:"genres"`
+	Persons     EntityDiff `json:"persons"`
+	Movies      EntityDiff `json:"movies"`
+	Collections EntityDiff `json:"collections"`
+}
+
+func (r *Report) sort() {
+	r.Genres.sort()
+	r.Persons.sort()
+	r.Movies.sort()
+	r.Collections.sort()
+}
+
+func (r *Report) String() string {
+	var sb strings.Builder
+	if r.DryRun {
+		sb.WriteString("catalog import (dry run)\n")
+	} else {
+		sb.WriteString("catalog import\n")
+	}
+	fmt.Fprintf(&sb, "genres: %s\n", r.Genres.String())
+	fmt.Fprintf(&sb, "persons: %s\n", r.Persons.String())
+	fmt.Fprintf(&sb, "movies: %s\n", r.Movies.String())
+	fmt.Fprintf(&sb, "collections: %s", r.Collections.String())
+	return sb.String()
+}
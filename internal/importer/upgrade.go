@@ -0,0 +1,173 @@
+package importer
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
+	"github.com/pkg/errors"
+)
+
+// MinFixturesVersion is the oldest fixtures format version importer understands
+const MinFixturesVersion = 1
+
+// jsonRecord fixture record with raw fields, so upgrade changes only fields it knows
+type jsonRecord map[string]json.RawMessage
+
+// fixtureUpgrade rewrites record of fixture file of older version in the current format
+type fixtureUpgrade struct {
+	json func(version int, rec jsonRecord) error
+	csv  func(version int, rec csvRecord)
+}
+
+// upgrades by fixture file name, files missing here did not change
+var upgrades = map[string]fixtureUpgrade{
+	personsFile: {json: upgradePersonJSON, csv: upgradePersonCSV},
+	moviesFile:  {json: upgradeMovieJSON, csv: upgradeMovieCSV},
+}
+
+// legacyMoneyFields amounts which version 1 kept as plain numbers, all of them were in dollars
+var legacyMoneyFields = []string{"budget", "box_office_us", "box_office_global", "box_office_russia"}
+
+// legacyPremieres premiere fields of version 2 by country
+var legacyPremieres = map[string]string{
+	"premier_global": models.PremiereWorld,
+	"premier_russia": models.PremiereRussia,
+}
+
+// upgradeJSON rewrites records of json fixture file of older version, data is returned as is when nothing changed
+func upgradeJSON(name string, version int, data []byte) ([]byte, error) {
+	upgrade, ok := upgrades[name]
+	if !ok || version >= FixturesVersion {
+		return data, nil
+	}
+
+	var records []jsonRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, err
+	}
+	for i, rec := range records {
+		if err := upgrade.json(version, rec); err != nil {
+			return nil, errors.Wrapf(err, "record %d", i)
+		}
+	}
+	return json.Marshal(records)
+}
+
+// upgradeCSV rewrites records of csv fixture file of older version in place
+func upgradeCSV(name string, version int, records []csvRecord) {
+	upgrade, ok := upgrades[name]
+	if !ok || version >= FixturesVersion {
+		return
+	}
+
+	for _, rec := range records {
+		upgrade.csv(version, rec)
+	}
+}
+
+func (r jsonRecord) set(field string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return errors.Wrapf(err, "field %s", field)
+	}
+	r[field] = data
+	return nil
+}
+
+// take removes field which is kept as string and returns it, false if field is missing or is not string
+func (r jsonRecord) take(field string) (string, bool) {
+	var value string
+	if err := json.Unmarshal(r[field], &value); err != nil {
+		return "", false
+	}
+	delete(r, field)
+	return value, true
+}
+
+func upgradePersonJSON(version int, person jsonRecord) error {
+	if version >= 2 {
+		return nil
+	}
+
+	if value, ok := person.take("growth"); ok {
+		if growth, ok := l10n.ParseHeight(value); ok {
+			return person.set("growth", growth)
+		}
+	}
+	return nil
+}
+
+func upgradePersonCSV(version int, person csvRecord) {
+	if version >= 2 {
+		return
+	}
+
+	growth, ok := l10n.ParseHeight(person.values["growth"])
+	if !ok {
+		delete(person.values, "growth")
+		return
+	}
+	person.values["growth"] = strconv.Itoa(growth)
+}
+
+func upgradeMovieJSON(version int, movie jsonRecord) error {
+	if version < 2 {
+		for _, field := range legacyMoneyFields {
+			var amount int64
+			if err := json.Unmarshal(movie[field], &amount); err != nil {
+				continue
+			}
+			if err := movie.set(field, models.Money{Amount: amount, Currency: models.USD}); err != nil {
+				return err
+			}
+		}
+
+		if value, ok := movie.take("duration"); ok {
+			if minutes, ok := l10n.ParseDuration(value); ok {
+				if err := movie.set("duration_minutes", minutes); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	premieres := make(map[string]string)
+	for field, country := range legacyPremieres {
+		if value, ok := movie.take(field); ok && value != "" {
+			premieres[country] = value
+		}
+	}
+	if len(premieres) == 0 {
+		return nil
+	}
+	return movie.set("premieres", premieres)
+}
+
+func upgradeMovieCSV(version int, movie csvRecord) {
+	if version < 2 {
+		for _, column := range legacyMoneyFields {
+			if amount := strings.TrimSpace(movie.values[column]); amount != "" {
+				movie.values[column] = amount + " " + string(models.USD)
+			}
+		}
+
+		if minutes, ok := l10n.ParseDuration(movie.values["duration"]); ok {
+			movie.values["duration_minutes"] = strconv.Itoa(minutes)
+		}
+		delete(movie.values, "duration")
+	}
+
+	var premieres []string
+	for column, country := range legacyPremieres {
+		if value := strings.TrimSpace(movie.values[column]); value != "" {
+			premieres = append(premieres, country+keySeparator+value)
+		}
+		delete(movie.values, column)
+	}
+	if len(premieres) > 0 {
+		movie.values["premieres"] = strings.Join(premieres, listSeparator)
+	}
+}
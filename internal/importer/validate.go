@@ -0,0 +1,156 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+)
+
+// ValidationError accumulates all problems found in catalog fixtures
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", errs.ErrInvalidFixtures.Error(), strings.Join(e.Problems, "; "))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return errs.ErrInvalidFixtures
+}
+
+func (e *ValidationError) add(format string, args ...interface{}) {
+	e.Problems = append(e.Problems, fmt.Sprintf(format, args...))
+}
+
+// Validate checks ids uniqueness, required fields and references between fixtures
+func Validate(catalog *Catalog) error {
+	verr := &ValidationError{}
+
+	genres := make(map[int]struct{}, len(catalog.Genres))
+	for _, genre := range catalog.Genres {
+		if _, ok := genres[genre.ID]; ok {
+			verr.add("duplicate genre id %d", genre.ID)
+		}
+		if strings.TrimSpace(genre.Name) == "" {
+			verr.add("genre %d has empty name", genre.ID)
+		}
+		genres[genre.ID] = struct{}{}
+	}
+
+	persons := make(map[int]struct{}, len(catalog.Persons))
+	for _, person := range catalog.Persons {
+		if _, ok := persons[person.ID]; ok {
+			verr.add("duplicate person id %d", person.ID)
+		}
+		if strings.TrimSpace(person.FullName) == "" {
+			verr.add("person %d has empty full_name", person.ID)
+		}
+		if person.Growth < 0 {
+			verr.add("person %d has negative growth", person.ID)
+		}
+		birthday, err := parseDate(person.Birthday)
+		if err != nil {
+			verr.add("person %d has invalid birthday %q", person.ID, person.Birthday)
+		}
+		death, err := parseDate(person.Death)
+		if err != nil {
+			verr.add("person %d has invalid death %q", person.ID, person.Death)
+		}
+		if birthday != nil && death != nil && death.Before(*birthday) {
+			verr.add("person %d died before birth", person.ID)
+		}
+		persons[person.ID] = struct{}{}
+	}
+
+	movies := make(map[int]struct{}, len(catalog.Movies))
+	for _, movie := range catalog.Movies {
+		if _, ok := movies[movie.ID]; ok {
+			verr.add("duplicate movie id %d", movie.ID)
+		}
+		if strings.TrimSpace(movie.Name) == "" {
+			verr.add("movie %d has empty name", movie.ID)
+		}
+		if movie.DurationMinutes < 0 {
+			verr.add("movie %d has negative duration_minutes", movie.ID)
+		}
+		for country, date := range movie.Premieres {
+			if !models.IsPremiereCountry(country) {
+				verr.add("movie %d has premiere in unknown country %q", movie.ID, country)
+			}
+			if premiere, err := parseDate(date); err != nil || premiere == nil {
+				verr.add("movie %d has invalid premiere date %q in %s", movie.ID, date, country)
+			}
+		}
+		for _, money := range []struct {
+			field string
+			value models.Money
+		}{
+			{"budget", movie.Budget},
+			{"box_office_us", movie.BoxOfficeUS},
+			{"box_office_global", movie.BoxOfficeGlobal},
+			{"box_office_russia", movie.BoxOfficeRussia},
+		} {
+			if !money.value.IsZero() && money.value.Currency == "" {
+				verr.add("movie %d has %s without currency", movie.ID, money.field)
+			}
+		}
+		for _, genreID := range movie.GenreIDs {
+			if _, ok := genres[genreID]; !ok {
+				verr.add("movie %d references unknown genre %d", movie.ID, genreID)
+			}
+		}
+		movies[movie.ID] = struct{}{}
+	}
+
+	type staffKey struct {
+		movieID int
+		credit  models.StaffCredit
+	}
+	staff := make(map[staffKey]struct{}, len(catalog.Staff))
+	for _, link := range catalog.Staff {
+		if !link.role().IsKnown() {
+			verr.add("staff link movie %d person %d has unknown role %q", link.MovieID, link.PersonID, link.Role)
+		}
+		if link.Character != "" && link.role() != models.RoleActor {
+			verr.add("staff link movie %d person %d has character but is not actor", link.MovieID, link.PersonID)
+		}
+		if _, ok := movies[link.MovieID]; !ok {
+			verr.add("staff link references unknown movie %d", link.MovieID)
+		}
+		if _, ok := persons[link.PersonID]; !ok {
+			verr.add("staff link references unknown person %d", link.PersonID)
+		}
+		key := staffKey{link.MovieID, models.NewStaffCredit(link.PersonID, link.role(), link.Character)}
+		if _, ok := staff[key]; ok {
+			verr.add("duplicate staff link movie %d person %d role %s character %q", link.MovieID, link.PersonID, link.role(), link.Character)
+		}
+		staff[key] = struct{}{}
+	}
+
+	type positionKey struct {
+		collection string
+		position   int
+	}
+	positions := make(map[positionKey]struct{}, len(catalog.Collections))
+	for _, entry := range catalog.Collections {
+		if strings.TrimSpace(entry.Collection) == "" {
+			verr.add("collection entry for movie %d has empty collection name", entry.MovieID)
+		}
+		if _, ok := movies[entry.MovieID]; !ok {
+			verr.add("collection %q references unknown movie %d", entry.Collection, entry.MovieID)
+		}
+		key := positionKey{entry.Collection, entry.Position}
+		if _, ok := positions[key]; ok {
+			verr.add("collection %q has duplicate position %d", entry.Collection, entry.Position)
+		}
+		positions[key] = struct{}{}
+	}
+
+	if len(verr.Problems) > 0 {
+		return verr
+	}
+	return nil
+}
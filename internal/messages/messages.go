@@ -1,7 +1,14 @@
 package messages
 
 const (
-  SuccessfulRegister = "Successfully registered"
-  SuccessfulLogin    = "Successfully logged in"
-  SuccessfulLogout   = "Successfully logged out"
+  SuccessfulRegister         = "Successfully registered"
+  SuccessfulLogin            = "Successfully logged in"
+  SuccessfulLogout           = "Successfully logged out"
+  SuccessfulReviewDelete     = "Review successfully deleted"
+  SuccessfulRatingDelete     = "Rating successfully deleted"
+  SuccessfulDiaryEntryDelete = "Diary entry successfully deleted"
+  SuccessfulListDelete       = "List successfully deleted"
+  SuccessfulCollectionDelete = "Collection successfully deleted"
+  SuccessfulMovieDelete      = "Movie successfully deleted"
+  SuccessfulPersonDelete     = "Person successfully deleted"
 )
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/go-park-mail-ru/2025_1_sigmaScript/config"
 	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
@@ -52,3 +53,19 @@ func (r *SessionRepository) StoreSession(ctx context.Context, newSessionID, logi
 	r.rdb.Store(newSessionID, login)
 	return nil
 }
+
+// Snapshot serializes all sessions
+func (r *SessionRepository) Snapshot(ctx context.Context) ([]byte, error) {
+	return json.Marshal(r.rdb.Copy())
+}
+
+// Restore replaces all sessions with ones from snapshot
+func (r *SessionRepository) Restore(ctx context.Context, data []byte) error {
+	var sessions map[string]string
+	if err := json.Unmarshal(data, &sessions); err != nil {
+		return err
+	}
+
+	r.rdb.Replace(sessions)
+	return nil
+}
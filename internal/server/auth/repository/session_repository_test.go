@@ -132,3 +132,20 @@ func TestSessionRepository_StoreSession(t *testing.T) {
 		})
 	}
 }
+
+func TestSessionRepository_SnapshotRestore(t *testing.T) {
+	ctx := context.Background()
+
+	r := NewSessionRepository()
+	assert.NoError(t, r.StoreSession(ctx, "session", "user"))
+
+	data, err := r.Snapshot(ctx)
+	assert.NoError(t, err)
+
+	restored := NewSessionRepository()
+	assert.NoError(t, restored.Restore(ctx, data))
+
+	login, err := restored.GetSession(ctx, "session")
+	assert.NoError(t, err)
+	assert.Equal(t, "user", login)
+}
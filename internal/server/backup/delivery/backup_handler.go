@@ -0,0 +1,76 @@
+package delivery
+
+import (
+	"context"
+	"net/http"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/jsonutil"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type BackupServiceInterface interface {
+	Backup(ctx context.Context) (*models.BackupInfo, error)
+	Restore(ctx context.Context) (*models.BackupInfo, error)
+}
+
+// BackupHandler handles admin requests to save and restore in-memory repositories
+type BackupHandler struct {
+	backupService BackupServiceInterface
+}
+
+// NewBackupHandler returns new instance of BackupHandler
+func NewBackupHandler(backupService BackupServiceInterface) *BackupHandler {
+	return &BackupHandler{
+		backupService: backupService,
+	}
+}
+
+// Backup handles POST request to write snapshot right now
+func (h *BackupHandler) Backup(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	logger.Info().Msg("backup requested")
+	info, err := h.backupService.Backup(r.Context())
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		if errors.Is(err, errs.ErrSnapshotDisabled) {
+			jsonutil.SendError(r.Context(), w, http.StatusConflict, errs.ErrMsgBackupShort, err.Error())
+			return
+		}
+		jsonutil.SendError(r.Context(), w, http.StatusInternalServerError, errs.ErrMsgBackupShort, errs.ErrMsgBackup)
+		return
+	}
+
+	if err = jsonutil.SendJSON(r.Context(), w, info); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSendJSON).Error())
+		return
+	}
+}
+
+// Restore handles POST request to replace repositories data with last snapshot
+func (h *BackupHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	logger.Info().Msg("restore requested")
+	info, err := h.backupService.Restore(r.Context())
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		switch {
+		case errors.Is(err, errs.ErrSnapshotDisabled):
+			jsonutil.SendError(r.Context(), w, http.StatusConflict, errs.ErrMsgRestoreShort, err.Error())
+		case errors.Is(err, errs.ErrSnapshotNotFound):
+			jsonutil.SendError(r.Context(), w, http.StatusNotFound, errs.ErrNotFoundShort, err.Error())
+		default:
+			jsonutil.SendError(r.Context(), w, http.StatusInternalServerError, errs.ErrMsgRestoreShort, errs.ErrMsgRestore)
+		}
+		return
+	}
+
+	if err = jsonutil.SendJSON(r.Context(), w, info); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSendJSON).Error())
+		return
+	}
+}
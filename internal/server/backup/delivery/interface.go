@@ -0,0 +1,8 @@
+package delivery
+
+import "net/http"
+
+type BackupHandlerInterface interface {
+	Backup(w http.ResponseWriter, r *http.Request)
+	Restore(w http.ResponseWriter, r *http.Request)
+}
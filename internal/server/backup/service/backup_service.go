@@ -0,0 +1,286 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// SnapshotVersion version of snapshot file format
+const SnapshotVersion = 3
+
+// StoreInterface in-memory repository which can be saved to snapshot and restored from it
+type StoreInterface interface {
+	Snapshot(ctx context.Context) ([]byte, error)
+	Restore(ctx context.Context, data []byte) error
+}
+
+type snapshotFile struct {
+	Version   int                        `json:"version"`
+	CreatedAt time.Time                  `json:"created_at"`
+	Stores    map[string]json.RawMessage `json:"stores"`
+}
+
+type namedStore struct {
+	name  string
+	store StoreInterface
+}
+
+// BackupService saves in-memory repositories to snapshot file and restores them
+type BackupService struct {
+	// mu serializes backups and restores
+	mu       sync.Mutex
+	path     string
+	interval time.Duration
+	stores   []namedStore
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewBackupService returns new instance of BackupService. Empty path disables snapshots
+func NewBackupService(path string, interval time.Duration) *BackupService {
+	return &BackupService{
+		path:     path,
+		interval: interval,
+	}
+}
+
+// Register adds store to every snapshot under given name. Stores are restored in registration order
+func (s *BackupService) Register(name string, store StoreInterface) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stores = append(s.stores, namedStore{name: name, store: store})
+}
+
+// Enabled reports whether snapshot path is configured
+func (s *BackupService) Enabled() bool {
+	return s.path != ""
+}
+
+// Backup writes snapshot of all registered stores. File is written to temporary file
+// and renamed, so readers never see partially written snapshot
+func (s *BackupService) Backup(ctx context.Context) (*models.BackupInfo, error) {
+	logger := log.Ctx(ctx)
+
+	if !s.Enabled() {
+		return nil, errs.ErrSnapshotDisabled
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot := snapshotFile{
+		Version:   SnapshotVersion,
+		CreatedAt: time.Now().UTC(),
+		Stores:    make(map[string]json.RawMessage, len(s.stores)),
+	}
+	for _, named := range s.stores {
+		data, err := named.store.Snapshot(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s: store %s", errs.ErrMsgBackup, named.name)
+		}
+		snapshot.Stores[named.name] = data
+	}
+
+	content, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, errors.Wrap(err, errs.ErrMsgBackup)
+	}
+
+	if err = writeFileAtomic(s.path, content); err != nil {
+		return nil, errors.Wrap(err, errs.ErrMsgBackup)
+	}
+
+	info := s.info(snapshot, int64(len(content)))
+	logger.Info().Str("path", s.path).Int64("size", info.Size).Msg("snapshot saved")
+	return info, nil
+}
+
+// Restore replaces data of registered stores with data from snapshot file, snapshots of older
+// versions are migrated first. Stores missing in snapshot are left untouched.
+// When some store fails to restore, all stores get back data they had before
+func (s *BackupService) Restore(ctx context.Context) (*models.BackupInfo, error) {
+	logger := log.Ctx(ctx)
+
+	if !s.Enabled() {
+		return nil, errs.ErrSnapshotDisabled
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	content, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, errs.ErrSnapshotNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, errs.ErrMsgRestore)
+	}
+
+	var snapshot snapshotFile
+	if err = json.Unmarshal(content, &snapshot); err != nil {
+		return nil, errors.Wrap(err, errs.ErrMsgRestore)
+	}
+	if err = migrate(&snapshot); err != nil {
+		return nil, errors.Wrap(err, errs.ErrMsgRestore)
+	}
+	if snapshot.Version != SnapshotVersion {
+		return nil, errors.Wrapf(errs.ErrUnsupportedSnapshot, "version %d", snapshot.Version)
+	}
+
+	// current data of stores is kept to roll back restored stores if one of them fails
+	previous := make(map[string]json.RawMessage, len(s.stores))
+	for _, named := range s.stores {
+		if _, ok := snapshot.Stores[named.name]; !ok {
+			continue
+		}
+		data, err := named.store.Snapshot(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s: store %s", errs.ErrMsgRestore, named.name)
+		}
+		previous[named.name] = data
+	}
+
+	for i, named := range s.stores {
+		data, ok := snapshot.Stores[named.name]
+		if !ok {
+			logger.Warn().Str("store", named.name).Msg("store is missing in snapshot")
+			continue
+		}
+		if err = named.store.Restore(ctx, data); err != nil {
+			s.rollback(ctx, s.stores[:i+1], previous)
+			return nil, errors.Wrapf(err, "%s: store %s", errs.ErrMsgRestore, named.name)
+		}
+	}
+
+	info := s.info(snapshot, int64(len(content)))
+	logger.Info().Str("path", s.path).Time("created_at", info.CreatedAt).Msg("snapshot restored")
+	return info, nil
+}
+
+// rollback returns stores to data they had before failed restore
+func (s *BackupService) rollback(ctx context.Context, stores []namedStore, previous map[string]json.RawMessage) {
+	logger := log.Ctx(ctx)
+
+	for _, named := range stores {
+		data, ok := previous[named.name]
+		if !ok {
+			continue
+		}
+		if err := named.store.Restore(ctx, data); err != nil {
+			logger.Error().Err(err).Str("store", named.name).Msg("failed to roll back store")
+		}
+	}
+}
+
+// RestoreIfExists restores snapshot on startup, missing snapshot is not an error
+func (s *BackupService) RestoreIfExists(ctx context.Context) error {
+	if !s.Enabled() {
+		return nil
+	}
+
+	_, err := s.Restore(ctx)
+	if errors.Is(err, errs.ErrSnapshotNotFound) {
+		log.Ctx(ctx).Info().Str("path", s.path).Msg("no snapshot to restore")
+		return nil
+	}
+	return err
+}
+
+// Start runs periodic backups until Stop is called. Zero interval disables periodic backups
+func (s *BackupService) Start(ctx context.Context) {
+	if !s.Enabled() || s.interval <= 0 {
+		return
+	}
+
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := s.Backup(ctx); err != nil {
+					log.Ctx(ctx).Error().Err(err).Msg(err.Error())
+				}
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops periodic backups and writes final snapshot
+func (s *BackupService) Stop(ctx context.Context) error {
+	if !s.Enabled() {
+		return nil
+	}
+
+	if s.stop != nil {
+		close(s.stop)
+		<-s.done
+		s.stop = nil
+	}
+
+	_, err := s.Backup(ctx)
+	return err
+}
+
+func (s *BackupService) info(snapshot snapshotFile, size int64) *models.BackupInfo {
+	stores := make([]string, 0, len(snapshot.Stores))
+	for name := range snapshot.Stores {
+		stores = append(stores, name)
+	}
+	sort.Strings(stores)
+
+	return &models.BackupInfo{
+		Path:      s.path,
+		CreatedAt: snapshot.CreatedAt,
+		Size:      size,
+		Stores:    stores,
+	}
+}
+
+func writeFileAtomic(path string, content []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName)
+
+	if _, err = tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpName, path)
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryStore struct {
+	data map[string]string
+}
+
+func (m *memoryStore) Snapshot(ctx context.Context) ([]byte, error) {
+	return json.Marshal(m.data)
+}
+
+func (m *memoryStore) Restore(ctx context.Context, data []byte) error {
+	m.data = nil
+	return json.Unmarshal(data, &m.data)
+}
+
+func TestBackupService_BackupRestore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "snapshots", "snapshot.json")
+
+	store := &memoryStore{data: map[string]string{"key": "value"}}
+	s := NewBackupService(path, 0)
+	s.Register("store", store)
+
+	info, err := s.Backup(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"store"}, info.Stores)
+	assert.Positive(t, info.Size)
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary files must be renamed or removed")
+
+	store.data = map[string]string{"other": "value"}
+
+	_, err = s.Restore(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"key": "value"}, store.data)
+}
+
+func TestBackupService_Restore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("disabled", func(t *testing.T) {
+		s := NewBackupService("", 0)
+		_, err := s.Restore(ctx)
+		assert.True(t, errors.Is(err, errs.ErrSnapshotDisabled))
+		assert.NoError(t, s.RestoreIfExists(ctx))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		s := NewBackupService(filepath.Join(t.TempDir(), "snapshot.json"), 0)
+		_, err := s.Restore(ctx)
+		assert.True(t, errors.Is(err, errs.ErrSnapshotNotFound))
+		assert.NoError(t, s.RestoreIfExists(ctx))
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "snapshot.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"version": 100}`), 0o600))
+
+		s := NewBackupService(path, 0)
+		_, err := s.Restore(ctx)
+		assert.True(t, errors.Is(err, errs.ErrUnsupportedSnapshot))
+	})
+
+	t.Run("failed store rolls back restored ones", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "snapshot.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"version": 3, "stores": {
+			"store": {"key": "restored"},
+			"broken": ["not a map"]
+		}}`), 0o600))
+
+		store := &memoryStore{data: map[string]string{"key": "value"}}
+		broken := &memoryStore{data: map[string]string{"broken": "value"}}
+		s := NewBackupService(path, 0)
+		s.Register("store", store)
+		s.Register("broken", broken)
+
+		_, err := s.Restore(ctx)
+		require.Error(t, err)
+		assert.Equal(t, map[string]string{"key": "value"}, store.data)
+		assert.Equal(t, map[string]string{"broken": "value"}, broken.data)
+	})
+
+	t.Run("missing store keeps data", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "snapshot.json")
+		s := NewBackupService(path, 0)
+		_, err := s.Backup(ctx)
+		require.NoError(t, err)
+
+		store := &memoryStore{data: map[string]string{"key": "value"}}
+		s.Register("store", store)
+		_, err = s.Restore(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"key": "value"}, store.data)
+	})
+}
+
+type rawStore struct {
+	data []byte
+}
+
+func (m *rawStore) Snapshot(ctx context.Context) ([]byte, error) {
+	return m.data, nil
+}
+
+func (m *rawStore) Restore(ctx context.Context, data []byte) error {
+	m.data = data
+	return nil
+}
+
+func TestBackupService_RestoreVersion2(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"version": 2, "stores": {
+		"movies": {
+			"1": {"id": 1, "name": "Дюна", "premier_russia": "2021-09-16T00:00:00Z", "premier_global": "2021-09-03T00:00:00Z"},
+			"2": {"id": 2, "name": "Без премьеры"}
+		},
+		"store": {"key": "value"}
+	}}`), 0o600))
+
+	movies := &rawStore{}
+	store := &memoryStore{}
+	s := NewBackupService(path, 0)
+	s.Register("movies", movies)
+	s.Register("store", store)
+
+	_, err := s.Restore(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"key": "value"}, store.data)
+
+	var restored map[int]models.Movie
+	require.NoError(t, json.Unmarshal(movies.data, &restored))
+	assert.Equal(t, []models.Premiere{
+		{Country: models.PremiereWorld, Date: time.Date(2021, time.September, 3, 0, 0, 0, 0, time.UTC)},
+		{Country: models.PremiereRussia, Date: time.Date(2021, time.September, 16, 0, 0, 0, 0, time.UTC)},
+	}, restored[1].Premieres)
+	assert.Equal(t, "Дюна", restored[1].Name)
+	assert.Empty(t, restored[2].Premieres)
+	assert.NotContains(t, string(movies.data), "premier_russia")
+}
+
+func TestBackupService_RestoreVersion1(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"version": 1, "stores": {
+		"movies": {
+			"0": {
+				"id": 0, "name": "Бойцовский клуб", "director": "Дэвид Финчер",
+				"budget": 63000000, "premier_global": "1999-09-10", "duration": "2ч 19м",
+				"staff": [
+					{"id": 1, "full_name": "Брэд Питт", "growth": "180", "birthday": "1963-12-18"},
+					{"id": 3, "full_name": "Дэвид Финчер"}
+				],
+				"reviews": [{"id": 1, "user": {"id": 101, "login": "KinoKritik77"}, "review_text": "Шедевр", "score": 10, "created_at": "15.10.2023"}]
+			}
+		},
+		"persons": {
+			"2": {"id": 2, "full_name": "Эдвард Нортон", "growth": "1.83 м", "birthday": "1969-08-18 0:0:0.041078099 +0300 MSK", "career": "Актер"}
+		},
+		"collections": {
+			"Лучшие за всё время": {"1": {"id": 7, "title": "Матрица"}, "0": {"id": 0, "title": "Бойцовский клуб"}}
+		}
+	}}`), 0o600))
+
+	movies, persons, collections := &rawStore{}, &rawStore{}, &rawStore{}
+	s := NewBackupService(path, 0)
+	s.Register("persons", persons)
+	s.Register("movies", movies)
+	s.Register("collections", collections)
+
+	_, err := s.Restore(ctx)
+	require.NoError(t, err)
+
+	var restoredMovies map[int]models.Movie
+	require.NoError(t, json.Unmarshal(movies.data, &restoredMovies))
+	movie := restoredMovies[0]
+	assert.Equal(t, models.Money{Amount: 63000000, Currency: models.USD}, movie.Budget)
+	assert.Equal(t, []models.Premiere{
+		{Country: models.PremiereWorld, Date: time.Date(1999, time.September, 10, 0, 0, 0, 0, time.UTC)},
+	}, movie.Premieres)
+	assert.Equal(t, 139, movie.Duration)
+	assert.Equal(t, []models.StaffMember{
+		{Person: models.Person{ID: 1, FullName: "Брэд Питт"}, Role: models.RoleActor},
+		{Person: models.Person{ID: 3, FullName: "Дэвид Финчер"}, Role: models.RoleDirector},
+	}, movie.Staff)
+	require.Len(t, movie.Reviews, 1)
+	assert.Equal(t, "Шедевр", movie.Reviews[0].Text)
+	assert.Equal(t, time.Date(2023, time.October, 15, 0, 0, 0, 0, time.UTC), movie.Reviews[0].CreatedAt)
+	assert.NotContains(t, string(movies.data), `"director":`)
+
+	var restoredPersons map[int]models.Person
+	require.NoError(t, json.Unmarshal(persons.data, &restoredPersons))
+	birthday := time.Date(1969, time.August, 18, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, models.Person{ID: 2, FullName: "Эдвард Нортон", Growth: 183, Birthday: &birthday}, restoredPersons[2])
+
+	var restoredCollections map[int]models.Collection
+	require.NoError(t, json.Unmarshal(collections.data, &restoredCollections))
+	assert.Equal(t, map[int]models.Collection{
+		1: {
+			ID:        1,
+			Slug:      "luchshie-za-vse-vremya",
+			Name:      "Лучшие за всё время",
+			Published: true,
+			Entries:   []models.CollectionEntry{{Position: 0, MovieID: 0}, {Position: 1, MovieID: 7}},
+		},
+	}, restoredCollections)
+}
+
+func TestParseLegacyDate(t *testing.T) {
+	date := time.Date(1964, time.September, 2, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, &date, parseLegacyDate("1964-09-2 0:0:0.041078099 +0300 MSK m=+0.000049449"))
+	assert.Equal(t, &date, parseLegacyDate("02.09.1964"))
+	assert.Nil(t, parseLegacyDate("неизвестно"))
+	assert.Nil(t, parseLegacyDate(""))
+}
+
+func TestBackupService_Periodic(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+
+	s := NewBackupService(path, 10*time.Millisecond)
+	s.Register("store", &memoryStore{data: map[string]string{"key": "value"}})
+	s.Start(ctx)
+
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(path)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, s.Stop(ctx))
+}
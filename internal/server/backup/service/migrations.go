@@ -0,0 +1,438 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	collectionValidation "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/validation/collection"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
+	"github.com/pkg/errors"
+)
+
+// migration upgrades stores of snapshot to next version
+type migration func(stores map[string]json.RawMessage) error
+
+// migrations by version they upgrade from, snapshot is upgraded step by step up to SnapshotVersion.
+// Movie staff and collections changed their shape while snapshots were still written as version 2,
+// so steps from version 2 accept both shapes
+var migrations = map[int][]migration{
+	1: {migrateMovieValues, migratePersonValues, migrateCollectionFilms},
+	2: {migrateMoviePremieres, migrateMovieStaff, migrateCollectionIDs},
+}
+
+// migrate upgrades snapshot of older version to SnapshotVersion
+func migrate(snapshot *snapshotFile) error {
+	for snapshot.Version < SnapshotVersion {
+		steps, ok := migrations[snapshot.Version]
+		if !ok {
+			break
+		}
+		for _, step := range steps {
+			if err := step(snapshot.Stores); err != nil {
+				return errors.Wrapf(err, "migration from version %d", snapshot.Version)
+			}
+		}
+		snapshot.Version++
+	}
+	return nil
+}
+
+// record stored entity with raw fields, so migration changes only fields it knows
+type record map[string]json.RawMessage
+
+func (r record) set(field string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return errors.Wrapf(err, "field %s", field)
+	}
+	r[field] = data
+	return nil
+}
+
+// legacyString returns field which older snapshot kept as string, false if field is missing or is not string
+func (r record) legacyString(field string) (string, bool) {
+	raw, ok := r[field]
+	if !ok {
+		return "", false
+	}
+
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "", false
+	}
+	return value, true
+}
+
+// setLegacyDate replaces date string with typed date, unknown dates are dropped
+func (r record) setLegacyDate(field string) error {
+	value, ok := r.legacyString(field)
+	if !ok {
+		return nil
+	}
+
+	date := parseLegacyDate(value)
+	if date == nil {
+		delete(r, field)
+		return nil
+	}
+	return r.set(field, date)
+}
+
+// updateRecords applies update to every record of store kept as records by id, missing store is skipped
+func updateRecords(stores map[string]json.RawMessage, name string, update func(rec record) error) error {
+	data, ok := stores[name]
+	if !ok {
+		return nil
+	}
+
+	var records map[string]record
+	if err := json.Unmarshal(data, &records); err != nil {
+		return errors.Wrapf(err, "store %s", name)
+	}
+
+	for id, rec := range records {
+		if err := update(rec); err != nil {
+			return errors.Wrapf(err, "store %s: record %s", name, id)
+		}
+	}
+
+	data, err := json.Marshal(records)
+	if err != nil {
+		return errors.Wrapf(err, "store %s", name)
+	}
+	stores[name] = data
+	return nil
+}
+
+// updateList applies update to every record of list field
+func updateList(rec record, field string, update func(item record) error) error {
+	raw, ok := rec[field]
+	if !ok {
+		return nil
+	}
+
+	var items []record
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return errors.Wrapf(err, "field %s", field)
+	}
+	for _, item := range items {
+		if err := update(item); err != nil {
+			return errors.Wrapf(err, "field %s", field)
+		}
+	}
+	return rec.set(field, items)
+}
+
+// legacyDateLayouts formats of dates kept by snapshots of version 1, only date part is used
+var legacyDateLayouts = []string{time.RFC3339, l10n.DateLayout, "2006-1-2", "02.01.2006", "2.1.2006"}
+
+// parseLegacyDate parses free-form date of version 1, nil when date is unknown
+func parseLegacyDate(value string) *time.Time {
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	for _, layout := range legacyDateLayouts {
+		if date, err := time.Parse(layout, fields[0]); err == nil {
+			date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+			return &date
+		}
+	}
+	return nil
+}
+
+// legacyMoneyFields amounts which snapshots of version 1 kept as plain numbers, all of them were in dollars
+var legacyMoneyFields = []string{"budget", "box_office_us", "box_office_global", "box_office_russia"}
+
+// migrateMovieValues turns money, premiere dates, duration and review dates of movies into typed values
+func migrateMovieValues(stores map[string]json.RawMessage) error {
+	return updateRecords(stores, "movies", func(movie record) error {
+		for _, field := range legacyMoneyFields {
+			raw, ok := movie[field]
+			if !ok {
+				continue
+			}
+			var amount int64
+			if err := json.Unmarshal(raw, &amount); err != nil {
+				continue
+			}
+			if err := movie.set(field, models.Money{Amount: amount, Currency: models.USD}); err != nil {
+				return err
+			}
+		}
+
+		for field := range legacyPremieres {
+			if err := movie.setLegacyDate(field); err != nil {
+				return err
+			}
+		}
+
+		if value, ok := movie.legacyString("duration"); ok {
+			delete(movie, "duration")
+			if minutes, ok := l10n.ParseDuration(value); ok {
+				if err := movie.set("duration", minutes); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := updateList(movie, "staff", migratePersonRecord); err != nil {
+			return err
+		}
+		return updateList(movie, "reviews", migrateReviewRecord)
+	})
+}
+
+// migratePersonValues turns growth and dates of persons into typed values
+func migratePersonValues(stores map[string]json.RawMessage) error {
+	return updateRecords(stores, "persons", migratePersonRecord)
+}
+
+func migratePersonRecord(person record) error {
+	if value, ok := person.legacyString("growth"); ok {
+		delete(person, "growth")
+		if growth, ok := l10n.ParseHeight(value); ok {
+			if err := person.set("growth", growth); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := person.setLegacyDate("birthday"); err != nil {
+		return err
+	}
+	return person.setLegacyDate("death")
+}
+
+// migrateReviewRecord renames review_text of the earliest snapshots and makes creation date typed
+func migrateReviewRecord(review record) error {
+	if text, ok := review["review_text"]; ok {
+		delete(review, "review_text")
+		review["text"] = text
+	}
+
+	value, ok := review.legacyString("created_at")
+	if !ok {
+		return nil
+	}
+
+	var createdAt time.Time
+	if date := parseLegacyDate(value); date != nil {
+		createdAt = *date
+	}
+	return review.set("created_at", createdAt)
+}
+
+// legacyCollectionFilm movie placed in collection as it was kept by snapshots of version 2
+type legacyCollectionFilm struct {
+	Position   int    `json:"position"`
+	MovieID    int    `json:"movie_id"`
+	Title      string `json:"title"`
+	PreviewURL string `json:"preview_url"`
+}
+
+// legacyCollection collection kept by name in snapshots of version 2
+type legacyCollection struct {
+	Name  string                 `json:"name"`
+	Films []legacyCollectionFilm `json:"films"`
+}
+
+// migrateCollectionFilms turns collections kept as films by position into collections with list of films
+func migrateCollectionFilms(stores map[string]json.RawMessage) error {
+	data, ok := stores["collections"]
+	if !ok {
+		return nil
+	}
+
+	var collections map[string]record
+	if err := json.Unmarshal(data, &collections); err != nil {
+		return errors.Wrap(err, "store collections")
+	}
+
+	res := make(map[string]interface{}, len(collections))
+	for name, films := range collections {
+		if _, ok = films["films"]; ok {
+			// collection already has list of films
+			res[name] = films
+			continue
+		}
+
+		collection := legacyCollection{Name: name}
+		for key, raw := range films {
+			position, err := strconv.Atoi(key)
+			if err != nil {
+				return errors.Wrapf(err, "store collections: collection %s", name)
+			}
+
+			var film struct {
+				ID         int    `json:"id"`
+				Title      string `json:"title"`
+				PreviewURL string `json:"preview_url"`
+			}
+			if err = json.Unmarshal(raw, &film); err != nil {
+				return errors.Wrapf(err, "store collections: collection %s", name)
+			}
+			collection.Films = append(collection.Films, legacyCollectionFilm{
+				Position:   position,
+				MovieID:    film.ID,
+				Title:      film.Title,
+				PreviewURL: film.PreviewURL,
+			})
+		}
+		sort.Slice(collection.Films, func(i, j int) bool {
+			return collection.Films[i].Position < collection.Films[j].Position
+		})
+		res[name] = collection
+	}
+
+	data, err := json.Marshal(res)
+	if err != nil {
+		return errors.Wrap(err, "store collections")
+	}
+	stores["collections"] = data
+	return nil
+}
+
+// legacy premiere fields of movies kept by snapshots of version 2
+var legacyPremieres = map[string]string{
+	"premier_global": models.PremiereWorld,
+	"premier_russia": models.PremiereRussia,
+}
+
+// migrateMoviePremieres moves premiere dates in Russia and in the world into premieres by country
+func migrateMoviePremieres(stores map[string]json.RawMessage) error {
+	return updateRecords(stores, "movies", func(movie record) error {
+		var premieres []models.Premiere
+		for field, country := range legacyPremieres {
+			value, ok := movie[field]
+			if !ok {
+				continue
+			}
+			delete(movie, field)
+
+			var date *time.Time
+			if err := json.Unmarshal(value, &date); err != nil {
+				return err
+			}
+			if date != nil {
+				premieres = append(premieres, models.Premiere{Country: country, Date: *date})
+			}
+		}
+		if len(premieres) == 0 {
+			return nil
+		}
+
+		models.SortPremieres(premieres)
+		return movie.set("premieres", premieres)
+	})
+}
+
+// migrateMovieStaff credits persons of movie staff kept without roles as actors,
+// except the one named as movie director
+func migrateMovieStaff(stores map[string]json.RawMessage) error {
+	return updateRecords(stores, "movies", func(movie record) error {
+		director, _ := movie.legacyString("director")
+		delete(movie, "director")
+
+		raw, ok := movie["staff"]
+		if !ok {
+			return nil
+		}
+
+		var items []record
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return errors.Wrap(err, "field staff")
+		}
+		for _, item := range items {
+			if _, ok = item["person"]; ok {
+				// staff already has roles
+				return nil
+			}
+		}
+
+		var persons []models.Person
+		if err := json.Unmarshal(raw, &persons); err != nil {
+			return errors.Wrap(err, "field staff")
+		}
+
+		staff := make([]models.StaffMember, 0, len(persons))
+		for _, person := range persons {
+			role := models.RoleActor
+			if director != "" && person.FullName == director {
+				role = models.RoleDirector
+			}
+			staff = append(staff, models.StaffMember{Person: person.Short(), Role: role})
+		}
+		return movie.set("staff", staff)
+	})
+}
+
+// migrateCollectionIDs keys collections kept by name by ids and gives them slugs,
+// they were all shown on main page, so they are published
+func migrateCollectionIDs(stores map[string]json.RawMessage) error {
+	data, ok := stores["collections"]
+	if !ok {
+		return nil
+	}
+
+	var raw map[string]record
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return errors.Wrap(err, "store collections")
+	}
+	for _, collection := range raw {
+		if _, ok = collection["films"]; !ok {
+			// collections are already keyed by id
+			return nil
+		}
+	}
+
+	var legacy map[string]legacyCollection
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return errors.Wrap(err, "store collections")
+	}
+
+	names := make([]string, 0, len(legacy))
+	for name := range legacy {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	collections := make(map[int]models.Collection, len(names))
+	slugs := make(map[string]bool, len(names))
+	for i, name := range names {
+		id := i + 1
+		slug := collectionValidation.SlugFromName(name)
+		if slug == "" || slugs[slug] {
+			slug = fmt.Sprintf("collection-%d", id)
+		}
+		slugs[slug] = true
+
+		collection := models.Collection{
+			ID:        id,
+			Slug:      slug,
+			Name:      name,
+			Published: true,
+			Entries:   make([]models.CollectionEntry, 0, len(legacy[name].Films)),
+		}
+		for _, film := range legacy[name].Films {
+			collection.Entries = append(collection.Entries, models.CollectionEntry{Position: film.Position, MovieID: film.MovieID})
+		}
+		sort.Slice(collection.Entries, func(i, j int) bool {
+			return collection.Entries[i].Position < collection.Entries[j].Position
+		})
+		collections[id] = collection
+	}
+
+	data, err := json.Marshal(collections)
+	if err != nil {
+		return errors.Wrap(err, "store collections")
+	}
+	stores["collections"] = data
+	return nil
+}
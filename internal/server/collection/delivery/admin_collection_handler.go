@@ -0,0 +1,282 @@
+package delivery
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/ds"
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/messages"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/collection/delivery/dto"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/validation/collection"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/jsonutil"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// getCollectionID parses collection id from path, error response is sent if it is invalid
+func getCollectionID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	logger := log.Ctx(r.Context())
+
+	collectionID, err := strconv.Atoi(mux.Vars(r)["collection_id"])
+	if err != nil {
+		errMsg := errors.Wrap(err, "collection action: bad collection_id")
+		logger.Error().Err(errMsg).Msg(errMsg.Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, errs.ErrBadPayload)
+		return 0, false
+	}
+
+	return collectionID, true
+}
+
+// defaultRuleLimit movies in smart collection when rule does not limit them
+const defaultRuleLimit = 50
+
+// parseRule converts rule of smart collection, sorting defaults are the same as in catalog
+func parseRule(req dto.SmartRuleJSON) (models.SmartRule, error) {
+	query, err := url.ParseQuery(req.Filter)
+	if err != nil {
+		return models.SmartRule{}, errors.Wrap(err, "rule filter")
+	}
+
+	rule := models.SmartRule{
+		Sort:  models.MovieSort(req.Sort),
+		Limit: req.Limit,
+	}
+	if rule.Filter, err = models.ParseMovieFilter(query); err != nil {
+		return rule, errors.Wrap(err, "rule filter")
+	}
+	if rule.Sort == "" {
+		rule.Sort = models.SortByRating
+	}
+	if rule.Limit == 0 {
+		rule.Limit = defaultRuleLimit
+	}
+
+	switch req.Order {
+	case "":
+		rule.Desc = rule.Sort != models.SortByTitle
+	case "asc":
+		rule.Desc = false
+	case "desc":
+		rule.Desc = true
+	default:
+		return rule, errors.Errorf("unknown order %q", req.Order)
+	}
+
+	return rule, collection.IsValidRule(rule)
+}
+
+// parseCollection validates request and converts it to collection, slug is made from name if omitted
+func parseCollection(req dto.CollectionRequest) (models.Collection, error) {
+	res := models.Collection{
+		Name:        strings.TrimSpace(req.Name),
+		Slug:        strings.TrimSpace(req.Slug),
+		Description: strings.TrimSpace(req.Description),
+	}
+	if res.Slug == "" {
+		res.Slug = collection.SlugFromName(res.Name)
+	}
+
+	if err := collection.IsValidName(res.Name); err != nil {
+		return res, err
+	}
+	if err := collection.IsValidSlug(res.Slug); err != nil {
+		return res, err
+	}
+	if err := collection.IsValidDescription(res.Description); err != nil {
+		return res, err
+	}
+	if err := collection.IsValidMovieIDs(req.MovieIDs); err != nil {
+		return res, err
+	}
+
+	if req.Rule != nil {
+		rule, err := parseRule(*req.Rule)
+		if err != nil {
+			return res, err
+		}
+		res.Rule = &rule
+	}
+
+	return res, nil
+}
+
+// readCollection reads and validates collection from request body, error response is sent if it is invalid
+func readCollection(w http.ResponseWriter, r *http.Request) (models.Collection, []int, bool) {
+	logger := log.Ctx(r.Context())
+
+	var req dto.CollectionRequest
+	if err := jsonutil.ReadJSON(r, &req); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrParseJSON)).Msg(errors.Wrap(err, errs.ErrParseJSON).Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errors.Wrap(err, errs.ErrParseJSONShort).Error(), errs.ErrBadPayload)
+		return models.Collection{}, nil, false
+	}
+
+	res, err := parseCollection(req)
+	if err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrInvalidCollection)).Msg(errors.Wrap(err, errs.ErrInvalidCollection).Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errors.Wrap(err, errs.ErrInvalidCollectionShort).Error(),
+			errors.Wrap(err, errs.ErrInvalidCollection).Error())
+		return res, nil, false
+	}
+
+	return res, req.MovieIDs, true
+}
+
+// sendAdminCollection responds with collection as seen by editors
+func sendAdminCollection(w http.ResponseWriter, r *http.Request, res *models.Collection) {
+	logger := log.Ctx(r.Context())
+
+	if err := jsonutil.SendJSON(r.Context(), w, dto.NewAdminCollectionJSON(*res)); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+	}
+}
+
+// GetAllCollections returns all collections for admin, drafts included
+func (h *CollectionHandler) GetAllCollections(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	collections, err := h.collectionService.GetAllCollections(r.Context())
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendCollectionError(r.Context(), w, err)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, dto.NewAdminCollectionsJSON(collections)); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
+
+// CreateCollection saves new collection as draft, it has to be published to appear on main page
+func (h *CollectionHandler) CreateCollection(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	req, movieIDs, ok := readCollection(w, r)
+	if !ok {
+		return
+	}
+
+	logger.Info().Msgf("creating collection %q", req.Slug)
+	res, err := h.collectionService.CreateCollection(r.Context(), req, movieIDs)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendCollectionError(r.Context(), w, err)
+		return
+	}
+
+	sendAdminCollection(w, r, res)
+}
+
+// UpdateCollection changes name, slug, description and rule of smart collection.
+// Movies of curated collection are changed by UpdateCollectionMovies
+func (h *CollectionHandler) UpdateCollection(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	collectionID, ok := getCollectionID(w, r)
+	if !ok {
+		return
+	}
+	req, _, ok := readCollection(w, r)
+	if !ok {
+		return
+	}
+	req.ID = collectionID
+
+	logger.Info().Msgf("updating collection %d", collectionID)
+	res, err := h.collectionService.UpdateCollection(r.Context(), req)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendCollectionError(r.Context(), w, err)
+		return
+	}
+
+	sendAdminCollection(w, r, res)
+}
+
+// UpdateCollectionMovies replaces movies of curated collection, so it also reorders them
+func (h *CollectionHandler) UpdateCollectionMovies(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	collectionID, ok := getCollectionID(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.CollectionMoviesRequest
+	if err := jsonutil.ReadJSON(r, &req); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrParseJSON)).Msg(errors.Wrap(err, errs.ErrParseJSON).Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errors.Wrap(err, errs.ErrParseJSONShort).Error(), errs.ErrBadPayload)
+		return
+	}
+	if err := collection.IsValidMovieIDs(req.MovieIDs); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrInvalidCollection)).Msg(errors.Wrap(err, errs.ErrInvalidCollection).Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errors.Wrap(err, errs.ErrInvalidCollectionShort).Error(),
+			errors.Wrap(err, errs.ErrInvalidCollection).Error())
+		return
+	}
+
+	logger.Info().Msgf("setting %d movies of collection %d", len(req.MovieIDs), collectionID)
+	res, err := h.collectionService.SetCollectionMovies(r.Context(), collectionID, req.MovieIDs)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendCollectionError(r.Context(), w, err)
+		return
+	}
+
+	sendAdminCollection(w, r, res)
+}
+
+// PublishCollection publishes collection or returns it to drafts with {"published": false}
+func (h *CollectionHandler) PublishCollection(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	collectionID, ok := getCollectionID(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.PublishRequest
+	if err := jsonutil.ReadJSON(r, &req); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrParseJSON)).Msg(errors.Wrap(err, errs.ErrParseJSON).Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errors.Wrap(err, errs.ErrParseJSONShort).Error(), errs.ErrBadPayload)
+		return
+	}
+
+	logger.Info().Msgf("setting publication of collection %d to %t", collectionID, req.Published)
+	res, err := h.collectionService.PublishCollection(r.Context(), collectionID, req.Published)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendCollectionError(r.Context(), w, err)
+		return
+	}
+
+	sendAdminCollection(w, r, res)
+}
+
+func (h *CollectionHandler) DeleteCollection(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	collectionID, ok := getCollectionID(w, r)
+	if !ok {
+		return
+	}
+
+	logger.Info().Msgf("deleting collection %d", collectionID)
+	if err := h.collectionService.DeleteCollection(r.Context(), collectionID); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendCollectionError(r.Context(), w, err)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, ds.Response{Message: messages.SuccessfulCollectionDelete}); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
@@ -3,25 +3,90 @@ package delivery
 import (
 	"context"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
-	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/collection/delivery/dto"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/middleware"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
 	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/jsonutil"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
+	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 )
 
+const (
+	// localeParam query parameter requesting localized display strings
+	localeParam = "locale"
+
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
 type CollectionServiceInterface interface {
-	GetMainPageCollections(ctx context.Context) (mocks.Collections, error)
+	GetMainPageCollections(ctx context.Context) ([]models.CollectionPage, error)
+	GetCollection(ctx context.Context, key string, req models.CollectionRequest) (*models.CollectionPage, error)
+
+	GetAllCollections(ctx context.Context) ([]models.Collection, error)
+	CreateCollection(ctx context.Context, collection models.Collection, movieIDs []int) (*models.Collection, error)
+	UpdateCollection(ctx context.Context, collection models.Collection) (*models.Collection, error)
+	SetCollectionMovies(ctx context.Context, collectionID int, movieIDs []int) (*models.Collection, error)
+	PublishCollection(ctx context.Context, collectionID int, published bool) (*models.Collection, error)
+	DeleteCollection(ctx context.Context, collectionID int) error
+
+	CreateList(ctx context.Context, username string, list models.UserList) (*models.UserList, error)
+	UpdateList(ctx context.Context, username string, list models.UserList) (*models.UserList, error)
+	ResetShareToken(ctx context.Context, username string, listID int) (*models.UserList, error)
+	DeleteList(ctx context.Context, username string, listID int) error
+	GetList(ctx context.Context, username string, listID int, token string) (*models.UserListDetails, error)
+	GetUserLists(ctx context.Context, username string) ([]models.UserList, error)
+	BrowseLists(ctx context.Context, req models.UserListRequest) (*models.UserListPage, error)
+}
+
+type WatchlistServiceInterface interface {
+	WatchlistedMovies(ctx context.Context, username string, movieIDs []int) (map[int]bool, error)
 }
 
 type CollectionHandler struct {
 	collectionService CollectionServiceInterface
+	watchlistService  WatchlistServiceInterface
 }
 
-func NewCollectionHandler(collectionService CollectionServiceInterface) *CollectionHandler {
+func NewCollectionHandler(collectionService CollectionServiceInterface, watchlistService WatchlistServiceInterface) *CollectionHandler {
 	return &CollectionHandler{
 		collectionService: collectionService,
+		watchlistService:  watchlistService,
+	}
+}
+
+// parseLimit reads page size from query string
+func parseLimit(query url.Values) (int, error) {
+	limit := defaultLimit
+	if val := query.Get("limit"); val != "" {
+		var err error
+		if limit, err = strconv.Atoi(val); err != nil {
+			return 0, errors.Wrap(err, "parameter limit")
+		}
+	}
+	if limit <= 0 || limit > maxLimit {
+		return 0, errors.Errorf("limit must be in 1-%d", maxLimit)
+	}
+	return limit, nil
+}
+
+func sendCollectionError(ctx context.Context, w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, errs.ErrCollectionNotExist) || errors.Is(err, errs.ErrMovieNotFound):
+		jsonutil.SendError(ctx, w, http.StatusNotFound, errs.ErrNotFoundShort, err.Error())
+	case errors.Is(err, errs.ErrCollectionSlugTaken) || errors.Is(err, errs.ErrSmartCollection) ||
+		errors.Is(err, errs.ErrBuiltinCollection):
+		jsonutil.SendError(ctx, w, http.StatusConflict, errs.ErrAlreadyExistsShort, err.Error())
+	case errors.Is(err, errs.ErrInvalidCursor) || errors.Is(err, errs.ErrInvalidCollectionRequest):
+		jsonutil.SendError(ctx, w, http.StatusBadRequest, errs.ErrBadPayload, err.Error())
+	default:
+		jsonutil.SendError(ctx, w, http.StatusInternalServerError, errs.ErrSomethingWentWrong, errs.ErrSomethingWentWrong)
 	}
 }
 
@@ -38,8 +103,47 @@ func (h *CollectionHandler) GetMainPageCollections(w http.ResponseWriter, r *htt
 		return
 	}
 
-	if err := jsonutil.SendJSON(r.Context(), w, collections); err != nil {
+	if err := jsonutil.SendJSON(r.Context(), w, dto.NewCollectionsJSON(collections, l10n.ParseLocale(r.URL.Query().Get(localeParam)))); err != nil {
 		logger.Error().Err(err).Msg("Error sending JSON")
 		return
 	}
 }
+
+// GetCollection returns page of published collection, collection_id is either id or slug.
+// Movies logged in user saved to watchlist are marked
+func (h *CollectionHandler) GetCollection(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	query := r.URL.Query()
+	limit, err := parseLimit(query)
+	if err != nil {
+		errMsg := errors.Wrap(err, "getCollection action: bad request")
+		logger.Error().Err(errMsg).Msg(errMsg.Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, err.Error())
+		return
+	}
+
+	req := models.CollectionRequest{Limit: limit, Cursor: query.Get("cursor")}
+	page, err := h.collectionService.GetCollection(r.Context(), mux.Vars(r)["collection_id"], req)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendCollectionError(r.Context(), w, err)
+		return
+	}
+
+	res := dto.NewCollectionJSON(*page, l10n.ParseLocale(query.Get(localeParam)))
+	if username := middleware.Username(r.Context()); username != "" {
+		watchlisted, err := h.watchlistService.WatchlistedMovies(r.Context(), username, res.MovieIDs())
+		if err != nil {
+			// collection is still shown, only without marks
+			logger.Warn().Err(err).Msg("getCollection action: failed to check watchlist")
+		} else {
+			res.MarkWatchlisted(watchlisted)
+		}
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, res); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
@@ -0,0 +1,263 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	movieDTO "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/delivery/dto"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
+)
+
+// SmartRuleJSON rule of smart collection
+type SmartRuleJSON struct {
+	// Filter catalog query, e.g. "genre=8&year_to=1999&rating_from=8"
+	Filter string `json:"filter"`
+	Sort   string `json:"sort,omitempty"`
+	Order  string `json:"order,omitempty"`
+	Limit  int    `json:"limit,omitempty"`
+}
+
+func NewSmartRuleJSON(rule models.SmartRule) *SmartRuleJSON {
+	res := &SmartRuleJSON{
+		Filter: rule.Filter.Encode(),
+		Sort:   string(rule.Sort),
+		Order:  "asc",
+		Limit:  rule.Limit,
+	}
+	if rule.Desc {
+		res.Order = "desc"
+	}
+	return res
+}
+
+// CollectionRequest collection to create or edit by admin, movies of curated collection are set on creation only.
+// Collection with rule is smart, its movies are selected by rule
+type CollectionRequest struct {
+	Name string `json:"name"`
+	// Slug is made from name when empty
+	Slug        string         `json:"slug,omitempty"`
+	Description string         `json:"description,omitempty"`
+	MovieIDs    []int          `json:"movie_ids,omitempty"`
+	Rule        *SmartRuleJSON `json:"rule,omitempty"`
+}
+
+// CollectionMoviesRequest all movies of collection in new order
+type CollectionMoviesRequest struct {
+	MovieIDs []int `json:"movie_ids"`
+}
+
+type PublishRequest struct {
+	Published bool `json:"published"`
+}
+
+type CollectionMovieJSON struct {
+	Position int `json:"position"`
+	// PreviousPosition and Movement are set for ranked built-in collections when movie was ranked earlier,
+	// positive movement means movie went up
+	PreviousPosition *int `json:"previous_position,omitempty"`
+	Movement         *int `json:"movement,omitempty"`
+	// Premiere ISO-8601 date, set for "Скоро в кино"
+	Premiere        string                  `json:"premiere,omitempty"`
+	PremiereDisplay string                  `json:"premiere_display,omitempty"`
+	Movie           movieDTO.MovieShortJSON `json:"movie"`
+	// InWatchlist whether current user saved movie to watch later, only for logged in user
+	InWatchlist *bool `json:"in_watchlist,omitempty"`
+}
+
+type CollectionJSON struct {
+	ID          int    `json:"id"`
+	Slug        string `json:"slug"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Smart       bool   `json:"smart"`
+	Builtin     string `json:"builtin,omitempty"`
+	// Movies page of collection movies by position
+	Movies     []CollectionMovieJSON `json:"movies"`
+	Total      int                   `json:"total"`
+	NextCursor string                `json:"next_cursor,omitempty"`
+}
+
+func NewCollectionJSON(page models.CollectionPage, locale l10n.Locale) CollectionJSON {
+	res := CollectionJSON{
+		ID:          page.Collection.ID,
+		Slug:        page.Collection.Slug,
+		Name:        page.Collection.Name,
+		Description: page.Collection.Description,
+		Smart:       page.Collection.IsSmart(),
+		Builtin:     string(page.Collection.Builtin),
+		Movies:      make([]CollectionMovieJSON, 0, len(page.Items)),
+		Total:       page.Total,
+		NextCursor:  page.NextCursor,
+	}
+	for _, item := range page.Items {
+		movie := CollectionMovieJSON{
+			Position:         item.Position,
+			PreviousPosition: item.PreviousPosition,
+			Movie:            movieDTO.NewMovieShortJSON(item.Movie, locale),
+		}
+		if item.PreviousPosition != nil {
+			movement := *item.PreviousPosition - item.Position
+			movie.Movement = &movement
+		}
+		if item.Premiere != nil {
+			movie.Premiere = item.Premiere.Format(l10n.DateLayout)
+			movie.PremiereDisplay = l10n.FormatDate(*item.Premiere, locale)
+		}
+		res.Movies = append(res.Movies, movie)
+	}
+	return res
+}
+
+// MovieIDs returns ids of movies on page
+func (c CollectionJSON) MovieIDs() []int {
+	res := make([]int, 0, len(c.Movies))
+	for _, movie := range c.Movies {
+		res = append(res, movie.Movie.ID)
+	}
+	return res
+}
+
+// MarkWatchlisted sets for every movie whether current user saved it
+func (c *CollectionJSON) MarkWatchlisted(watchlisted map[int]bool) {
+	for i := range c.Movies {
+		inWatchlist := watchlisted[c.Movies[i].Movie.ID]
+		c.Movies[i].InWatchlist = &inWatchlist
+	}
+}
+
+// CollectionsJSON published collections in main page order
+type CollectionsJSON struct {
+	Collections []CollectionJSON `json:"collections"`
+}
+
+func NewCollectionsJSON(pages []models.CollectionPage, locale l10n.Locale) CollectionsJSON {
+	res := CollectionsJSON{Collections: make([]CollectionJSON, 0, len(pages))}
+	for _, page := range pages {
+		res.Collections = append(res.Collections, NewCollectionJSON(page, locale))
+	}
+	return res
+}
+
+// AdminCollectionJSON collection as seen by editors, movies are referenced by id
+type AdminCollectionJSON struct {
+	ID          int    `json:"id"`
+	Slug        string `json:"slug"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Published   bool   `json:"published"`
+	Builtin     string `json:"builtin,omitempty"`
+	// MovieIDs movies of curated collection in position order
+	MovieIDs []int          `json:"movie_ids"`
+	Rule     *SmartRuleJSON `json:"rule,omitempty"`
+}
+
+func NewAdminCollectionJSON(collection models.Collection) AdminCollectionJSON {
+	res := AdminCollectionJSON{
+		ID:          collection.ID,
+		Slug:        collection.Slug,
+		Name:        collection.Name,
+		Description: collection.Description,
+		Published:   collection.Published,
+		Builtin:     string(collection.Builtin),
+		MovieIDs:    make([]int, 0, len(collection.Entries)),
+	}
+	for _, entry := range collection.Entries {
+		res.MovieIDs = append(res.MovieIDs, entry.MovieID)
+	}
+	if collection.IsSmart() {
+		res.Rule = NewSmartRuleJSON(*collection.Rule)
+	}
+	return res
+}
+
+type AdminCollectionsJSON struct {
+	Collections []AdminCollectionJSON `json:"collections"`
+}
+
+func NewAdminCollectionsJSON(collections []models.Collection) AdminCollectionsJSON {
+	res := AdminCollectionsJSON{Collections: make([]AdminCollectionJSON, 0, len(collections))}
+	for _, collection := range collections {
+		res.Collections = append(res.Collections, NewAdminCollectionJSON(collection))
+	}
+	return res
+}
+
+type UserListEntryRequest struct {
+	MovieID int    `json:"movie_id"`
+	Note    string `json:"note,omitempty"`
+}
+
+// UserListRequest list to create or replace, movies are kept in given order
+type UserListRequest struct {
+	Title       string                 `json:"title"`
+	Description string                 `json:"description,omitempty"`
+	Cover       string                 `json:"cover,omitempty"`
+	Visibility  models.ListVisibility  `json:"visibility"`
+	Movies      []UserListEntryRequest `json:"movies"`
+}
+
+type UserListMovieJSON struct {
+	Movie movieDTO.MovieShortJSON `json:"movie"`
+	Note  string                  `json:"note,omitempty"`
+}
+
+type UserListJSON struct {
+	ID          int                   `json:"id"`
+	OwnerLogin  string                `json:"owner_login"`
+	Title       string                `json:"title"`
+	Description string                `json:"description,omitempty"`
+	Cover       string                `json:"cover,omitempty"`
+	Visibility  models.ListVisibility `json:"visibility"`
+	// ShareToken is sent only to owner, list is shared by link with ?token=
+	ShareToken string              `json:"share_token,omitempty"`
+	MovieCount int                 `json:"movie_count"`
+	Movies     []UserListMovieJSON `json:"movies,omitempty"`
+	CreatedAt  time.Time           `json:"created_at"`
+	UpdatedAt  time.Time           `json:"updated_at"`
+}
+
+// NewUserListJSON list without its movies, as shown in lists of lists
+func NewUserListJSON(list models.UserList) UserListJSON {
+	return UserListJSON{
+		ID:          list.ID,
+		OwnerLogin:  list.OwnerLogin,
+		Title:       list.Title,
+		Description: list.Description,
+		Cover:       list.Cover,
+		Visibility:  list.Visibility,
+		ShareToken:  list.ShareToken,
+		MovieCount:  len(list.Entries),
+		CreatedAt:   list.CreatedAt,
+		UpdatedAt:   list.UpdatedAt,
+	}
+}
+
+func NewUserListDetailsJSON(details models.UserListDetails, locale l10n.Locale) UserListJSON {
+	res := NewUserListJSON(details.List)
+	res.MovieCount = len(details.Items)
+	res.Movies = make([]UserListMovieJSON, 0, len(details.Items))
+	for _, item := range details.Items {
+		res.Movies = append(res.Movies, UserListMovieJSON{
+			Movie: movieDTO.NewMovieShortJSON(item.Movie, locale),
+			Note:  item.Entry.Note,
+		})
+	}
+	return res
+}
+
+// UserListsJSON lists, the recently updated first
+type UserListsJSON struct {
+	Lists      []UserListJSON `json:"lists"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+func NewUserListsJSON(lists []models.UserList, nextCursor string) UserListsJSON {
+	res := UserListsJSON{
+		Lists:      make([]UserListJSON, 0, len(lists)),
+		NextCursor: nextCursor,
+	}
+	for _, list := range lists {
+		res.Lists = append(res.Lists, NewUserListJSON(list))
+	}
+	return res
+}
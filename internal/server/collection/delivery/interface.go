@@ -4,4 +4,20 @@ import "net/http"
 
 type CollectionHandlerInterface interface {
 	GetMainPageCollections(w http.ResponseWriter, r *http.Request)
+	GetCollection(w http.ResponseWriter, r *http.Request)
+
+	GetAllCollections(w http.ResponseWriter, r *http.Request)
+	CreateCollection(w http.ResponseWriter, r *http.Request)
+	UpdateCollection(w http.ResponseWriter, r *http.Request)
+	UpdateCollectionMovies(w http.ResponseWriter, r *http.Request)
+	PublishCollection(w http.ResponseWriter, r *http.Request)
+	DeleteCollection(w http.ResponseWriter, r *http.Request)
+
+	BrowseLists(w http.ResponseWriter, r *http.Request)
+	GetMyLists(w http.ResponseWriter, r *http.Request)
+	GetList(w http.ResponseWriter, r *http.Request)
+	CreateList(w http.ResponseWriter, r *http.Request)
+	UpdateList(w http.ResponseWriter, r *http.Request)
+	ResetShareToken(w http.ResponseWriter, r *http.Request)
+	DeleteList(w http.ResponseWriter, r *http.Request)
 }
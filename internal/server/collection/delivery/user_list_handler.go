@@ -0,0 +1,297 @@
+package delivery
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/ds"
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/messages"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/collection/delivery/dto"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/middleware"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/validation/collection"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/jsonutil"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// getListID parses list id from path, error response is sent if it is invalid
+func getListID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	logger := log.Ctx(r.Context())
+
+	listID, err := strconv.Atoi(mux.Vars(r)["list_id"])
+	if err != nil {
+		errMsg := errors.Wrap(err, "list action: bad list_id")
+		logger.Error().Err(errMsg).Msg(errMsg.Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, errs.ErrBadPayload)
+		return 0, false
+	}
+
+	return listID, true
+}
+
+// parseList validates request and converts it to list
+func parseList(req dto.UserListRequest) (models.UserList, error) {
+	list := models.UserList{
+		Title:       strings.TrimSpace(req.Title),
+		Description: strings.TrimSpace(req.Description),
+		Cover:       strings.TrimSpace(req.Cover),
+		Visibility:  req.Visibility,
+		Entries:     make([]models.UserListEntry, 0, len(req.Movies)),
+	}
+	if list.Visibility == "" {
+		list.Visibility = models.VisibilityPrivate
+	}
+	for _, movie := range req.Movies {
+		list.Entries = append(list.Entries, models.UserListEntry{MovieID: movie.MovieID, Note: strings.TrimSpace(movie.Note)})
+	}
+
+	if err := collection.IsValidTitle(list.Title); err != nil {
+		return list, err
+	}
+	if err := collection.IsValidDescription(list.Description); err != nil {
+		return list, err
+	}
+	if err := collection.IsValidVisibility(list.Visibility); err != nil {
+		return list, err
+	}
+	if err := collection.IsValidEntries(list.Entries); err != nil {
+		return list, err
+	}
+
+	return list, nil
+}
+
+// readList reads and validates list from request body, error response is sent if it is invalid
+func readList(w http.ResponseWriter, r *http.Request) (models.UserList, bool) {
+	logger := log.Ctx(r.Context())
+
+	var req dto.UserListRequest
+	if err := jsonutil.ReadJSON(r, &req); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrParseJSON)).Msg(errors.Wrap(err, errs.ErrParseJSON).Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errors.Wrap(err, errs.ErrParseJSONShort).Error(), errs.ErrBadPayload)
+		return models.UserList{}, false
+	}
+
+	list, err := parseList(req)
+	if err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrInvalidUserList)).Msg(errors.Wrap(err, errs.ErrInvalidUserList).Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errors.Wrap(err, errs.ErrInvalidUserListShort).Error(),
+			errors.Wrap(err, errs.ErrInvalidUserList).Error())
+		return list, false
+	}
+
+	return list, true
+}
+
+// parseBrowseRequest reads owner filter and pagination from query string
+func parseBrowseRequest(query url.Values) (models.UserListRequest, error) {
+	limit, err := parseLimit(query)
+	if err != nil {
+		return models.UserListRequest{}, err
+	}
+
+	return models.UserListRequest{
+		Owner:  query.Get("owner"),
+		Limit:  limit,
+		Cursor: query.Get("cursor"),
+	}, nil
+}
+
+func sendListError(ctx context.Context, w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, errs.ErrMovieNotFound) || errors.Is(err, errs.ErrUserListNotFound):
+		jsonutil.SendError(ctx, w, http.StatusNotFound, errs.ErrNotFoundShort, err.Error())
+	case errors.Is(err, errs.ErrNotListOwner):
+		jsonutil.SendError(ctx, w, http.StatusForbidden, errs.ErrForbiddenShort, err.Error())
+	case errors.Is(err, errs.ErrInvalidCursor) || errors.Is(err, errs.ErrInvalidListRequest):
+		jsonutil.SendError(ctx, w, http.StatusBadRequest, errs.ErrBadPayload, err.Error())
+	default:
+		jsonutil.SendError(ctx, w, http.StatusInternalServerError, errs.ErrSomethingWentWrong, errs.ErrSomethingWentWrong)
+	}
+}
+
+// BrowseLists returns page of public lists, optionally of one user with owner=login
+func (h *CollectionHandler) BrowseLists(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	req, err := parseBrowseRequest(r.URL.Query())
+	if err != nil {
+		errMsg := errors.Wrap(err, "browseLists action: bad request")
+		logger.Error().Err(errMsg).Msg(errMsg.Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, err.Error())
+		return
+	}
+
+	page, err := h.collectionService.BrowseLists(r.Context(), req)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendListError(r.Context(), w, err)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, dto.NewUserListsJSON(page.Lists, page.NextCursor)); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
+
+// GetMyLists returns all lists of logged in user, private and unlisted included
+func (h *CollectionHandler) GetMyLists(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	username, ok := middleware.RequireUsername(w, r)
+	if !ok {
+		return
+	}
+
+	lists, err := h.collectionService.GetUserLists(r.Context(), username)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendListError(r.Context(), w, err)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, dto.NewUserListsJSON(lists, "")); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
+
+// GetList returns list with movies, unlisted list is opened by share link with token parameter
+func (h *CollectionHandler) GetList(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	listID, ok := getListID(w, r)
+	if !ok {
+		return
+	}
+
+	details, err := h.collectionService.GetList(r.Context(), middleware.Username(r.Context()), listID, r.URL.Query().Get("token"))
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendListError(r.Context(), w, err)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, dto.NewUserListDetailsJSON(*details, l10n.ParseLocale(r.URL.Query().Get(localeParam)))); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
+
+func (h *CollectionHandler) CreateList(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	username, ok := middleware.RequireUsername(w, r)
+	if !ok {
+		return
+	}
+	list, ok := readList(w, r)
+	if !ok {
+		return
+	}
+
+	logger.Info().Msgf("creating list of %s", username)
+	res, err := h.collectionService.CreateList(r.Context(), username, list)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendListError(r.Context(), w, err)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, dto.NewUserListJSON(*res)); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
+
+// UpdateList replaces list, movies are saved in order of request
+func (h *CollectionHandler) UpdateList(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	username, ok := middleware.RequireUsername(w, r)
+	if !ok {
+		return
+	}
+	listID, ok := getListID(w, r)
+	if !ok {
+		return
+	}
+	list, ok := readList(w, r)
+	if !ok {
+		return
+	}
+	list.ID = listID
+
+	logger.Info().Msgf("updating list %d of %s", listID, username)
+	res, err := h.collectionService.UpdateList(r.Context(), username, list)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendListError(r.Context(), w, err)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, dto.NewUserListJSON(*res)); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
+
+// ResetShareToken issues new share link of list, the old link stops working
+func (h *CollectionHandler) ResetShareToken(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	username, ok := middleware.RequireUsername(w, r)
+	if !ok {
+		return
+	}
+	listID, ok := getListID(w, r)
+	if !ok {
+		return
+	}
+
+	logger.Info().Msgf("resetting share link of list %d of %s", listID, username)
+	res, err := h.collectionService.ResetShareToken(r.Context(), username, listID)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendListError(r.Context(), w, err)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, dto.NewUserListJSON(*res)); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
+
+func (h *CollectionHandler) DeleteList(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	username, ok := middleware.RequireUsername(w, r)
+	if !ok {
+		return
+	}
+	listID, ok := getListID(w, r)
+	if !ok {
+		return
+	}
+
+	logger.Info().Msgf("deleting list %d of %s", listID, username)
+	if err := h.collectionService.DeleteList(r.Context(), username, listID); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendListError(r.Context(), w, err)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, ds.Response{Message: messages.SuccessfulListDelete}); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
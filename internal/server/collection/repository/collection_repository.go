@@ -2,25 +2,205 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"sort"
+	"sync"
 
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
 	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	collectionValidation "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/validation/collection"
 	"github.com/rs/zerolog/log"
 )
 
 type CollectionRepository struct {
-	db *mocks.Collections
+	mu     sync.RWMutex
+	db     *mocks.Collections
+	nextID int
 }
 
 func NewCollectionRepository(repo *mocks.Collections) *CollectionRepository {
 	return &CollectionRepository{
-		db: repo,
+		db:     repo,
+		nextID: nextCollectionID(*repo),
 	}
 }
 
-func (r *CollectionRepository) GetMainPageCollectionsFromRepo(ctx context.Context) (mocks.Collections, error) {
+func nextCollectionID(collections mocks.Collections) int {
+	nextID := 1
+	for id := range collections {
+		nextID = max(nextID, id+1)
+	}
+	return nextID
+}
+
+// sortEntries keeps entries of collection in position order, entries slice is copied
+func sortEntries(collection *models.Collection) {
+	entries := make([]models.CollectionEntry, len(collection.Entries))
+	copy(entries, collection.Entries)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Position < entries[j].Position
+	})
+	collection.Entries = entries
+}
+
+// slugTaken reports whether other collection uses slug, must be called under lock
+func (r *CollectionRepository) slugTaken(slug string, id int) bool {
+	for _, collection := range *r.db {
+		if collection.Slug == slug && collection.ID != id {
+			return true
+		}
+	}
+	return false
+}
+
+// GetCollections returns all collections, drafts included, ordered by id
+func (r *CollectionRepository) GetCollections(ctx context.Context) ([]models.Collection, error) {
 	logger := log.Ctx(ctx)
 
 	logger.Info().Msg("Get Collections from repo")
 
-	return *r.db, nil
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	res := make([]models.Collection, 0, len(*r.db))
+	for _, collection := range *r.db {
+		res = append(res, collection)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ID < res[j].ID
+	})
+
+	return res, nil
+}
+
+func (r *CollectionRepository) GetCollection(ctx context.Context, collectionID int) (*models.Collection, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	collection, ok := (*r.db)[collectionID]
+	if !ok {
+		return nil, errs.ErrCollectionNotExist
+	}
+
+	return &collection, nil
+}
+
+func (r *CollectionRepository) GetCollectionBySlug(ctx context.Context, slug string) (*models.Collection, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, collection := range *r.db {
+		if collection.Slug == slug {
+			return &collection, nil
+		}
+	}
+
+	return nil, errs.ErrCollectionNotExist
+}
+
+// CreateCollection saves collection with new id, slug must be unique
+func (r *CollectionRepository) CreateCollection(ctx context.Context, collection models.Collection) (*models.Collection, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.slugTaken(collection.Slug, 0) {
+		return nil, errs.ErrCollectionSlugTaken
+	}
+
+	collection.ID = r.nextID
+	r.nextID++
+	sortEntries(&collection)
+	(*r.db)[collection.ID] = collection
+
+	return &collection, nil
+}
+
+// UpdateCollection replaces existing collection with the same id, slug must stay unique
+func (r *CollectionRepository) UpdateCollection(ctx context.Context, collection models.Collection) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := (*r.db)[collection.ID]; !ok {
+		return errs.ErrCollectionNotExist
+	}
+	if r.slugTaken(collection.Slug, collection.ID) {
+		return errs.ErrCollectionSlugTaken
+	}
+
+	sortEntries(&collection)
+	(*r.db)[collection.ID] = collection
+
+	return nil
+}
+
+func (r *CollectionRepository) DeleteCollection(ctx context.Context, collectionID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := (*r.db)[collectionID]; !ok {
+		return errs.ErrCollectionNotExist
+	}
+	delete(*r.db, collectionID)
+
+	return nil
+}
+
+// UpsertCollection replaces entries of collection with the same name or creates published one.
+// Id, slug, description and publication of existing collection are kept, so edits of editors survive reimport
+func (r *CollectionRepository) UpsertCollection(ctx context.Context, collection models.Collection) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range *r.db {
+		if existing.Name == collection.Name {
+			existing.Entries = collection.Entries
+			sortEntries(&existing)
+			(*r.db)[existing.ID] = existing
+			return nil
+		}
+	}
+
+	collection.ID = r.nextID
+	if collection.Slug == "" {
+		collection.Slug = collectionValidation.SlugFromName(collection.Name)
+	}
+	if collection.Slug == "" || r.slugTaken(collection.Slug, 0) {
+		return errs.ErrCollectionSlugTaken
+	}
+	collection.Published = true
+	r.nextID++
+	sortEntries(&collection)
+	(*r.db)[collection.ID] = collection
+
+	return nil
+}
+
+// Snapshot serializes all collections
+func (r *CollectionRepository) Snapshot(ctx context.Context) ([]byte, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return json.Marshal(*r.db)
+}
+
+// Restore replaces all collections with ones from snapshot
+func (r *CollectionRepository) Restore(ctx context.Context, data []byte) error {
+	var collections mocks.Collections
+	if err := json.Unmarshal(data, &collections); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id := range *r.db {
+		delete(*r.db, id)
+	}
+	for id, collection := range collections {
+		(*r.db)[id] = collection
+	}
+	r.nextID = nextCollectionID(collections)
+
+	return nil
 }
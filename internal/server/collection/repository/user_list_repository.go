@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+)
+
+// UserListRepository lists created by users, separate from curated collections
+type UserListRepository struct {
+	mu     sync.RWMutex
+	db     map[int]models.UserList
+	nextID int
+}
+
+func NewUserListRepository() *UserListRepository {
+	return &UserListRepository{
+		db:     make(map[int]models.UserList),
+		nextID: 1,
+	}
+}
+
+// CreateList saves list with new id
+func (r *UserListRepository) CreateList(ctx context.Context, list models.UserList) (*models.UserList, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list.ID = r.nextID
+	r.nextID++
+	r.db[list.ID] = list
+
+	return &list, nil
+}
+
+// UpdateList replaces existing list with the same id
+func (r *UserListRepository) UpdateList(ctx context.Context, list models.UserList) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.db[list.ID]; !ok {
+		return errs.ErrUserListNotFound
+	}
+	r.db[list.ID] = list
+
+	return nil
+}
+
+func (r *UserListRepository) GetList(ctx context.Context, listID int) (*models.UserList, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list, ok := r.db[listID]
+	if !ok {
+		return nil, errs.ErrUserListNotFound
+	}
+
+	return &list, nil
+}
+
+func (r *UserListRepository) DeleteList(ctx context.Context, listID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.db[listID]; !ok {
+		return errs.ErrUserListNotFound
+	}
+	delete(r.db, listID)
+
+	return nil
+}
+
+// GetLists returns all lists, the recently updated first
+func (r *UserListRepository) GetLists(ctx context.Context) ([]models.UserList, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	res := make([]models.UserList, 0, len(r.db))
+	for _, list := range r.db {
+		res = append(res, list)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if !res[i].UpdatedAt.Equal(res[j].UpdatedAt) {
+			return res[i].UpdatedAt.After(res[j].UpdatedAt)
+		}
+		return res[i].ID > res[j].ID
+	})
+
+	return res, nil
+}
+
+// Snapshot serializes all user lists
+func (r *UserListRepository) Snapshot(ctx context.Context) ([]byte, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return json.Marshal(r.db)
+}
+
+// Restore replaces all user lists with ones from snapshot
+func (r *UserListRepository) Restore(ctx context.Context, data []byte) error {
+	var db map[int]models.UserList
+	if err := json.Unmarshal(data, &db); err != nil {
+		return err
+	}
+
+	nextID := 1
+	for id := range db {
+		nextID = max(nextID, id+1)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.db = db
+	r.nextID = nextID
+
+	return nil
+}
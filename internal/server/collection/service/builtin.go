@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/rs/zerolog/log"
+)
+
+// RegisterBuiltin sets source of movies of built-in collections of given kind
+func (s *CollectionService) RegisterBuiltin(kind models.BuiltinCollection, source BuiltinSourceInterface) {
+	s.builtins[kind] = source
+}
+
+// builtinEntries returns movies of built-in collection, collection of kind without source is empty
+func (s *CollectionService) builtinEntries(ctx context.Context, collection models.Collection) ([]models.CollectionEntry, error) {
+	source, ok := s.builtins[collection.Builtin]
+	if !ok {
+		log.Ctx(ctx).Warn().Str("builtin", string(collection.Builtin)).Msg("built-in collection has no source")
+		return nil, nil
+	}
+	return source.CollectionEntries(ctx)
+}
+
+// EnsureBuiltin creates published built-in collection unless collection of its kind exists,
+// existing one keeps name and publication set by editors
+func (s *CollectionService) EnsureBuiltin(ctx context.Context, collection models.Collection) (*models.Collection, error) {
+	logger := log.Ctx(ctx)
+
+	collections, err := s.collectionRepo.GetCollections(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+	for _, existing := range collections {
+		if existing.Builtin == collection.Builtin {
+			return &existing, nil
+		}
+	}
+
+	collection.Entries = nil
+	collection.Rule = nil
+	collection.Published = true
+	res, err := s.collectionRepo.CreateCollection(ctx, collection)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	logger.Info().Str("builtin", string(collection.Builtin)).Int("collection_id", res.ID).Msg("built-in collection created")
+	return res, nil
+}
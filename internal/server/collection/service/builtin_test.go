@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// builtinEntries source which returns the same entries every time
+type builtinEntries []models.CollectionEntry
+
+func (e builtinEntries) CollectionEntries(ctx context.Context) ([]models.CollectionEntry, error) {
+	return e, nil
+}
+
+func TestCollectionService_Builtin(t *testing.T) {
+	ctx := context.Background()
+	collections := mocks.Collections{}
+	s := newCollectionService(&collections)
+
+	previous := 0
+	s.RegisterBuiltin(models.BuiltinTop250, builtinEntries{
+		{Position: 0, MovieID: 7},
+		{Position: 1, MovieID: 3, PreviousPosition: &previous},
+		{Position: 2, MovieID: 42},
+		{Position: 3, MovieID: 5},
+	})
+
+	created, err := s.EnsureBuiltin(ctx, models.Collection{Slug: "top-250", Name: "Топ-250", Builtin: models.BuiltinTop250})
+	require.NoError(t, err)
+	assert.True(t, created.Published)
+
+	// editors may hide or rename built-in collection, it is not created again
+	_, err = s.PublishCollection(ctx, created.ID, false)
+	require.NoError(t, err)
+	again, err := s.EnsureBuiltin(ctx, models.Collection{Slug: "top-250", Name: "Топ-250", Builtin: models.BuiltinTop250})
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, again.ID)
+	assert.False(t, again.Published)
+	_, err = s.PublishCollection(ctx, created.ID, true)
+	require.NoError(t, err)
+
+	page, err := s.GetCollection(ctx, "top-250", models.CollectionRequest{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []int{7, 3, 5}, itemMovieIDs(page.Items), "deleted movie is skipped")
+	assert.Nil(t, page.Items[0].PreviousPosition)
+	require.NotNil(t, page.Items[1].PreviousPosition)
+	assert.Equal(t, 0, *page.Items[1].PreviousPosition)
+
+	_, err = s.SetCollectionMovies(ctx, created.ID, []int{1, 2})
+	require.ErrorIs(t, err, errs.ErrBuiltinCollection)
+}
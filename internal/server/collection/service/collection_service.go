@@ -2,35 +2,325 @@ package service
 
 import (
 	"context"
+	"strconv"
+	"sync"
 
-	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/cursor"
+	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 )
 
+// mainPagePreviewSize movies of each collection shown on main page, the rest is paged by collection endpoint
+const mainPagePreviewSize = 10
+
 type CollectionRepositoryInterface interface {
-	GetMainPageCollectionsFromRepo(ctx context.Context) (mocks.Collections, error)
+	GetCollections(ctx context.Context) ([]models.Collection, error)
+	GetCollection(ctx context.Context, collectionID int) (*models.Collection, error)
+	GetCollectionBySlug(ctx context.Context, slug string) (*models.Collection, error)
+	CreateCollection(ctx context.Context, collection models.Collection) (*models.Collection, error)
+	UpdateCollection(ctx context.Context, collection models.Collection) error
+	DeleteCollection(ctx context.Context, collectionID int) error
+}
+
+type UserListRepositoryInterface interface {
+	CreateList(ctx context.Context, list models.UserList) (*models.UserList, error)
+	UpdateList(ctx context.Context, list models.UserList) error
+	GetList(ctx context.Context, listID int) (*models.UserList, error)
+	DeleteList(ctx context.Context, listID int) error
+	GetLists(ctx context.Context) ([]models.UserList, error)
+}
+
+type MovieRepositoryInterface interface {
+	GetMovieFromRepoByID(ctx context.Context, movieID int) (*models.Movie, error)
+}
+
+type UserRepositoryInterface interface {
+	GetUser(ctx context.Context, login string) (*models.User, error)
+	GetUserByID(ctx context.Context, userID int) (*models.User, error)
 }
 
+// MovieCatalogInterface evaluates rules of smart collections
+type MovieCatalogInterface interface {
+	ListMovies(ctx context.Context, req models.MovieListRequest) (*models.MoviePage, error)
+}
+
+// BuiltinSourceInterface computes movies of built-in collection
+type BuiltinSourceInterface interface {
+	CollectionEntries(ctx context.Context) ([]models.CollectionEntry, error)
+}
+
+// CollectionService collections of main page and lists created by users
 type CollectionService struct {
 	collectionRepo CollectionRepositoryInterface
+	userListRepo   UserListRepositoryInterface
+	movieRepo      MovieRepositoryInterface
+	userRepo       UserRepositoryInterface
+	catalog        MovieCatalogInterface
+
+	// builtins sources of built-in collections by kind, they are registered on start
+	builtins map[models.BuiltinCollection]BuiltinSourceInterface
+
+	// smart caches movies selected by rules of smart collections until catalog changes
+	smartMu  sync.RWMutex
+	smart    map[int]smartResult
+	smartGen int
 }
 
-func NewCollectionService(collectionRepo CollectionRepositoryInterface) *CollectionService {
+func NewCollectionService(collectionRepo CollectionRepositoryInterface, userListRepo UserListRepositoryInterface,
+	movieRepo MovieRepositoryInterface, userRepo UserRepositoryInterface, catalog MovieCatalogInterface) *CollectionService {
 	return &CollectionService{
 		collectionRepo: collectionRepo,
+		userListRepo:   userListRepo,
+		movieRepo:      movieRepo,
+		userRepo:       userRepo,
+		catalog:        catalog,
+		builtins:       make(map[models.BuiltinCollection]BuiltinSourceInterface),
+		smart:          make(map[int]smartResult),
+	}
+}
+
+// collectionPage hydrates entries of collection with movies and cuts requested page
+func (s *CollectionService) collectionPage(ctx context.Context, collection models.Collection, req models.CollectionRequest) (*models.CollectionPage, error) {
+	var err error
+	switch {
+	case collection.IsSmart():
+		collection.Entries, err = s.smartEntries(ctx, collection)
+	case collection.IsBuiltin():
+		collection.Entries, err = s.builtinEntries(ctx, collection)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]models.CollectionItem, 0, len(collection.Entries))
+	for _, entry := range collection.Entries {
+		movie, err := s.movieRepo.GetMovieFromRepoByID(ctx, entry.MovieID)
+		if errors.Is(err, errs.ErrMovieNotFound) {
+			// movie was deleted from catalog after it was added
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, models.CollectionItem{
+			Position:         entry.Position,
+			PreviousPosition: entry.PreviousPosition,
+			Premiere:         entry.Premiere,
+			Movie:            *movie,
+		})
+	}
+
+	page, err := cursor.Paginate(items, func(item models.CollectionItem) cursor.Key {
+		return cursor.Key{Num: float64(item.Position), ID: item.Movie.ID}
+	}, cursor.Request{Scope: cursor.Scope(collection.ID), Limit: req.Limit, Cursor: req.Cursor})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.CollectionPage{Collection: collection, Items: page.Items, Total: page.Total, NextCursor: page.NextCursor}, nil
+}
+
+// entriesOf places movies in collection in given order, all movies must be in catalog
+func (s *CollectionService) entriesOf(ctx context.Context, movieIDs []int) ([]models.CollectionEntry, error) {
+	entries := make([]models.CollectionEntry, 0, len(movieIDs))
+	for i, movieID := range movieIDs {
+		if _, err := s.movieRepo.GetMovieFromRepoByID(ctx, movieID); err != nil {
+			return nil, err
+		}
+		entries = append(entries, models.CollectionEntry{Position: i, MovieID: movieID})
 	}
+	return entries, nil
 }
 
-func (s *CollectionService) GetMainPageCollections(ctx context.Context) (mocks.Collections, error) {
+// GetMainPageCollections returns published collections with the first movies of each
+func (s *CollectionService) GetMainPageCollections(ctx context.Context) ([]models.CollectionPage, error) {
 	logger := log.Ctx(ctx)
 
 	logger.Info().Msg("Get Collections from service")
 
-	collections, err := s.collectionRepo.GetMainPageCollectionsFromRepo(ctx)
+	collections, err := s.collectionRepo.GetCollections(ctx)
 	if err != nil {
 		logger.Err(err).Msg(err.Error())
 		return nil, err
 	}
 
+	res := make([]models.CollectionPage, 0, len(collections))
+	for _, collection := range collections {
+		if !collection.Published {
+			continue
+		}
+
+		page, err := s.collectionPage(ctx, collection, models.CollectionRequest{Limit: mainPagePreviewSize})
+		if err != nil {
+			logger.Err(err).Msg(err.Error())
+			return nil, err
+		}
+		res = append(res, *page)
+	}
+
+	return res, nil
+}
+
+// GetCollection returns page of published collection found by id or by slug
+func (s *CollectionService) GetCollection(ctx context.Context, key string, req models.CollectionRequest) (*models.CollectionPage, error) {
+	logger := log.Ctx(ctx)
+
+	if req.Limit <= 0 {
+		logger.Error().Int("limit", req.Limit).Msg(errs.ErrBadPayload)
+		return nil, errs.ErrInvalidCollectionRequest
+	}
+
+	var collection *models.Collection
+	var err error
+	if collectionID, convErr := strconv.Atoi(key); convErr == nil {
+		collection, err = s.collectionRepo.GetCollection(ctx, collectionID)
+	} else {
+		collection, err = s.collectionRepo.GetCollectionBySlug(ctx, key)
+	}
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+	if !collection.Published {
+		logger.Error().Str("collection", key).Msg("collection is not published")
+		return nil, errs.ErrCollectionNotExist
+	}
+
+	page, err := s.collectionPage(ctx, *collection, req)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// GetAllCollections returns collections for editors, drafts included
+func (s *CollectionService) GetAllCollections(ctx context.Context) ([]models.Collection, error) {
+	logger := log.Ctx(ctx)
+
+	collections, err := s.collectionRepo.GetCollections(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
 	return collections, nil
 }
+
+// CreateCollection saves draft collection, movies of curated one are placed in given order
+func (s *CollectionService) CreateCollection(ctx context.Context, collection models.Collection, movieIDs []int) (*models.Collection, error) {
+	logger := log.Ctx(ctx)
+
+	if collection.IsSmart() && len(movieIDs) > 0 {
+		logger.Error().Msg(errs.ErrSmartCollection.Error())
+		return nil, errs.ErrSmartCollection
+	}
+
+	entries, err := s.entriesOf(ctx, movieIDs)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	collection.Entries = entries
+	collection.Published = false
+	res, err := s.collectionRepo.CreateCollection(ctx, collection)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// UpdateCollection changes name, slug and description of collection and rule of smart one.
+// Rule is kept when it is not given, curated collection can not get rule
+func (s *CollectionService) UpdateCollection(ctx context.Context, collection models.Collection) (*models.Collection, error) {
+	logger := log.Ctx(ctx)
+
+	res, err := s.collectionRepo.GetCollection(ctx, collection.ID)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	if collection.IsSmart() {
+		if !res.IsSmart() {
+			logger.Error().Int("collection_id", res.ID).Msg(errs.ErrSmartCollection.Error())
+			return nil, errs.ErrSmartCollection
+		}
+		res.Rule = collection.Rule
+	}
+	res.Name = collection.Name
+	res.Slug = collection.Slug
+	res.Description = collection.Description
+	if err = s.collectionRepo.UpdateCollection(ctx, *res); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// SetCollectionMovies replaces movies of collection, positions follow given order
+func (s *CollectionService) SetCollectionMovies(ctx context.Context, collectionID int, movieIDs []int) (*models.Collection, error) {
+	logger := log.Ctx(ctx)
+
+	res, err := s.collectionRepo.GetCollection(ctx, collectionID)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+	if res.IsSmart() {
+		logger.Error().Int("collection_id", res.ID).Msg(errs.ErrSmartCollection.Error())
+		return nil, errs.ErrSmartCollection
+	}
+	if res.IsBuiltin() {
+		logger.Error().Int("collection_id", res.ID).Msg(errs.ErrBuiltinCollection.Error())
+		return nil, errs.ErrBuiltinCollection
+	}
+
+	if res.Entries, err = s.entriesOf(ctx, movieIDs); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+	if err = s.collectionRepo.UpdateCollection(ctx, *res); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// PublishCollection shows collection on main page or hides it back to drafts
+func (s *CollectionService) PublishCollection(ctx context.Context, collectionID int, published bool) (*models.Collection, error) {
+	logger := log.Ctx(ctx)
+
+	res, err := s.collectionRepo.GetCollection(ctx, collectionID)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	res.Published = published
+	if err = s.collectionRepo.UpdateCollection(ctx, *res); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *CollectionService) DeleteCollection(ctx context.Context, collectionID int) error {
+	logger := log.Ctx(ctx)
+
+	if err := s.collectionRepo.DeleteCollection(ctx, collectionID); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	repoCollection "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/collection/repository"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	repoMovie "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/repository"
+	serviceMovie "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/service"
+	repoUser "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/user/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func itemMovieIDs(items []models.CollectionItem) []int {
+	ids := make([]int, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.Movie.ID)
+	}
+	return ids
+}
+
+func newCollectionService(collections *mocks.Collections) *CollectionService {
+	movies := mocks.Movies{}
+	for id := 1; id <= 15; id++ {
+		movies[id] = models.Movie{ID: id}
+	}
+	movieRepo := repoMovie.NewMovieRepository(&movies)
+	return NewCollectionService(repoCollection.NewCollectionRepository(collections), repoCollection.NewUserListRepository(),
+		movieRepo, repoUser.NewUserRepository(), serviceMovie.NewMovieService(movieRepo, nil, nil, nil))
+}
+
+func TestCollectionService_GetCollection(t *testing.T) {
+	ctx := context.Background()
+
+	movieIDs := []int{15, 3, 7, 1, 12, 42}
+	entries := make([]models.CollectionEntry, 0, len(movieIDs))
+	for i, id := range movieIDs {
+		// positions need not be contiguous
+		entries = append(entries, models.CollectionEntry{Position: i * 10, MovieID: id})
+	}
+	collections := mocks.Collections{
+		1: {ID: 1, Slug: "best", Name: "Лучшие", Published: true, Entries: entries},
+		2: {ID: 2, Slug: "draft", Name: "Черновик", Entries: entries},
+		3: {ID: 3, Slug: "other", Name: "Другие", Published: true, Entries: entries},
+	}
+	s := newCollectionService(&collections)
+
+	var got []int
+	req := models.CollectionRequest{Limit: 2}
+	for {
+		page, err := s.GetCollection(ctx, "best", req)
+		require.NoError(t, err)
+		assert.Equal(t, 5, page.Total, "deleted movie is skipped")
+		got = append(got, itemMovieIDs(page.Items)...)
+		if page.NextCursor == "" {
+			break
+		}
+		req.Cursor = page.NextCursor
+	}
+	assert.Equal(t, []int{15, 3, 7, 1, 12}, got)
+
+	page, err := s.GetCollection(ctx, "1", models.CollectionRequest{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, "Лучшие", page.Collection.Name)
+
+	_, err = s.GetCollection(ctx, "draft", models.CollectionRequest{Limit: 10})
+	assert.ErrorIs(t, err, errs.ErrCollectionNotExist)
+	_, err = s.GetCollection(ctx, "4", models.CollectionRequest{Limit: 10})
+	assert.ErrorIs(t, err, errs.ErrCollectionNotExist)
+	_, err = s.GetCollection(ctx, "best", models.CollectionRequest{Limit: 10, Cursor: "bad"})
+	assert.ErrorIs(t, err, errs.ErrInvalidCursor)
+
+	page, err = s.GetCollection(ctx, "best", models.CollectionRequest{Limit: 2})
+	require.NoError(t, err)
+	_, err = s.GetCollection(ctx, "other", models.CollectionRequest{Limit: 2, Cursor: page.NextCursor})
+	assert.ErrorIs(t, err, errs.ErrInvalidCursor, "cursor of another collection")
+
+	main, err := s.GetMainPageCollections(ctx)
+	require.NoError(t, err)
+	require.Len(t, main, 2)
+	assert.Equal(t, 1, main[0].Collection.ID)
+}
+
+func TestCollectionService_EditCollection(t *testing.T) {
+	ctx := context.Background()
+
+	collections := mocks.Collections{}
+	s := newCollectionService(&collections)
+
+	created, err := s.CreateCollection(ctx, models.Collection{Name: "Новинки", Slug: "new"}, []int{5, 2})
+	require.NoError(t, err)
+	assert.False(t, created.Published)
+	assert.Equal(t, []models.CollectionEntry{{Position: 0, MovieID: 5}, {Position: 1, MovieID: 2}}, created.Entries)
+
+	_, err = s.CreateCollection(ctx, models.Collection{Name: "Другие", Slug: "new"}, nil)
+	assert.ErrorIs(t, err, errs.ErrCollectionSlugTaken)
+	_, err = s.CreateCollection(ctx, models.Collection{Name: "Другие", Slug: "other"}, []int{100})
+	assert.ErrorIs(t, err, errs.ErrMovieNotFound)
+
+	main, err := s.GetMainPageCollections(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, main, "draft is not shown")
+
+	_, err = s.PublishCollection(ctx, created.ID, true)
+	require.NoError(t, err)
+	_, err = s.SetCollectionMovies(ctx, created.ID, []int{2, 9, 5})
+	require.NoError(t, err)
+	updated, err := s.UpdateCollection(ctx, models.Collection{ID: created.ID, Name: "Новинки месяца", Slug: "new-month"})
+	require.NoError(t, err)
+	assert.True(t, updated.Published)
+
+	page, err := s.GetCollection(ctx, "new-month", models.CollectionRequest{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 9, 5}, itemMovieIDs(page.Items))
+
+	require.NoError(t, s.DeleteCollection(ctx, created.ID))
+	assert.ErrorIs(t, s.DeleteCollection(ctx, created.ID), errs.ErrCollectionNotExist)
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+)
+
+// smartResult movies selected by rule, rule is kept to notice its edits
+type smartResult struct {
+	rule    models.SmartRule
+	entries []models.CollectionEntry
+}
+
+// smartEntries returns movies of smart collection, rule is evaluated over catalog when there is no cached result
+func (s *CollectionService) smartEntries(ctx context.Context, collection models.Collection) ([]models.CollectionEntry, error) {
+	rule := *collection.Rule
+
+	s.smartMu.RLock()
+	cached, ok := s.smart[collection.ID]
+	gen := s.smartGen
+	s.smartMu.RUnlock()
+	if ok && reflect.DeepEqual(cached.rule, rule) {
+		return cached.entries, nil
+	}
+
+	page, err := s.catalog.ListMovies(ctx, models.MovieListRequest{
+		Filter: rule.Filter,
+		Sort:   rule.Sort,
+		Desc:   rule.Desc,
+		Limit:  rule.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]models.CollectionEntry, 0, len(page.Movies))
+	for i, movie := range page.Movies {
+		entries = append(entries, models.CollectionEntry{Position: i, MovieID: movie.ID})
+	}
+
+	s.smartMu.Lock()
+	// catalog may have changed while rule was evaluated, then result is already stale
+	if gen == s.smartGen {
+		s.smart[collection.ID] = smartResult{rule: rule, entries: entries}
+	}
+	s.smartMu.Unlock()
+
+	return entries, nil
+}
+
+// resetSmart drops cached movies of all smart collections
+func (s *CollectionService) resetSmart() {
+	s.smartMu.Lock()
+	defer s.smartMu.Unlock()
+
+	s.smart = make(map[int]smartResult)
+	s.smartGen++
+}
+
+// OnMovieUpsert refreshes smart collections when catalog changes
+func (s *CollectionService) OnMovieUpsert(ctx context.Context, movie models.Movie) {
+	s.resetSmart()
+}
+
+// OnMovieDelete refreshes smart collections when catalog changes
+func (s *CollectionService) OnMovieDelete(ctx context.Context, movieID int) {
+	s.resetSmart()
+}
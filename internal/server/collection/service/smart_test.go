@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	repoCollection "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/collection/repository"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	repoMovie "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/repository"
+	serviceMovie "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/service"
+	repoUser "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/user/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectionService_SmartCollection(t *testing.T) {
+	ctx := context.Background()
+
+	drama := models.Genre{ID: 8, Name: "драма"}
+	movies := mocks.Movies{
+		1: {ID: 1, ReleaseYear: 1994, Rating: 9.1, Genres: []models.Genre{drama}},
+		2: {ID: 2, ReleaseYear: 1972, Rating: 8.7, Genres: []models.Genre{drama}},
+		3: {ID: 3, ReleaseYear: 2008, Rating: 8.9, Genres: []models.Genre{drama}},
+		4: {ID: 4, ReleaseYear: 1999, Rating: 8.6},
+		5: {ID: 5, ReleaseYear: 1990, Rating: 7.5, Genres: []models.Genre{drama}},
+	}
+	movieRepo := repoMovie.NewMovieRepository(&movies)
+	collections := mocks.Collections{}
+	s := NewCollectionService(repoCollection.NewCollectionRepository(&collections), repoCollection.NewUserListRepository(),
+		movieRepo, repoUser.NewUserRepository(), serviceMovie.NewMovieService(movieRepo, nil, nil, nil))
+	movieRepo.Subscribe(s)
+
+	// dramas before 2000 rated above 8
+	rule := models.SmartRule{
+		Filter: models.MovieFilter{GenreIDs: []int{8}, YearTo: 1999, RatingFrom: 8},
+		Sort:   models.SortByRating,
+		Desc:   true,
+		Limit:  10,
+	}
+	created, err := s.CreateCollection(ctx, models.Collection{Name: "Драмы", Slug: "dramas", Rule: &rule}, nil)
+	require.NoError(t, err)
+	_, err = s.PublishCollection(ctx, created.ID, true)
+	require.NoError(t, err)
+
+	page, err := s.GetCollection(ctx, "dramas", models.CollectionRequest{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, itemMovieIDs(page.Items))
+
+	// catalog change is picked up
+	require.NoError(t, movieRepo.UpsertMovie(ctx, models.Movie{ID: 6, ReleaseYear: 1993, Rating: 8.9, Genres: []models.Genre{drama}}))
+	page, err = s.GetCollection(ctx, "dramas", models.CollectionRequest{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 6, 2}, itemMovieIDs(page.Items))
+
+	// rule edit is picked up
+	newRule := rule
+	newRule.Filter.RatingFrom = 7
+	newRule.Limit = 2
+	_, err = s.UpdateCollection(ctx, models.Collection{ID: created.ID, Name: "Драмы", Slug: "dramas", Rule: &newRule})
+	require.NoError(t, err)
+	main, err := s.GetMainPageCollections(ctx)
+	require.NoError(t, err)
+	require.Len(t, main, 1)
+	assert.Equal(t, []int{1, 6}, itemMovieIDs(main[0].Items))
+
+	_, err = s.SetCollectionMovies(ctx, created.ID, []int{3})
+	assert.ErrorIs(t, err, errs.ErrSmartCollection)
+	_, err = s.CreateCollection(ctx, models.Collection{Name: "Смесь", Slug: "mix", Rule: &rule}, []int{3})
+	assert.ErrorIs(t, err, errs.ErrSmartCollection)
+
+	curated, err := s.CreateCollection(ctx, models.Collection{Name: "Избранное", Slug: "favourite"}, []int{3})
+	require.NoError(t, err)
+	_, err = s.UpdateCollection(ctx, models.Collection{ID: curated.ID, Name: "Избранное", Slug: "favourite", Rule: &rule})
+	assert.ErrorIs(t, err, errs.ErrSmartCollection)
+}
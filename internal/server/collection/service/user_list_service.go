@@ -0,0 +1,313 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/cursor"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/session"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// shareTokenLength random bytes behind share token of list
+const shareTokenLength = 32
+
+// listKey sorts lists by time of update, the key is in microseconds which float keeps exactly
+func listKey(list models.UserList) cursor.Key {
+	return cursor.Key{Num: float64(list.UpdatedAt.UnixMicro()), ID: list.ID}
+}
+
+// viewerID returns id of user by login, anonymous viewer with empty login gets zero
+func (s *CollectionService) viewerID(ctx context.Context, username string) (int, error) {
+	if username == "" {
+		return 0, nil
+	}
+
+	user, err := s.userRepo.GetUser(ctx, username)
+	if err != nil {
+		return 0, err
+	}
+	return user.ID, nil
+}
+
+// fillOwnerLogins sets current logins of list owners
+func (s *CollectionService) fillOwnerLogins(ctx context.Context, lists []models.UserList) error {
+	logins := make(map[int]string)
+	for i := range lists {
+		login, ok := logins[lists[i].OwnerID]
+		if !ok {
+			owner, err := s.userRepo.GetUserByID(ctx, lists[i].OwnerID)
+			if err != nil {
+				return err
+			}
+			login = owner.Username
+			logins[lists[i].OwnerID] = login
+		}
+		lists[i].OwnerLogin = login
+	}
+	return nil
+}
+
+// checkMovies makes sure all movies of entries are in catalog
+func (s *CollectionService) checkMovies(ctx context.Context, entries []models.UserListEntry) error {
+	for _, entry := range entries {
+		if _, err := s.movieRepo.GetMovieFromRepoByID(ctx, entry.MovieID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getOwnedList returns list which user can edit, lists hidden from user are reported as not found
+func (s *CollectionService) getOwnedList(ctx context.Context, username string, listID int) (*models.UserList, error) {
+	user, err := s.userRepo.GetUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := s.userListRepo.GetList(ctx, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	if list.OwnerID != user.ID {
+		if list.CanView(user.ID, "") {
+			return nil, errs.ErrNotListOwner
+		}
+		return nil, errs.ErrUserListNotFound
+	}
+	list.OwnerLogin = user.Username
+
+	return list, nil
+}
+
+// CreateList saves new list of user with fresh share token
+func (s *CollectionService) CreateList(ctx context.Context, username string, list models.UserList) (*models.UserList, error) {
+	logger := log.Ctx(ctx)
+
+	user, err := s.userRepo.GetUser(ctx, username)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	if err = s.checkMovies(ctx, list.Entries); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	if list.ShareToken, err = session.GenerateSessionID(shareTokenLength); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	list.OwnerID = user.ID
+	list.CreatedAt = time.Now().UTC()
+	list.UpdatedAt = list.CreatedAt
+
+	res, err := s.userListRepo.CreateList(ctx, list)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+	res.OwnerLogin = user.Username
+
+	return res, nil
+}
+
+// UpdateList replaces title, description, cover, visibility and entries of list
+func (s *CollectionService) UpdateList(ctx context.Context, username string, list models.UserList) (*models.UserList, error) {
+	logger := log.Ctx(ctx)
+
+	res, err := s.getOwnedList(ctx, username, list.ID)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	if err = s.checkMovies(ctx, list.Entries); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	res.Title = list.Title
+	res.Description = list.Description
+	res.Cover = list.Cover
+	res.Visibility = list.Visibility
+	res.Entries = list.Entries
+	res.UpdatedAt = time.Now().UTC()
+	if err = s.userListRepo.UpdateList(ctx, *res); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ResetShareToken replaces share token of list, links shared before stop working
+func (s *CollectionService) ResetShareToken(ctx context.Context, username string, listID int) (*models.UserList, error) {
+	logger := log.Ctx(ctx)
+
+	res, err := s.getOwnedList(ctx, username, listID)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	if res.ShareToken, err = session.GenerateSessionID(shareTokenLength); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+	if err = s.userListRepo.UpdateList(ctx, *res); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *CollectionService) DeleteList(ctx context.Context, username string, listID int) error {
+	logger := log.Ctx(ctx)
+
+	if _, err := s.getOwnedList(ctx, username, listID); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return err
+	}
+
+	if err := s.userListRepo.DeleteList(ctx, listID); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// GetList returns list with its movies if viewer may see it, empty username means anonymous viewer.
+// Share token is revealed only to owner
+func (s *CollectionService) GetList(ctx context.Context, username string, listID int, token string) (*models.UserListDetails, error) {
+	logger := log.Ctx(ctx)
+
+	userID, err := s.viewerID(ctx, username)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	list, err := s.userListRepo.GetList(ctx, listID)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	if !list.CanView(userID, token) {
+		logger.Error().Int("list_id", listID).Msg(errs.ErrUserListNotFound.Error())
+		return nil, errs.ErrUserListNotFound
+	}
+	if list.OwnerID != userID {
+		list.ShareToken = ""
+	}
+	owner, err := s.userRepo.GetUserByID(ctx, list.OwnerID)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+	list.OwnerLogin = owner.Username
+
+	res := &models.UserListDetails{
+		List:  *list,
+		Items: make([]models.UserListItem, 0, len(list.Entries)),
+	}
+	for _, entry := range list.Entries {
+		movie, err := s.movieRepo.GetMovieFromRepoByID(ctx, entry.MovieID)
+		if errors.Is(err, errs.ErrMovieNotFound) {
+			// movie was deleted from catalog after it was added
+			continue
+		}
+		if err != nil {
+			logger.Error().Err(err).Msg(err.Error())
+			return nil, err
+		}
+		res.Items = append(res.Items, models.UserListItem{Entry: entry, Movie: *movie})
+	}
+
+	return res, nil
+}
+
+// GetUserLists returns all lists of user whatever their visibility, the recently updated first
+func (s *CollectionService) GetUserLists(ctx context.Context, username string) ([]models.UserList, error) {
+	logger := log.Ctx(ctx)
+
+	user, err := s.userRepo.GetUser(ctx, username)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	lists, err := s.userListRepo.GetLists(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	res := make([]models.UserList, 0)
+	for _, list := range lists {
+		if list.OwnerID == user.ID {
+			list.OwnerLogin = user.Username
+			res = append(res, list)
+		}
+	}
+
+	return res, nil
+}
+
+// BrowseLists returns page of public lists of all users or of one owner, the recently updated first
+func (s *CollectionService) BrowseLists(ctx context.Context, req models.UserListRequest) (*models.UserListPage, error) {
+	logger := log.Ctx(ctx)
+
+	if req.Limit <= 0 {
+		logger.Error().Int("limit", req.Limit).Msg(errs.ErrBadPayload)
+		return nil, errs.ErrInvalidListRequest
+	}
+
+	// lists are kept by id of owner, which stays the same when owner changes login
+	var ownerID int
+	if req.Owner != "" {
+		owner, err := s.userRepo.GetUser(ctx, req.Owner)
+		if err != nil {
+			// unknown user has no lists
+			logger.Info().Str("owner", req.Owner).Msg(err.Error())
+			return &models.UserListPage{Lists: make([]models.UserList, 0)}, nil
+		}
+		ownerID = owner.ID
+	}
+
+	lists, err := s.userListRepo.GetLists(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	public := make([]models.UserList, 0, len(lists))
+	for _, list := range lists {
+		if list.Visibility == models.VisibilityPublic && (ownerID == 0 || list.OwnerID == ownerID) {
+			list.ShareToken = ""
+			public = append(public, list)
+		}
+	}
+
+	page, err := cursor.Paginate(public, listKey, cursor.Request{Scope: cursor.Scope(ownerID), Desc: true, Limit: req.Limit, Cursor: req.Cursor})
+	if err != nil {
+		logger.Error().Err(err).Str("cursor", req.Cursor).Msg(err.Error())
+		return nil, err
+	}
+
+	if err = s.fillOwnerLogins(ctx, page.Items); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return &models.UserListPage{Lists: page.Items, NextCursor: page.NextCursor}, nil
+}
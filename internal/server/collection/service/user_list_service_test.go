@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	repoCollection "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/collection/repository"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	repoMovie "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/repository"
+	serviceMovie "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/service"
+	repoUser "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/user/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func listIDs(lists []models.UserList) []int {
+	ids := make([]int, 0, len(lists))
+	for _, list := range lists {
+		ids = append(ids, list.ID)
+	}
+	return ids
+}
+
+func newUserListService(t *testing.T) (*CollectionService, *repoUser.UserRepository) {
+	ctx := context.Background()
+
+	movies := mocks.Movies{1: {ID: 1, Name: "Матрица"}, 2: {ID: 2, Name: "Чужой"}, 3: {ID: 3, Name: "Сталкер"}}
+	userRepo := repoUser.NewUserRepository()
+	require.NoError(t, userRepo.CreateUser(ctx, &models.User{Username: "neo"}))
+	require.NoError(t, userRepo.CreateUser(ctx, &models.User{Username: "trinity"}))
+
+	collections := mocks.Collections{}
+	movieRepo := repoMovie.NewMovieRepository(&movies)
+	return NewCollectionService(repoCollection.NewCollectionRepository(&collections), repoCollection.NewUserListRepository(),
+		movieRepo, userRepo, serviceMovie.NewMovieService(movieRepo, nil, nil, nil)), userRepo
+}
+
+func TestCollectionService_UserListVisibility(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newUserListService(t)
+
+	entries := []models.UserListEntry{{MovieID: 3, Note: "начать с него"}, {MovieID: 1}}
+	public, err := s.CreateList(ctx, "neo", models.UserList{Title: "Фантастика", Visibility: models.VisibilityPublic, Entries: entries})
+	require.NoError(t, err)
+	unlisted, err := s.CreateList(ctx, "neo", models.UserList{Title: "Для друзей", Visibility: models.VisibilityUnlisted})
+	require.NoError(t, err)
+	private, err := s.CreateList(ctx, "neo", models.UserList{Title: "Черновик", Visibility: models.VisibilityPrivate})
+	require.NoError(t, err)
+	require.NotEmpty(t, unlisted.ShareToken)
+
+	_, err = s.CreateList(ctx, "neo", models.UserList{Title: "Нет фильма", Entries: []models.UserListEntry{{MovieID: 42}}})
+	assert.ErrorIs(t, err, errs.ErrMovieNotFound)
+
+	tests := []struct {
+		name    string
+		viewer  string
+		listID  int
+		token   string
+		wantErr error
+	}{
+		{name: "public to anonymous", listID: public.ID},
+		{name: "unlisted by link", viewer: "trinity", listID: unlisted.ID, token: unlisted.ShareToken},
+		{name: "unlisted without link", viewer: "trinity", listID: unlisted.ID, wantErr: errs.ErrUserListNotFound},
+		{name: "unlisted with wrong link", listID: unlisted.ID, token: "wrong", wantErr: errs.ErrUserListNotFound},
+		{name: "private to owner", viewer: "neo", listID: private.ID},
+		{name: "private to other", viewer: "trinity", listID: private.ID, token: private.ShareToken, wantErr: errs.ErrUserListNotFound},
+		{name: "missing", listID: 100, wantErr: errs.ErrUserListNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			details, err := s.GetList(ctx, tt.viewer, tt.listID, tt.token)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.listID, details.List.ID)
+			assert.Equal(t, "neo", details.List.OwnerLogin)
+			assert.Equal(t, tt.viewer == "neo", details.List.ShareToken != "")
+		})
+	}
+
+	details, err := s.GetList(ctx, "", public.ID, "")
+	require.NoError(t, err)
+	require.Len(t, details.Items, 2)
+	assert.Equal(t, 3, details.Items[0].Movie.ID)
+	assert.Equal(t, "начать с него", details.Items[0].Entry.Note)
+	assert.Equal(t, 1, details.Items[1].Movie.ID)
+}
+
+func TestCollectionService_EditUserList(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newUserListService(t)
+
+	list, err := s.CreateList(ctx, "neo", models.UserList{Title: "Фантастика", Visibility: models.VisibilityPublic})
+	require.NoError(t, err)
+	hidden, err := s.CreateList(ctx, "neo", models.UserList{Title: "Черновик", Visibility: models.VisibilityPrivate})
+	require.NoError(t, err)
+
+	_, err = s.UpdateList(ctx, "trinity", models.UserList{ID: list.ID, Title: "Моё"})
+	assert.ErrorIs(t, err, errs.ErrNotListOwner)
+	_, err = s.UpdateList(ctx, "trinity", models.UserList{ID: hidden.ID, Title: "Моё"})
+	assert.ErrorIs(t, err, errs.ErrUserListNotFound)
+	assert.ErrorIs(t, s.DeleteList(ctx, "trinity", list.ID), errs.ErrNotListOwner)
+
+	updated, err := s.UpdateList(ctx, "neo", models.UserList{
+		ID:         list.ID,
+		Title:      "Лучшая фантастика",
+		Visibility: models.VisibilityUnlisted,
+		Entries:    []models.UserListEntry{{MovieID: 2}, {MovieID: 1}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Лучшая фантастика", updated.Title)
+	assert.Equal(t, list.ShareToken, updated.ShareToken)
+	assert.Equal(t, list.CreatedAt, updated.CreatedAt)
+	assert.Len(t, updated.Entries, 2)
+
+	reset, err := s.ResetShareToken(ctx, "neo", list.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, list.ShareToken, reset.ShareToken)
+	_, err = s.GetList(ctx, "trinity", list.ID, list.ShareToken)
+	assert.ErrorIs(t, err, errs.ErrUserListNotFound)
+	_, err = s.GetList(ctx, "trinity", list.ID, reset.ShareToken)
+	assert.NoError(t, err)
+
+	require.NoError(t, s.DeleteList(ctx, "neo", list.ID))
+	_, err = s.GetList(ctx, "neo", list.ID, "")
+	assert.ErrorIs(t, err, errs.ErrUserListNotFound)
+}
+
+func TestCollectionService_BrowseLists(t *testing.T) {
+	ctx := context.Background()
+	s, userRepo := newUserListService(t)
+
+	var ids []int
+	for i, owner := range []string{"neo", "trinity", "neo", "neo", "trinity"} {
+		list, err := s.CreateList(ctx, owner, models.UserList{Title: "Список", Visibility: models.VisibilityPublic})
+		require.NoError(t, err)
+		ids = append(ids, list.ID)
+		if i == 2 {
+			_, err = s.CreateList(ctx, owner, models.UserList{Title: "Скрытый", Visibility: models.VisibilityPrivate})
+			require.NoError(t, err)
+		}
+	}
+
+	// lists created within the same millisecond are ordered by id
+	var got []int
+	req := models.UserListRequest{Limit: 2}
+	for {
+		page, err := s.BrowseLists(ctx, req)
+		require.NoError(t, err)
+		for _, list := range page.Lists {
+			assert.Empty(t, list.ShareToken)
+		}
+		got = append(got, listIDs(page.Lists)...)
+		if page.NextCursor == "" {
+			break
+		}
+		req.Cursor = page.NextCursor
+	}
+	assert.Equal(t, []int{ids[4], ids[3], ids[2], ids[1], ids[0]}, got)
+
+	page, err := s.BrowseLists(ctx, models.UserListRequest{Owner: "trinity", Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []int{ids[4]}, listIDs(page.Lists))
+	assert.Equal(t, "trinity", page.Lists[0].OwnerLogin)
+
+	// owner changes login, lists and cursors stay with them
+	trinity, err := userRepo.GetUser(ctx, "trinity")
+	require.NoError(t, err)
+	require.NoError(t, userRepo.DeleteUser(ctx, "trinity"))
+	require.NoError(t, userRepo.CreateUser(ctx, &models.User{ID: trinity.ID, Username: "morpheus"}))
+
+	page, err = s.BrowseLists(ctx, models.UserListRequest{Owner: "morpheus", Limit: 1, Cursor: page.NextCursor})
+	require.NoError(t, err)
+	assert.Equal(t, []int{ids[1]}, listIDs(page.Lists))
+	assert.Equal(t, "morpheus", page.Lists[0].OwnerLogin)
+
+	page, err = s.BrowseLists(ctx, models.UserListRequest{Owner: "trinity", Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, page.Lists)
+
+	details, err := s.GetList(ctx, "", ids[1], "")
+	require.NoError(t, err)
+	assert.Equal(t, "morpheus", details.List.OwnerLogin)
+
+	own, err := s.GetUserLists(ctx, "neo")
+	require.NoError(t, err)
+	assert.Len(t, own, 4)
+
+	_, err = s.BrowseLists(ctx, models.UserListRequest{Limit: 10, Cursor: "bad"})
+	assert.ErrorIs(t, err, errs.ErrInvalidCursor)
+	_, err = s.BrowseLists(ctx, models.UserListRequest{})
+	assert.ErrorIs(t, err, errs.ErrInvalidListRequest)
+}
@@ -0,0 +1,237 @@
+package delivery
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/ds"
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/messages"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/diary/delivery/dto"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/middleware"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/validation/diary"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/validation/review"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/jsonutil"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// localeParam query parameter requesting localized display strings
+const localeParam = "locale"
+
+type DiaryServiceInterface interface {
+	CreateEntry(ctx context.Context, username string, entry models.DiaryEntry) (*models.DiaryEntry, error)
+	UpdateEntry(ctx context.Context, username string, entry models.DiaryEntry) (*models.DiaryEntry, error)
+	DeleteEntry(ctx context.Context, username string, entryID int) error
+	GetDiary(ctx context.Context, username string, filter models.DiaryFilter) ([]models.DiaryItem, error)
+}
+
+type DiaryHandler struct {
+	diaryService DiaryServiceInterface
+}
+
+func NewDiaryHandler(diaryService DiaryServiceInterface) *DiaryHandler {
+	return &DiaryHandler{
+		diaryService: diaryService,
+	}
+}
+
+// getEntryID parses entry id from path, error response is sent if it is invalid
+func getEntryID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	logger := log.Ctx(r.Context())
+
+	entryID, err := strconv.Atoi(mux.Vars(r)["entry_id"])
+	if err != nil {
+		errMsg := errors.Wrap(err, "diary action: bad entry_id")
+		logger.Error().Err(errMsg).Msg(errMsg.Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, errs.ErrBadPayload)
+		return 0, false
+	}
+
+	return entryID, true
+}
+
+// parseEntry validates request and converts it to entry
+func parseEntry(req dto.DiaryEntryRequest) (models.DiaryEntry, error) {
+	entry := models.DiaryEntry{
+		MovieID: req.MovieID,
+		Score:   req.Score,
+		Note:    strings.TrimSpace(req.Note),
+	}
+
+	var err error
+	if entry.WatchedAt, err = time.Parse(l10n.DateLayout, req.WatchedAt); err != nil {
+		return entry, errors.Wrap(err, "watched_at")
+	}
+	if err = diary.IsValidWatchDate(entry.WatchedAt, time.Now().UTC()); err != nil {
+		return entry, err
+	}
+	if entry.Score != nil {
+		if err = review.IsValidScore(*entry.Score); err != nil {
+			return entry, err
+		}
+	}
+	if err = diary.IsValidNote(entry.Note); err != nil {
+		return entry, err
+	}
+
+	return entry, nil
+}
+
+// readEntry reads and validates entry from request body, error response is sent if it is invalid
+func readEntry(w http.ResponseWriter, r *http.Request) (models.DiaryEntry, bool) {
+	logger := log.Ctx(r.Context())
+
+	var req dto.DiaryEntryRequest
+	if err := jsonutil.ReadJSON(r, &req); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrParseJSON)).Msg(errors.Wrap(err, errs.ErrParseJSON).Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errors.Wrap(err, errs.ErrParseJSONShort).Error(), errs.ErrBadPayload)
+		return models.DiaryEntry{}, false
+	}
+
+	entry, err := parseEntry(req)
+	if err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrInvalidDiaryEntry)).Msg(errors.Wrap(err, errs.ErrInvalidDiaryEntry).Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errors.Wrap(err, errs.ErrInvalidDiaryEntryShort).Error(),
+			errors.Wrap(err, errs.ErrInvalidDiaryEntry).Error())
+		return entry, false
+	}
+
+	return entry, true
+}
+
+func sendDiaryError(ctx context.Context, w http.ResponseWriter, err error) {
+	if errors.Is(err, errs.ErrMovieNotFound) || errors.Is(err, errs.ErrDiaryEntryNotFound) {
+		jsonutil.SendError(ctx, w, http.StatusNotFound, errs.ErrNotFoundShort, err.Error())
+		return
+	}
+	jsonutil.SendError(ctx, w, http.StatusInternalServerError, errs.ErrSomethingWentWrong, errs.ErrSomethingWentWrong)
+}
+
+// GetDiary returns diary of user, optionally for year=2024 or year=2024&month=5
+func (h *DiaryHandler) GetDiary(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	username, ok := middleware.RequireUsername(w, r)
+	if !ok {
+		return
+	}
+
+	var year, month int
+	var err error
+	if val := r.URL.Query().Get("year"); val != "" {
+		year, err = strconv.Atoi(val)
+	}
+	if val := r.URL.Query().Get("month"); val != "" && err == nil {
+		month, err = strconv.Atoi(val)
+	}
+	if err == nil {
+		err = diary.IsValidPeriod(year, month)
+	}
+	if err != nil {
+		errMsg := errors.Wrap(err, "getDiary action: bad request")
+		logger.Error().Err(errMsg).Msg(errMsg.Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, err.Error())
+		return
+	}
+
+	items, err := h.diaryService.GetDiary(r.Context(), username, models.DiaryFilter{Year: year, Month: time.Month(month)})
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendDiaryError(r.Context(), w, err)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, dto.NewDiaryJSON(items, l10n.ParseLocale(r.URL.Query().Get(localeParam)))); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
+
+func (h *DiaryHandler) CreateEntry(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	username, ok := middleware.RequireUsername(w, r)
+	if !ok {
+		return
+	}
+	entry, ok := readEntry(w, r)
+	if !ok {
+		return
+	}
+
+	logger.Info().Msgf("logging watch of movie %d by %s", entry.MovieID, username)
+	res, err := h.diaryService.CreateEntry(r.Context(), username, entry)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendDiaryError(r.Context(), w, err)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, dto.NewDiaryEntryJSON(*res, l10n.ParseLocale(r.URL.Query().Get(localeParam)))); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
+
+func (h *DiaryHandler) UpdateEntry(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	username, ok := middleware.RequireUsername(w, r)
+	if !ok {
+		return
+	}
+	entryID, ok := getEntryID(w, r)
+	if !ok {
+		return
+	}
+	entry, ok := readEntry(w, r)
+	if !ok {
+		return
+	}
+	entry.ID = entryID
+
+	logger.Info().Msgf("updating diary entry %d of %s", entryID, username)
+	res, err := h.diaryService.UpdateEntry(r.Context(), username, entry)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendDiaryError(r.Context(), w, err)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, dto.NewDiaryEntryJSON(*res, l10n.ParseLocale(r.URL.Query().Get(localeParam)))); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
+
+func (h *DiaryHandler) DeleteEntry(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	username, ok := middleware.RequireUsername(w, r)
+	if !ok {
+		return
+	}
+	entryID, ok := getEntryID(w, r)
+	if !ok {
+		return
+	}
+
+	logger.Info().Msgf("deleting diary entry %d of %s", entryID, username)
+	if err := h.diaryService.DeleteEntry(r.Context(), username, entryID); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendDiaryError(r.Context(), w, err)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, ds.Response{Message: messages.SuccessfulDiaryEntryDelete}); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	movieDTO "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/delivery/dto"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
+)
+
+// DiaryEntryRequest watch to log or edit, movie can not be changed by edit
+type DiaryEntryRequest struct {
+	MovieID int `json:"movie_id"`
+	// WatchedAt ISO-8601 date
+	WatchedAt string `json:"watched_at"`
+	Score     *int   `json:"score,omitempty"`
+	Note      string `json:"note,omitempty"`
+}
+
+type DiaryEntryJSON struct {
+	ID      int                      `json:"id"`
+	MovieID int                      `json:"movie_id"`
+	Movie   *movieDTO.MovieShortJSON `json:"movie,omitempty"`
+	// WatchedAt ISO-8601 date
+	WatchedAt        string    `json:"watched_at"`
+	WatchedAtDisplay string    `json:"watched_at_display,omitempty"`
+	Score            *int      `json:"score,omitempty"`
+	Note             string    `json:"note,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+func NewDiaryEntryJSON(entry models.DiaryEntry, locale l10n.Locale) DiaryEntryJSON {
+	return DiaryEntryJSON{
+		ID:               entry.ID,
+		MovieID:          entry.MovieID,
+		WatchedAt:        entry.WatchedAt.Format(l10n.DateLayout),
+		WatchedAtDisplay: l10n.FormatDate(entry.WatchedAt, locale),
+		Score:            entry.Score,
+		Note:             entry.Note,
+		CreatedAt:        entry.CreatedAt,
+	}
+}
+
+// DiaryJSON diary entries, the latest watched first
+type DiaryJSON struct {
+	Entries []DiaryEntryJSON `json:"entries"`
+}
+
+func NewDiaryJSON(items []models.DiaryItem, locale l10n.Locale) DiaryJSON {
+	res := DiaryJSON{Entries: make([]DiaryEntryJSON, 0, len(items))}
+	for _, item := range items {
+		entry := NewDiaryEntryJSON(item.Entry, locale)
+		movie := movieDTO.NewMovieShortJSON(item.Movie, locale)
+		entry.Movie = &movie
+		res.Entries = append(res.Entries, entry)
+	}
+	return res
+}
@@ -0,0 +1,10 @@
+package delivery
+
+import "net/http"
+
+type DiaryHandlerInterface interface {
+	GetDiary(w http.ResponseWriter, r *http.Request)
+	CreateEntry(w http.ResponseWriter, r *http.Request)
+	UpdateEntry(w http.ResponseWriter, r *http.Request)
+	DeleteEntry(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+)
+
+// DiaryRepository keeps diary entries by user id and entry id
+type DiaryRepository struct {
+	mu     sync.RWMutex
+	db     map[int]map[int]models.DiaryEntry
+	nextID int
+}
+
+func NewDiaryRepository() *DiaryRepository {
+	return &DiaryRepository{
+		db:     make(map[int]map[int]models.DiaryEntry),
+		nextID: 1,
+	}
+}
+
+// CreateEntry saves entry with new id
+func (r *DiaryRepository) CreateEntry(ctx context.Context, entry models.DiaryEntry) (*models.DiaryEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry.ID = r.nextID
+	r.nextID++
+
+	if r.db[entry.UserID] == nil {
+		r.db[entry.UserID] = make(map[int]models.DiaryEntry)
+	}
+	r.db[entry.UserID][entry.ID] = entry
+
+	return &entry, nil
+}
+
+// UpdateEntry replaces existing entry of the same user
+func (r *DiaryRepository) UpdateEntry(ctx context.Context, entry models.DiaryEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.db[entry.UserID][entry.ID]; !ok {
+		return errs.ErrDiaryEntryNotFound
+	}
+	r.db[entry.UserID][entry.ID] = entry
+
+	return nil
+}
+
+// GetEntry returns entry of user, entries of other users are not found
+func (r *DiaryRepository) GetEntry(ctx context.Context, userID int, entryID int) (*models.DiaryEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entry, ok := r.db[userID][entryID]
+	if !ok {
+		return nil, errs.ErrDiaryEntryNotFound
+	}
+
+	return &entry, nil
+}
+
+func (r *DiaryRepository) DeleteEntry(ctx context.Context, userID int, entryID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.db[userID][entryID]; !ok {
+		return errs.ErrDiaryEntryNotFound
+	}
+	delete(r.db[userID], entryID)
+	if len(r.db[userID]) == 0 {
+		delete(r.db, userID)
+	}
+
+	return nil
+}
+
+// GetEntries returns all entries of user, the latest watched first
+func (r *DiaryRepository) GetEntries(ctx context.Context, userID int) ([]models.DiaryEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	res := make([]models.DiaryEntry, 0, len(r.db[userID]))
+	for _, entry := range r.db[userID] {
+		res = append(res, entry)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if !res[i].WatchedAt.Equal(res[j].WatchedAt) {
+			return res[i].WatchedAt.After(res[j].WatchedAt)
+		}
+		return res[i].ID > res[j].ID
+	})
+
+	return res, nil
+}
+
+// Snapshot serializes diaries of all users
+func (r *DiaryRepository) Snapshot(ctx context.Context) ([]byte, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	records := make([]models.DiaryEntry, 0)
+	for _, entries := range r.db {
+		for _, entry := range entries {
+			records = append(records, entry)
+		}
+	}
+
+	return json.Marshal(records)
+}
+
+// Restore replaces all diaries with ones from snapshot
+func (r *DiaryRepository) Restore(ctx context.Context, data []byte) error {
+	var records []models.DiaryEntry
+	if err := json.Unmarshal(data, &records); err != nil {
+		return err
+	}
+
+	db := make(map[int]map[int]models.DiaryEntry)
+	nextID := 1
+	for _, entry := range records {
+		if db[entry.UserID] == nil {
+			db[entry.UserID] = make(map[int]models.DiaryEntry)
+		}
+		db[entry.UserID][entry.ID] = entry
+		nextID = max(nextID, entry.ID+1)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.db = db
+	r.nextID = nextID
+
+	return nil
+}
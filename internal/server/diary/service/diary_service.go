@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type DiaryRepositoryInterface interface {
+	CreateEntry(ctx context.Context, entry models.DiaryEntry) (*models.DiaryEntry, error)
+	UpdateEntry(ctx context.Context, entry models.DiaryEntry) error
+	GetEntry(ctx context.Context, userID int, entryID int) (*models.DiaryEntry, error)
+	DeleteEntry(ctx context.Context, userID int, entryID int) error
+	GetEntries(ctx context.Context, userID int) ([]models.DiaryEntry, error)
+}
+
+type RatingRepositoryInterface interface {
+	SetRating(ctx context.Context, rating models.Rating) error
+}
+
+type MovieRepositoryInterface interface {
+	GetMovieFromRepoByID(ctx context.Context, movieID int) (*models.Movie, error)
+}
+
+type UserRepositoryInterface interface {
+	GetUser(ctx context.Context, login string) (*models.User, error)
+}
+
+// DiaryService log of watched movies, scores from diary become user ratings
+type DiaryService struct {
+	diaryRepo  DiaryRepositoryInterface
+	ratingRepo RatingRepositoryInterface
+	movieRepo  MovieRepositoryInterface
+	userRepo   UserRepositoryInterface
+}
+
+func NewDiaryService(diaryRepo DiaryRepositoryInterface, ratingRepo RatingRepositoryInterface, movieRepo MovieRepositoryInterface,
+	userRepo UserRepositoryInterface) *DiaryService {
+	return &DiaryService{
+		diaryRepo:  diaryRepo,
+		ratingRepo: ratingRepo,
+		movieRepo:  movieRepo,
+		userRepo:   userRepo,
+	}
+}
+
+// syncRating sets user rating of movie to score of its latest watch which has score
+func (s *DiaryService) syncRating(ctx context.Context, userID int, movieID int) error {
+	entries, err := s.diaryRepo.GetEntries(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// entries go from the latest watched
+	for _, entry := range entries {
+		if entry.MovieID == movieID && entry.Score != nil {
+			return s.ratingRepo.SetRating(ctx, models.Rating{
+				UserID:    userID,
+				MovieID:   movieID,
+				Score:     *entry.Score,
+				UpdatedAt: time.Now().UTC(),
+			})
+		}
+	}
+	return nil
+}
+
+func sameScore(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// CreateEntry logs watch of movie, entry id and creation time are set by service
+func (s *DiaryService) CreateEntry(ctx context.Context, username string, entry models.DiaryEntry) (*models.DiaryEntry, error) {
+	logger := log.Ctx(ctx)
+
+	user, err := s.userRepo.GetUser(ctx, username)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	if _, err = s.movieRepo.GetMovieFromRepoByID(ctx, entry.MovieID); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	entry.UserID = user.ID
+	entry.CreatedAt = time.Now().UTC()
+	res, err := s.diaryRepo.CreateEntry(ctx, entry)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	if res.Score != nil {
+		if err = s.syncRating(ctx, user.ID, res.MovieID); err != nil {
+			logger.Error().Err(err).Msg(err.Error())
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// UpdateEntry changes watch date, score and note of entry, movie stays the same
+func (s *DiaryService) UpdateEntry(ctx context.Context, username string, entry models.DiaryEntry) (*models.DiaryEntry, error) {
+	logger := log.Ctx(ctx)
+
+	user, err := s.userRepo.GetUser(ctx, username)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	res, err := s.diaryRepo.GetEntry(ctx, user.ID, entry.ID)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	// rating given to movie elsewhere is kept when only date or note of entry change
+	scoreChanged := !sameScore(res.Score, entry.Score)
+
+	res.WatchedAt = entry.WatchedAt
+	res.Score = entry.Score
+	res.Note = entry.Note
+	if err = s.diaryRepo.UpdateEntry(ctx, *res); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	if !scoreChanged {
+		return res, nil
+	}
+	if err = s.syncRating(ctx, user.ID, res.MovieID); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// DeleteEntry removes entry from diary, rating given to movie is kept
+func (s *DiaryService) DeleteEntry(ctx context.Context, username string, entryID int) error {
+	logger := log.Ctx(ctx)
+
+	user, err := s.userRepo.GetUser(ctx, username)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return err
+	}
+
+	if err = s.diaryRepo.DeleteEntry(ctx, user.ID, entryID); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// GetDiary returns entries of user watched in requested period, the latest watched first
+func (s *DiaryService) GetDiary(ctx context.Context, username string, filter models.DiaryFilter) ([]models.DiaryItem, error) {
+	logger := log.Ctx(ctx)
+
+	user, err := s.userRepo.GetUser(ctx, username)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	entries, err := s.diaryRepo.GetEntries(ctx, user.ID)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	res := make([]models.DiaryItem, 0, len(entries))
+	for _, entry := range entries {
+		if !filter.Matches(entry) {
+			continue
+		}
+
+		movie, err := s.movieRepo.GetMovieFromRepoByID(ctx, entry.MovieID)
+		if errors.Is(err, errs.ErrMovieNotFound) {
+			// movie was deleted from catalog after it was watched
+			continue
+		}
+		if err != nil {
+			logger.Error().Err(err).Msg(err.Error())
+			return nil, err
+		}
+		res = append(res, models.DiaryItem{Entry: entry, Movie: *movie})
+	}
+
+	return res, nil
+}
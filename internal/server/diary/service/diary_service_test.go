@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	repoDiary "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/diary/repository"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	repoMovie "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/repository"
+	repoRating "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/rating/repository"
+	repoUser "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/user/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func score(val int) *int {
+	return &val
+}
+
+func entryIDs(items []models.DiaryItem) []int {
+	ids := make([]int, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.Entry.ID)
+	}
+	return ids
+}
+
+func TestDiaryService(t *testing.T) {
+	ctx := context.Background()
+
+	movies := mocks.Movies{1: {ID: 1, Name: "Матрица"}, 2: {ID: 2, Name: "Чужой"}}
+	userRepo := repoUser.NewUserRepository()
+	require.NoError(t, userRepo.CreateUser(ctx, &models.User{Username: "neo"}))
+	ratingRepo := repoRating.NewRatingRepository()
+	s := NewDiaryService(repoDiary.NewDiaryRepository(), ratingRepo, repoMovie.NewMovieRepository(&movies), userRepo)
+
+	first, err := s.CreateEntry(ctx, "neo", models.DiaryEntry{MovieID: 1, WatchedAt: date(2023, 12, 31), Score: score(6)})
+	require.NoError(t, err)
+	_, err = s.CreateEntry(ctx, "neo", models.DiaryEntry{MovieID: 2, WatchedAt: date(2024, 2, 10), Note: "в кино с друзьями"})
+	require.NoError(t, err)
+	rewatch, err := s.CreateEntry(ctx, "neo", models.DiaryEntry{MovieID: 1, WatchedAt: date(2024, 2, 14), Score: score(9)})
+	require.NoError(t, err)
+
+	_, err = s.CreateEntry(ctx, "neo", models.DiaryEntry{MovieID: 3, WatchedAt: date(2024, 2, 14)})
+	assert.ErrorIs(t, err, errs.ErrMovieNotFound)
+
+	// rating follows the latest watch
+	rating, err := ratingRepo.GetRating(ctx, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 9, rating.Score)
+
+	tests := []struct {
+		name   string
+		filter models.DiaryFilter
+		want   []int
+	}{
+		{name: "all", want: []int{rewatch.ID, 2, first.ID}},
+		{name: "year", filter: models.DiaryFilter{Year: 2024}, want: []int{rewatch.ID, 2}},
+		{name: "month", filter: models.DiaryFilter{Year: 2023, Month: time.December}, want: []int{first.ID}},
+		{name: "empty month", filter: models.DiaryFilter{Year: 2024, Month: time.March}, want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := s.GetDiary(ctx, "neo", tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, entryIDs(items))
+		})
+	}
+
+	// older watch gets newer date and becomes the latest one
+	updated, err := s.UpdateEntry(ctx, "neo", models.DiaryEntry{ID: first.ID, WatchedAt: date(2024, 3, 1), Score: score(7), Note: "третий раз"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, updated.MovieID)
+	assert.Equal(t, first.CreatedAt, updated.CreatedAt)
+	rating, err = ratingRepo.GetRating(ctx, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 7, rating.Score)
+
+	// rating changed on movie page is kept when entry is edited without changing its score
+	require.NoError(t, ratingRepo.SetRating(ctx, models.Rating{UserID: 1, MovieID: 1, Score: 4, UpdatedAt: time.Now().UTC()}))
+	_, err = s.UpdateEntry(ctx, "neo", models.DiaryEntry{ID: first.ID, WatchedAt: date(2024, 3, 2), Score: score(7), Note: "третий раз, дома"})
+	require.NoError(t, err)
+	rating, err = ratingRepo.GetRating(ctx, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 4, rating.Score)
+
+	require.NoError(t, s.DeleteEntry(ctx, "neo", first.ID))
+	assert.ErrorIs(t, s.DeleteEntry(ctx, "neo", first.ID), errs.ErrDiaryEntryNotFound)
+	_, err = s.UpdateEntry(ctx, "neo", models.DiaryEntry{ID: first.ID, WatchedAt: date(2024, 3, 1)})
+	assert.ErrorIs(t, err, errs.ErrDiaryEntryNotFound)
+}
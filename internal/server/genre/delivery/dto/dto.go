@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	movieDTO "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/delivery/dto"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
+)
+
+// GenreSummaryJSON genre in genres list
+type GenreSummaryJSON struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	MovieCount int    `json:"movie_count"`
+}
+
+// GenreJSON genre page
+type GenreJSON struct {
+	ID          int                       `json:"id"`
+	Name        string                    `json:"name"`
+	Description string                    `json:"description,omitempty"`
+	MovieCount  int                       `json:"movie_count"`
+	TopMovies   []movieDTO.MovieShortJSON `json:"top_movies"`
+}
+
+func NewGenreSummaryJSON(summary models.GenreSummary, locale l10n.Locale) GenreSummaryJSON {
+	return GenreSummaryJSON{
+		ID:         summary.Genre.ID,
+		Name:       l10n.Pick(locale, summary.Genre.Name, summary.Genre.EnName),
+		MovieCount: summary.MovieCount,
+	}
+}
+
+func NewGenresJSON(summaries []models.GenreSummary, locale l10n.Locale) []GenreSummaryJSON {
+	res := make([]GenreSummaryJSON, 0, len(summaries))
+	for _, summary := range summaries {
+		res = append(res, NewGenreSummaryJSON(summary, locale))
+	}
+	return res
+}
+
+func NewGenreJSON(details models.GenreDetails, locale l10n.Locale) GenreJSON {
+	res := GenreJSON{
+		ID:          details.Genre.ID,
+		Name:        l10n.Pick(locale, details.Genre.Name, details.Genre.EnName),
+		Description: l10n.Pick(locale, details.Genre.Description, details.Genre.EnDescription),
+		MovieCount:  details.MovieCount,
+		TopMovies:   make([]movieDTO.MovieShortJSON, 0, len(details.TopMovies)),
+	}
+	for _, movie := range details.TopMovies {
+		res.TopMovies = append(res.TopMovies, movieDTO.NewMovieShortJSON(movie, locale))
+	}
+	return res
+}
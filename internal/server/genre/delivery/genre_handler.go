@@ -0,0 +1,93 @@
+package delivery
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/genre/delivery/dto"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/jsonutil"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// localeParam query parameter choosing language of genre names
+	localeParam = "locale"
+
+	defaultTopLimit = 10
+	maxTopLimit     = 100
+)
+
+type GenreServiceInterface interface {
+	GetGenres(ctx context.Context) ([]models.GenreSummary, error)
+	GetGenre(ctx context.Context, genreID int, topLimit int) (*models.GenreDetails, error)
+}
+
+type GenreHandler struct {
+	genreService GenreServiceInterface
+}
+
+func NewGenreHandler(genreService GenreServiceInterface) *GenreHandler {
+	return &GenreHandler{
+		genreService: genreService,
+	}
+}
+
+func (h *GenreHandler) GetGenres(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	genres, err := h.genreService.GetGenres(r.Context())
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		jsonutil.SendError(r.Context(), w, http.StatusInternalServerError, errs.ErrSomethingWentWrong, errs.ErrSomethingWentWrong)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, dto.NewGenresJSON(genres, l10n.ParseLocale(r.URL.Query().Get(localeParam)))); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
+
+func (h *GenreHandler) GetGenre(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	genreID, err := strconv.Atoi(mux.Vars(r)["genre_id"])
+	if err != nil {
+		errMsg := errors.Wrap(err, "getGenre action: bad request")
+		logger.Error().Err(errMsg).Msg(errMsg.Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, errs.ErrBadPayload)
+		return
+	}
+
+	limit := defaultTopLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 || limit > maxTopLimit {
+			logger.Error().Str("limit", limitStr).Msg("getGenre action: bad limit")
+			jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, errs.ErrBadPayload)
+			return
+		}
+	}
+
+	genre, err := h.genreService.GetGenre(r.Context(), genreID, limit)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		if errors.Is(err, errs.ErrGenreNotFound) {
+			jsonutil.SendError(r.Context(), w, http.StatusNotFound, errors.Wrap(err, errs.ErrNotFoundShort).Error(), err.Error())
+			return
+		}
+		jsonutil.SendError(r.Context(), w, http.StatusInternalServerError, errs.ErrSomethingWentWrong, errs.ErrSomethingWentWrong)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, dto.NewGenreJSON(*genre, l10n.ParseLocale(r.URL.Query().Get(localeParam)))); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
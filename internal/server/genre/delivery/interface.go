@@ -0,0 +1,8 @@
+package delivery
+
+import "net/http"
+
+type GenreHandlerInterface interface {
+	GetGenres(w http.ResponseWriter, r *http.Request)
+	GetGenre(w http.ResponseWriter, r *http.Request)
+}
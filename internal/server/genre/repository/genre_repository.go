@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/rs/zerolog/log"
+)
+
+type GenreRepository struct {
+	mu sync.RWMutex
+	db *mocks.Genres
+}
+
+func NewGenreRepository(genreDB *mocks.Genres) *GenreRepository {
+	return &GenreRepository{db: genreDB}
+}
+
+// GetAllGenres returns all genres ordered by id
+func (r *GenreRepository) GetAllGenres(ctx context.Context) ([]models.Genre, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	res := make([]models.Genre, 0, len(*r.db))
+	for _, genre := range *r.db {
+		res = append(res, genre)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ID < res[j].ID
+	})
+
+	return res, nil
+}
+
+func (r *GenreRepository) GetGenreByID(ctx context.Context, genreID int) (*models.Genre, error) {
+	logger := log.Ctx(ctx)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	genre, exists := (*r.db)[genreID]
+	if !exists {
+		logger.Err(errs.ErrGenreNotFound).Msg(errs.ErrGenreNotFound.Error())
+		return nil, errs.ErrGenreNotFound
+	}
+
+	return &genre, nil
+}
+
+// UpsertGenre creates genre or replaces existing one with the same id
+func (r *GenreRepository) UpsertGenre(ctx context.Context, genre models.Genre) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	(*r.db)[genre.ID] = genre
+	return nil
+}
+
+// Snapshot serializes all genres
+func (r *GenreRepository) Snapshot(ctx context.Context) ([]byte, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return json.Marshal(*r.db)
+}
+
+// Restore replaces all genres with ones from snapshot
+func (r *GenreRepository) Restore(ctx context.Context, data []byte) error {
+	var genres mocks.Genres
+	if err := json.Unmarshal(data, &genres); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id := range *r.db {
+		delete(*r.db, id)
+	}
+	for id, genre := range genres {
+		(*r.db)[id] = genre
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/rs/zerolog/log"
+)
+
+type GenreRepositoryInterface interface {
+	GetAllGenres(ctx context.Context) ([]models.Genre, error)
+	GetGenreByID(ctx context.Context, genreID int) (*models.Genre, error)
+}
+
+type MovieRepositoryInterface interface {
+	GetAllMovies(ctx context.Context) ([]models.Movie, error)
+}
+
+type GenreService struct {
+	genreRepo GenreRepositoryInterface
+	movieRepo MovieRepositoryInterface
+}
+
+func NewGenreService(genreRepo GenreRepositoryInterface, movieRepo MovieRepositoryInterface) *GenreService {
+	return &GenreService{
+		genreRepo: genreRepo,
+		movieRepo: movieRepo,
+	}
+}
+
+// GetGenres returns all genres with number of their movies
+func (s *GenreService) GetGenres(ctx context.Context) ([]models.GenreSummary, error) {
+	logger := log.Ctx(ctx)
+
+	genres, err := s.genreRepo.GetAllGenres(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	movies, err := s.movieRepo.GetAllMovies(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	counts := make(map[int]int, len(genres))
+	for _, movie := range movies {
+		for _, genre := range movie.Genres {
+			counts[genre.ID]++
+		}
+	}
+
+	res := make([]models.GenreSummary, 0, len(genres))
+	for _, genre := range genres {
+		res = append(res, models.GenreSummary{Genre: genre, MovieCount: counts[genre.ID]})
+	}
+	return res, nil
+}
+
+// GetGenre returns genre with at most topLimit its best rated movies
+func (s *GenreService) GetGenre(ctx context.Context, genreID int, topLimit int) (*models.GenreDetails, error) {
+	logger := log.Ctx(ctx)
+
+	genre, err := s.genreRepo.GetGenreByID(ctx, genreID)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	movies, err := s.movieRepo.GetAllMovies(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	var genreMovies []models.Movie
+	for _, movie := range movies {
+		if movie.HasGenre(genreID) {
+			genreMovies = append(genreMovies, movie)
+		}
+	}
+	sort.SliceStable(genreMovies, func(i, j int) bool {
+		if genreMovies[i].Rating != genreMovies[j].Rating {
+			return genreMovies[i].Rating > genreMovies[j].Rating
+		}
+		return genreMovies[i].Popularity() > genreMovies[j].Popularity()
+	})
+
+	res := &models.GenreDetails{
+		GenreSummary: models.GenreSummary{Genre: *genre, MovieCount: len(genreMovies)},
+		TopMovies:    genreMovies[:min(topLimit, len(genreMovies))],
+	}
+	return res, nil
+}
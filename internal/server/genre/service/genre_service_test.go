@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	repoGenre "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/genre/repository"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	repoMovie "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestService() *GenreService {
+	genres := mocks.Genres{
+		1: {ID: 1, Name: "драма", EnName: "drama", Description: "Серьёзные истории"},
+		2: {ID: 2, Name: "комедия", EnName: "comedy"},
+		3: {ID: 3, Name: "ужасы", EnName: "horror"},
+	}
+	drama, comedy := models.Genre{ID: 1}, models.Genre{ID: 2}
+	movies := mocks.Movies{
+		1: {ID: 1, Rating: 7.5, Genres: []models.Genre{drama}},
+		2: {ID: 2, Rating: 8.5, Genres: []models.Genre{drama, comedy}},
+		3: {ID: 3, Rating: 7.5, Genres: []models.Genre{drama}, Reviews: make([]models.Review, 2)},
+		4: {ID: 4, Rating: 6.0, Genres: []models.Genre{comedy}},
+	}
+
+	return NewGenreService(repoGenre.NewGenreRepository(&genres), repoMovie.NewMovieRepository(&movies))
+}
+
+func TestGenreService_GetGenres(t *testing.T) {
+	s := newTestService()
+
+	genres, err := s.GetGenres(context.Background())
+	require.NoError(t, err)
+	require.Len(t, genres, 3)
+
+	counts := make([]int, 0, len(genres))
+	for _, genre := range genres {
+		counts = append(counts, genre.MovieCount)
+	}
+	assert.Equal(t, []int{3, 2, 0}, counts)
+	assert.Equal(t, "drama", genres[0].Genre.EnName)
+}
+
+func TestGenreService_GetGenre(t *testing.T) {
+	s := newTestService()
+
+	genre, err := s.GetGenre(context.Background(), 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "Серьёзные истории", genre.Genre.Description)
+	assert.Equal(t, 3, genre.MovieCount)
+	require.Len(t, genre.TopMovies, 2)
+	assert.Equal(t, 2, genre.TopMovies[0].ID)
+	assert.Equal(t, 3, genre.TopMovies[1].ID, "more popular movie wins rating tie")
+
+	genre, err = s.GetGenre(context.Background(), 3, 10)
+	require.NoError(t, err)
+	assert.Empty(t, genre.TopMovies)
+
+	_, err = s.GetGenre(context.Background(), 42, 10)
+	assert.ErrorIs(t, err, errs.ErrGenreNotFound)
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/jsonutil"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type SessionGetterInterface interface {
+	GetSession(ctx context.Context, sessionID string) (string, error)
+}
+
+// NewAdminMiddleware allows request only for logged in users listed in admins
+func NewAdminMiddleware(cookieName string, sessionService SessionGetterInterface, admins []string) mux.MiddlewareFunc {
+	allowed := make(map[string]struct{}, len(admins))
+	for _, admin := range admins {
+		allowed[admin] = struct{}{}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			logger := log.Ctx(r.Context())
+
+			if r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			sessionCookie, err := r.Cookie(cookieName)
+			if err != nil {
+				logger.Warn().Msg(errors.Wrap(err, errs.ErrUnauthorized).Error())
+				jsonutil.SendError(r.Context(), w, http.StatusUnauthorized, errs.ErrUnauthorizedShort, errs.ErrUnauthorized)
+				return
+			}
+
+			username, err := sessionService.GetSession(r.Context(), sessionCookie.Value)
+			if err != nil {
+				logger.Error().Err(errors.Wrap(err, errs.ErrMsgSessionNotExists)).Msg(errs.ErrMsgFailedToGetSession)
+				jsonutil.SendError(r.Context(), w, http.StatusUnauthorized, errs.ErrMsgSessionNotExists, errs.ErrMsgFailedToGetSession)
+				return
+			}
+
+			if _, ok := allowed[username]; !ok {
+				logger.Warn().Str("username", username).Msg("admin access denied")
+				jsonutil.SendError(r.Context(), w, http.StatusForbidden, errs.ErrForbiddenShort, errs.ErrForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type sessionsStub map[string]string
+
+func (s sessionsStub) GetSession(ctx context.Context, sessionID string) (string, error) {
+	username, ok := s[sessionID]
+	if !ok {
+		return "", errs.ErrSessionNotExists
+	}
+	return username, nil
+}
+
+func TestNewAdminMiddleware(t *testing.T) {
+	sessions := sessionsStub{"admin_session": "admin", "user_session": "user"}
+	mw := NewAdminMiddleware("session_id", sessions, []string{"admin"})
+
+	tests := []struct {
+		name         string
+		sessionID    string
+		expectedCode int
+	}{
+		{name: "admin", sessionID: "admin_session", expectedCode: http.StatusOK},
+		{name: "not admin", sessionID: "user_session", expectedCode: http.StatusForbidden},
+		{name: "unknown session", sessionID: "other", expectedCode: http.StatusUnauthorized},
+		{name: "no cookie", expectedCode: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/backup", nil)
+			if tt.sessionID != "" {
+				req.AddCookie(&http.Cookie{Name: "session_id", Value: tt.sessionID})
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
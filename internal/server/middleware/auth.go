@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/jsonutil"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type sessionKey struct{}
+
+// session login found by session cookie of request or error if session is not found
+type session struct {
+	username string
+	err      error
+}
+
+// NewAuthMiddleware puts login of user logged in by session cookie with given name to request context.
+// Anonymous requests pass too, handlers get login by Username or RequireUsername
+func NewAuthMiddleware(cookieName string, sessionService SessionGetterInterface) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sessionCookie, err := r.Cookie(cookieName)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			username, err := sessionService.GetSession(r.Context(), sessionCookie.Value)
+			ctx := context.WithValue(r.Context(), sessionKey{}, session{username: username, err: err})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// Username returns login of logged in user, empty for anonymous user
+func Username(ctx context.Context) string {
+	s, _ := ctx.Value(sessionKey{}).(session)
+	if s.err != nil {
+		return ""
+	}
+	return s.username
+}
+
+// RequireUsername returns login of logged in user, error response is sent if there is none
+func RequireUsername(w http.ResponseWriter, r *http.Request) (string, bool) {
+	logger := log.Ctx(r.Context())
+
+	s, ok := r.Context().Value(sessionKey{}).(session)
+	if !ok {
+		logger.Warn().Msg(errors.Wrap(http.ErrNoCookie, errs.ErrUnauthorized).Error())
+		jsonutil.SendError(r.Context(), w, http.StatusUnauthorized, errs.ErrUnauthorizedShort, errs.ErrUnauthorized)
+		return "", false
+	}
+
+	if s.err != nil {
+		logger.Error().Err(errors.Wrap(s.err, errs.ErrMsgSessionNotExists)).Msg(errs.ErrMsgFailedToGetSession)
+		jsonutil.SendError(r.Context(), w, http.StatusUnauthorized, errs.ErrMsgSessionNotExists, errs.ErrMsgFailedToGetSession)
+		return "", false
+	}
+
+	return s.username, true
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAuthMiddleware(t *testing.T) {
+	sessions := sessionsStub{"user_session": "user"}
+	mw := NewAuthMiddleware("kinolk_session", sessions)
+
+	tests := []struct {
+		name             string
+		cookieName       string
+		sessionID        string
+		expectedUsername string
+		expectedCode     int
+	}{
+		{name: "logged in", cookieName: "kinolk_session", sessionID: "user_session", expectedUsername: "user", expectedCode: http.StatusOK},
+		{name: "unknown session", cookieName: "kinolk_session", sessionID: "other", expectedCode: http.StatusUnauthorized},
+		{name: "other cookie name", cookieName: "session_id", sessionID: "user_session", expectedCode: http.StatusUnauthorized},
+		{name: "no cookie", expectedCode: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var viewer string
+			handler := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				viewer = Username(r.Context())
+				if _, ok := RequireUsername(w, r); !ok {
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/watchlist", nil)
+			if tt.cookieName != "" {
+				req.AddCookie(&http.Cookie{Name: tt.cookieName, Value: tt.sessionID})
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.Equal(t, tt.expectedUsername, viewer)
+		})
+	}
+}
@@ -1,42 +1,49 @@
 package mocks
 
-type Film struct {
-	ID         int    `json:"id"`
-	Title      string `json:"title"`
-	PreviewURL string `json:"preview_url"`
-}
-
-type Collection map[int]Film
+import "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
 
-type Collections map[string]Collection
+// Collections collections by id
+type Collections map[int]models.Collection
 
-var BestOfAllTime = Collection{
-	0: {ID: 0, Title: "Бойцовский клуб", PreviewURL: "/img/0.webp"},
-	1: {ID: 1, Title: "Тёмный рыцарь", PreviewURL: "/img/1.webp"},
-	2: {ID: 2, Title: "Форрест Гамп", PreviewURL: "/img/2.webp"},
-	3: {ID: 3, Title: "Крестный отец", PreviewURL: "/img/3.webp"},
-	4: {ID: 4, Title: "Интерстеллар", PreviewURL: "/img/4.webp"},
-	5: {ID: 5, Title: "Криминальное чтиво ", PreviewURL: "/img/5.webp"},
-	6: {ID: 6, Title: "Побег из Шоушенка", PreviewURL: "/img/6.webp"},
-	7: {ID: 7, Title: "Матрица", PreviewURL: "/img/7.webp"},
-	8: {ID: 8, Title: "Зелёная миля", PreviewURL: "/img/8.webp"},
-	9: {ID: 9, Title: "Одержимость", PreviewURL: "/img/9.webp"},
+var BestOfAllTime = models.Collection{
+	ID:        1,
+	Slug:      "luchshie-za-vse-vremya",
+	Name:      "Лучшие за всё время",
+	Published: true,
+	Entries: []models.CollectionEntry{
+		{Position: 0, MovieID: 0},
+		{Position: 1, MovieID: 1},
+		{Position: 2, MovieID: 2},
+		{Position: 3, MovieID: 3},
+		{Position: 4, MovieID: 4},
+		{Position: 5, MovieID: 5},
+		{Position: 6, MovieID: 6},
+		{Position: 7, MovieID: 7},
+		{Position: 8, MovieID: 8},
+		{Position: 9, MovieID: 9},
+	},
 }
 
-var OskarNominees = Collection{
-	0: {ID: 10, Title: "Ford против Ferrari", PreviewURL: "/img/10.webp"},
-	1: {ID: 11, Title: "Оппенгеймер", PreviewURL: "/img/11.webp"},
-	2: {ID: 12, Title: "Звёздные войны: Эпизод 4 – Новая надежда", PreviewURL: "/img/12.webp"},
-	3: {ID: 13, Title: "Рокки", PreviewURL: "/img/13.webp"},
-	4: {ID: 14, Title: "Джокер", PreviewURL: "/img/14.webp"},
-	5: {ID: 15, Title: "Игра в имитацию ", PreviewURL: "/img/15.webp"},
-	6: {ID: 16, Title: "Начало", PreviewURL: "/img/16.webp"},
-	7: {ID: 17, Title: "Назад в будущее", PreviewURL: "/img/17.webp"},
-	8: {ID: 18, Title: "Гладиатор", PreviewURL: "/img/18.webp"},
-	9: {ID: 19, Title: "Титаник", PreviewURL: "/img/19.webp"},
+var OskarNominees = models.Collection{
+	ID:        2,
+	Slug:      "nominanty-na-oskar",
+	Name:      "Номинанты на оскар",
+	Published: true,
+	Entries: []models.CollectionEntry{
+		{Position: 0, MovieID: 10},
+		{Position: 1, MovieID: 11},
+		{Position: 2, MovieID: 12},
+		{Position: 3, MovieID: 13},
+		{Position: 4, MovieID: 14},
+		{Position: 5, MovieID: 15},
+		{Position: 6, MovieID: 16},
+		{Position: 7, MovieID: 17},
+		{Position: 8, MovieID: 18},
+		{Position: 9, MovieID: 19},
+	},
 }
 
 var MainPageCollections = Collections{
-	"Лучшие за всё время": BestOfAllTime,
-	"Номинанты на оскар":  OskarNominees,
+	BestOfAllTime.ID: BestOfAllTime,
+	OskarNominees.ID: OskarNominees,
 }
@@ -0,0 +1,12 @@
+package mocks
+
+import "time"
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func dayPtr(year int, month time.Month, d int) *time.Time {
+	t := day(year, month, d)
+	return &t
+}
@@ -0,0 +1,56 @@
+package mocks
+
+import "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+
+type Genres map[int]models.Genre
+
+var ExistingGenres = Genres{
+	1: {ID: 1, Name: "драма", EnName: "drama",
+		Description:   "Истории о серьёзных жизненных конфликтах и внутреннем мире героев",
+		EnDescription: "Stories of serious life conflicts and the inner world of characters"},
+	2: {ID: 2, Name: "комедия", EnName: "comedy",
+		Description:   "Фильмы, цель которых — рассмешить зрителя",
+		EnDescription: "Films made to make the audience laugh"},
+	3: {ID: 3, Name: "триллер", EnName: "thriller",
+		Description:   "Напряжённые истории, держащие зрителя в ожидании развязки",
+		EnDescription: "Tense stories keeping the audience in suspense until the end"},
+	4: {ID: 4, Name: "боевик", EnName: "action",
+		Description:   "Динамичные фильмы с погонями, драками и перестрелками",
+		EnDescription: "Dynamic films with chases, fights and shootouts"},
+	5: {ID: 5, Name: "фантастика", EnName: "sci-fi",
+		Description:   "Истории о будущем, технологиях и других мирах",
+		EnDescription: "Stories about the future, technology and other worlds"},
+	6: {ID: 6, Name: "ужасы", EnName: "horror",
+		Description:   "Фильмы, созданные, чтобы напугать",
+		EnDescription: "Films made to frighten"},
+	7: {ID: 7, Name: "мелодрама", EnName: "romance",
+		Description:   "Истории о любви и отношениях",
+		EnDescription: "Stories about love and relationships"},
+	8: {ID: 8, Name: "детектив", EnName: "crime",
+		Description:   "Расследования преступлений и поиск виновных",
+		EnDescription: "Crime investigations and the search for the guilty"},
+	9: {ID: 9, Name: "приключения", EnName: "adventure",
+		Description:   "Путешествия, поиски и опасные испытания",
+		EnDescription: "Journeys, quests and dangerous trials"},
+	10: {ID: 10, Name: "фэнтези", EnName: "fantasy",
+		Description:   "Волшебные миры, магия и мифические существа",
+		EnDescription: "Magical worlds, magic and mythical creatures"},
+	11: {ID: 11, Name: "мультфильм", EnName: "animation",
+		Description:   "Анимационные фильмы для всех возрастов",
+		EnDescription: "Animated films for all ages"},
+	12: {ID: 12, Name: "документальный", EnName: "documentary",
+		Description:   "Фильмы о реальных людях и событиях",
+		EnDescription: "Films about real people and events"},
+	13: {ID: 13, Name: "военный", EnName: "war",
+		Description:   "Фильмы о войне и людях на ней",
+		EnDescription: "Films about war and people at war"},
+	14: {ID: 14, Name: "история", EnName: "history",
+		Description:   "Экранизации исторических событий",
+		EnDescription: "Screen adaptations of historical events"},
+	15: {ID: 15, Name: "биография", EnName: "biography",
+		Description:   "Жизнеописания известных людей",
+		EnDescription: "Life stories of famous people"},
+	16: {ID: 16, Name: "семейный", EnName: "family",
+		Description:   "Фильмы для просмотра всей семьёй",
+		EnDescription: "Films for the whole family to watch"},
+}
@@ -1,61 +1,12 @@
 package mocks
 
-type UserJSON struct {
-	ID     int    `json:"id"`
-	Login  string `json:"login"`
-	Avatar string `json:"avatar,omitempty"`
-}
-
-type ReviewJSON struct {
-	ID         int      `json:"id"`
-	User       UserJSON `json:"user"`
-	ReviewText string   `json:"review_text"`
-	Score      int      `json:"score"`
-	CreatedAt  string   `json:"created_at"`
-}
+import (
+	"time"
 
-type GenreJSON struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
-}
-
-// объявлено в staff_person.go, берем оттуда или копию типа здесь оставляем
-// type PersonJSON struct {
-// 	ID         int    `json:"id"`
-// 	FullName   string `json:"full_name"`
-// 	EnFullName string `json:"en_full_name,omitempty"`
-// 	Photo      string `json:"photo"`
-// 	About      string `json:"about"`
-// 	Sex        string `json:"sex,omitempty"`
-// 	Growth     string `json:"growth,omitempty"`
-// 	Birthday   string `json:"birthday,omitempty"`
-// 	Death      string `json:"death,omitempty"`
-// }
-
-type MovieJSON struct {
-	ID              int          `json:"id"`
-	Name            string       `json:"name"`
-	OriginalName    string       `json:"original_name,omitempty"`
-	About           string       `json:"about,omitempty"`
-	Poster          string       `json:"poster,omitempty"`
-	ReleaseYear     int          `json:"release_year,omitempty"`
-	Country         string       `json:"country,omitempty"`
-	Slogan          string       `json:"slogan,omitempty"`
-	Director        string       `json:"director,omitempty"`
-	Budget          int64        `json:"budget,omitempty"`
-	BoxOfficeUS     int64        `json:"box_office_us,omitempty"`
-	BoxOfficeGlobal int64        `json:"box_office_global,omitempty"`
-	BoxOfficeRussia int64        `json:"box_office_russia,omitempty"`
-	PremierRussia   string       `json:"premier_russia,omitempty"`
-	PremierGlobal   string       `json:"premier_global,omitempty"`
-	Rating          float64      `json:"rating,omitempty"`
-	Duration        string       `json:"duration,omitempty"`
-	Genres          []GenreJSON  `json:"genres,omitempty"`
-	Staff           []PersonJSON `json:"staff,omitempty"`
-	Reviews         []ReviewJSON `json:"reviews,omitempty"`
-}
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+)
 
-type Movies map[int]MovieJSON
+type Movies map[int]models.Movie
 
 var ExistingMovies = Movies{
 	0: {
@@ -65,62 +16,62 @@ var ExistingMovies = Movies{
 		Poster:          "/static/img/0.webp",
 		ReleaseYear:     1999,
 		Country:         "США",
-		Budget:          63000000,
-		BoxOfficeUS:     37030102,
-		BoxOfficeGlobal: 100853753,
+		Budget:          models.Money{Amount: 63000000, Currency: models.USD},
+		BoxOfficeUS:     models.Money{Amount: 37030102, Currency: models.USD},
+		BoxOfficeGlobal: models.Money{Amount: 100853753, Currency: models.USD},
 		Rating:          8.8,
-		Duration:        "2ч 19м",
-		Genres: []GenreJSON{
-			{ID: 1, Name: "триллер"},
-			{ID: 2, Name: "драма"},
+		Duration:        139,
+		Genres: []models.Genre{
+			{ID: 3, Name: "триллер", EnName: "thriller"},
+			{ID: 1, Name: "драма", EnName: "drama"},
 		},
-		Staff: []PersonJSON{
-			{ID: 1, FullName: "Брэд Питт", Photo: "/static/img/brad_pitt.webp"},
-			{ID: 2, FullName: "Эдвард Нортон"},
-			{ID: 3, FullName: "Хелена Бонем Картер"},
-			{ID: 4, FullName: "Мит Лоуф"},
-			{ID: 5, FullName: "Джаред Лето"},
-			{ID: 6, FullName: "Зэк Гренье"},
-			{ID: 7, FullName: "Холт Маккэллани"},
-			{ID: 8, FullName: "Эйон Бэйли"},
-			{ID: 9, FullName: "Ричмонд Аркетт"},
-			{ID: 10, FullName: "Дэвид Эндрюс"},
+		Staff: []models.StaffMember{
+			{Person: models.Person{ID: 1, FullName: "Брэд Питт", Photo: "/static/img/brad_pitt.webp"}, Role: models.RoleActor, Character: "Тайлер Дёрден"},
+			{Person: models.Person{ID: 2, FullName: "Эдвард Нортон"}, Role: models.RoleActor, Character: "Рассказчик"},
+			{Person: models.Person{ID: 3, FullName: "Хелена Бонем Картер"}, Role: models.RoleActor, Character: "Марла Сингер"},
+			{Person: models.Person{ID: 4, FullName: "Мит Лоуф"}, Role: models.RoleActor, Character: "Роберт Полсон"},
+			{Person: models.Person{ID: 5, FullName: "Джаред Лето"}, Role: models.RoleActor, Character: "Ангельское личико"},
+			{Person: models.Person{ID: 6, FullName: "Зэк Гренье"}, Role: models.RoleActor, Character: "Ричард Чесслер"},
+			{Person: models.Person{ID: 7, FullName: "Холт Маккэллани"}, Role: models.RoleActor, Character: "Механик"},
+			{Person: models.Person{ID: 8, FullName: "Эйон Бэйли"}, Role: models.RoleActor, Character: "Рикки"},
+			{Person: models.Person{ID: 9, FullName: "Ричмонд Аркетт"}, Role: models.RoleActor, Character: "Интерн"},
+			{Person: models.Person{ID: 10, FullName: "Дэвид Эндрюс"}, Role: models.RoleActor, Character: "Томас"},
 		},
-		Reviews: []ReviewJSON{
+		Reviews: []models.Review{
 			{
-				ID:         1,
-				User:       UserJSON{ID: 101, Login: "KinoKritik77"},
-				ReviewText: "Абсолютный шедевр! Фильм, который заставляет задуматься о современном обществе, консьюмеризме и поиске себя. Потрясающая игра актеров и неожиданный финал.",
-				Score:      10,
-				CreatedAt:  "15.10.2023",
+				ID:        1,
+				User:      models.ReviewAuthor{ID: 101, Login: "KinoKritik77"},
+				Text:      "Абсолютный шедевр! Фильм, который заставляет задуматься о современном обществе, консьюмеризме и поиске себя. Потрясающая игра актеров и неожиданный финал.",
+				Score:     10,
+				CreatedAt: day(2023, time.October, 15),
 			},
 			{
-				ID:         2,
-				User:       UserJSON{ID: 102, Login: "Alice_F"},
-				ReviewText: "Сначала показался странным и жестоким, но потом поняла глубину. Финал просто взрывает мозг! Пересматривала несколько раз.",
-				Score:      9,
-				CreatedAt:  "20.01.2024",
+				ID:        2,
+				User:      models.ReviewAuthor{ID: 102, Login: "Alice_F"},
+				Text:      "Сначала показался странным и жестоким, но потом поняла глубину. Финал просто взрывает мозг! Пересматривала несколько раз.",
+				Score:     9,
+				CreatedAt: day(2024, time.January, 20),
 			},
 			{
-				ID:         3,
-				User:       UserJSON{ID: 103, Login: "Sergey_N"},
-				ReviewText: "Не мое. Слишком много неоправданного насилия и псевдофилософии. Пытается быть глубоким, но выглядит претенциозно. Финал предсказуем, если внимательно смотреть.",
-				Score:      5,
-				CreatedAt:  "01.11.2023",
+				ID:        3,
+				User:      models.ReviewAuthor{ID: 103, Login: "Sergey_N"},
+				Text:      "Не мое. Слишком много неоправданного насилия и псевдофилософии. Пытается быть глубоким, но выглядит претенциозно. Финал предсказуем, если внимательно смотреть.",
+				Score:     5,
+				CreatedAt: day(2023, time.November, 1),
 			},
 			{
-				ID:         4,
-				User:       UserJSON{ID: 205, Login: "Tyler_Fan99"},
-				ReviewText: "Лучший фильм ЭВЕР! Нортон и Питт на высоте. Идея анархии и разрушения системы - то, что нужно! Первое правило - никому не рассказывать!",
-				Score:      10,
-				CreatedAt:  "08.03.2024",
+				ID:        4,
+				User:      models.ReviewAuthor{ID: 205, Login: "Tyler_Fan99"},
+				Text:      "Лучший фильм ЭВЕР! Нортон и Питт на высоте. Идея анархии и разрушения системы - то, что нужно! Первое правило - никому не рассказывать!",
+				Score:     10,
+				CreatedAt: day(2024, time.March, 8),
 			},
 			{
-				ID:         5,
-				User:       UserJSON{ID: 310, Login: "RegularViewer"},
-				ReviewText: "Интересный фильм с неожиданным поворотом. Хорошая сатира на общество потребления, но местами затянуто. Стоит посмотреть хотя бы раз.",
-				Score:      7,
-				CreatedAt:  "25.12.2023",
+				ID:        5,
+				User:      models.ReviewAuthor{ID: 310, Login: "RegularViewer"},
+				Text:      "Интересный фильм с неожиданным поворотом. Хорошая сатира на общество потребления, но местами затянуто. Стоит посмотреть хотя бы раз.",
+				Score:     7,
+				CreatedAt: day(2023, time.December, 25),
 			},
 		},
 	},
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// BackupInfo describes snapshot file of in-memory repositories
+type BackupInfo struct {
+	Path      string    `json:"path"`
+	CreatedAt time.Time `json:"created_at"`
+	Size      int64     `json:"size"`
+	Stores    []string  `json:"stores"`
+}
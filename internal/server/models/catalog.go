@@ -0,0 +1,206 @@
+package models
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// MovieSort field catalog is ordered by
+type MovieSort string
+
+const (
+	SortByRating     MovieSort = "rating"
+	SortByYear       MovieSort = "year"
+	SortByPopularity MovieSort = "popularity"
+	SortByTitle      MovieSort = "title"
+	// SortByTrending recent activity of users, see TrendingPeriod
+	SortByTrending MovieSort = "trending"
+)
+
+// MovieFilter catalog filter, zero values mean no restriction
+type MovieFilter struct {
+	// GenreIDs movie must have all of these genres
+	GenreIDs     []int
+	Country      string
+	YearFrom     int
+	YearTo       int
+	RatingFrom   float64
+	RatingTo     float64
+	DurationFrom int // minutes
+	DurationTo   int // minutes
+	// PersonID movie staff must include this person
+	PersonID *int
+}
+
+// MovieListRequest catalog page request
+type MovieListRequest struct {
+	Filter MovieFilter
+	Sort   MovieSort
+	// Period activity is counted in for SortByTrending, week when empty
+	Period TrendingPeriod
+	Desc   bool
+	Limit  int
+	// Cursor opaque position returned with previous page
+	Cursor string
+}
+
+// MoviePage catalog page with total number of movies matching filter
+type MoviePage struct {
+	Movies     []Movie
+	Total      int
+	NextCursor string
+}
+
+// Popularity how much attention movie gets from users
+func (m Movie) Popularity() int {
+	return len(m.Reviews)
+}
+
+// HasGenre reports whether movie belongs to genre
+func (m Movie) HasGenre(genreID int) bool {
+	for _, genre := range m.Genres {
+		if genre.ID == genreID {
+			return true
+		}
+	}
+	return false
+}
+
+// HasCountry checks movie countries, they are stored comma separated, e.g. "США, Германия"
+func (m Movie) HasCountry(country string) bool {
+	for _, c := range strings.Split(m.Country, ",") {
+		if strings.EqualFold(strings.TrimSpace(c), strings.TrimSpace(country)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches reports whether movie satisfies every restriction of filter
+func (f MovieFilter) Matches(movie Movie) bool {
+	if f.YearFrom > 0 && movie.ReleaseYear < f.YearFrom ||
+		f.YearTo > 0 && movie.ReleaseYear > f.YearTo {
+		return false
+	}
+	if f.RatingFrom > 0 && movie.Rating < f.RatingFrom ||
+		f.RatingTo > 0 && movie.Rating > f.RatingTo {
+		return false
+	}
+	if f.DurationFrom > 0 && movie.Duration < f.DurationFrom ||
+		f.DurationTo > 0 && movie.Duration > f.DurationTo {
+		return false
+	}
+	if f.Country != "" && !movie.HasCountry(f.Country) {
+		return false
+	}
+
+	for _, genreID := range f.GenreIDs {
+		if !movie.HasGenre(genreID) {
+			return false
+		}
+	}
+
+	if f.PersonID != nil {
+		return movie.HasPerson(*f.PersonID)
+	}
+
+	return true
+}
+
+// ParseMovieFilter reads filter from catalog query like "genre=8&year_to=1999&rating_from=8"
+func ParseMovieFilter(query url.Values) (MovieFilter, error) {
+	var filter MovieFilter
+	var err error
+
+	intParams := []struct {
+		name string
+		dst  *int
+	}{
+		{"year_from", &filter.YearFrom},
+		{"year_to", &filter.YearTo},
+		{"duration_from", &filter.DurationFrom},
+		{"duration_to", &filter.DurationTo},
+	}
+	for _, param := range intParams {
+		if val := query.Get(param.name); val != "" {
+			if *param.dst, err = strconv.Atoi(val); err != nil {
+				return filter, errors.Wrapf(err, "parameter %s", param.name)
+			}
+		}
+	}
+
+	floatParams := []struct {
+		name string
+		dst  *float64
+	}{
+		{"rating_from", &filter.RatingFrom},
+		{"rating_to", &filter.RatingTo},
+	}
+	for _, param := range floatParams {
+		if val := query.Get(param.name); val != "" {
+			if *param.dst, err = strconv.ParseFloat(val, 64); err != nil {
+				return filter, errors.Wrapf(err, "parameter %s", param.name)
+			}
+		}
+	}
+
+	// genres may be passed both as genre=1&genre=2 and genre=1,2
+	for _, val := range query["genre"] {
+		for _, part := range strings.Split(val, ",") {
+			genreID, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				return filter, errors.Wrap(err, "parameter genre")
+			}
+			filter.GenreIDs = append(filter.GenreIDs, genreID)
+		}
+	}
+
+	if val := query.Get("person"); val != "" {
+		personID, err := strconv.Atoi(val)
+		if err != nil {
+			return filter, errors.Wrap(err, "parameter person")
+		}
+		filter.PersonID = &personID
+	}
+
+	filter.Country = strings.TrimSpace(query.Get("country"))
+
+	return filter, nil
+}
+
+// Encode writes filter in catalog query form accepted by ParseMovieFilter
+func (f MovieFilter) Encode() string {
+	query := url.Values{}
+	for _, param := range []struct {
+		name string
+		val  int
+	}{
+		{"year_from", f.YearFrom},
+		{"year_to", f.YearTo},
+		{"duration_from", f.DurationFrom},
+		{"duration_to", f.DurationTo},
+	} {
+		if param.val > 0 {
+			query.Set(param.name, strconv.Itoa(param.val))
+		}
+	}
+	if f.RatingFrom > 0 {
+		query.Set("rating_from", strconv.FormatFloat(f.RatingFrom, 'f', -1, 64))
+	}
+	if f.RatingTo > 0 {
+		query.Set("rating_to", strconv.FormatFloat(f.RatingTo, 'f', -1, 64))
+	}
+	for _, genreID := range f.GenreIDs {
+		query.Add("genre", strconv.Itoa(genreID))
+	}
+	if f.PersonID != nil {
+		query.Set("person", strconv.Itoa(*f.PersonID))
+	}
+	if f.Country != "" {
+		query.Set("country", f.Country)
+	}
+	return query.Encode()
+}
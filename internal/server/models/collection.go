@@ -0,0 +1,79 @@
+package models
+
+import "time"
+
+// CollectionEntry movie placed in collection at explicit position
+type CollectionEntry struct {
+	Position int `json:"position"`
+	MovieID  int `json:"movie_id"`
+	// PreviousPosition position in earlier ranking, set only for ranked built-in collections.
+	// Movie new to ranking has none
+	PreviousPosition *int `json:"previous_position,omitempty"`
+	// Premiere date movie premieres on, set only for "Скоро в кино"
+	Premiere *time.Time `json:"premiere,omitempty"`
+}
+
+// BuiltinCollection kind of collection which movies are computed by service instead of stored
+type BuiltinCollection string
+
+const (
+	// BuiltinTop250 movies with the best weighted rating by users
+	BuiltinTop250 BuiltinCollection = "top250"
+	// BuiltinComingSoon movies premiering in the coming days
+	BuiltinComingSoon BuiltinCollection = "coming_soon"
+)
+
+// SmartRule catalog query which selects movies of smart collection
+type SmartRule struct {
+	Filter MovieFilter `json:"filter"`
+	Sort   MovieSort   `json:"sort"`
+	Desc   bool        `json:"desc"`
+	Limit  int         `json:"limit"`
+}
+
+// Collection list of movies, it is shown on main page once published.
+// Movies of curated collection are picked by editors, movies of smart one are selected by its rule
+// and movies of built-in one are computed by service of its kind
+type Collection struct {
+	ID          int    `json:"id"`
+	Slug        string `json:"slug"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Published   bool   `json:"published"`
+	// Entries are kept sorted by position, smart collection has none stored
+	Entries []CollectionEntry `json:"entries"`
+	Rule    *SmartRule        `json:"rule,omitempty"`
+	Builtin BuiltinCollection `json:"builtin,omitempty"`
+}
+
+// IsSmart reports whether movies of collection are selected by rule
+func (c Collection) IsSmart() bool {
+	return c.Rule != nil
+}
+
+// IsBuiltin reports whether movies of collection are computed by service
+func (c Collection) IsBuiltin() bool {
+	return c.Builtin != ""
+}
+
+// CollectionItem collection entry with its movie
+type CollectionItem struct {
+	Position         int
+	PreviousPosition *int
+	Premiere         *time.Time
+	Movie            Movie
+}
+
+// CollectionRequest page of collection movies by position
+type CollectionRequest struct {
+	Limit  int
+	Cursor string
+}
+
+// CollectionPage page of collection movies, entries of deleted movies are skipped
+type CollectionPage struct {
+	Collection Collection
+	Items      []CollectionItem
+	Total      int
+	NextCursor string
+}
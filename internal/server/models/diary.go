@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// DiaryEntry record that user watched movie on given date, movie may be watched several times
+type DiaryEntry struct {
+	ID      int `json:"id"`
+	UserID  int `json:"user_id"`
+	MovieID int `json:"movie_id"`
+	// WatchedAt date movie was watched, time part is zero
+	WatchedAt time.Time `json:"watched_at"`
+	// Score optional rating given on this watch
+	Score *int `json:"score,omitempty"`
+	// Note private note visible only to user
+	Note      string    `json:"note,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DiaryFilter zero values mean no restriction, month requires year
+type DiaryFilter struct {
+	Year  int
+	Month time.Month
+}
+
+// Matches reports whether entry was watched in requested period
+func (f DiaryFilter) Matches(entry DiaryEntry) bool {
+	if f.Year > 0 && entry.WatchedAt.Year() != f.Year {
+		return false
+	}
+	if f.Month > 0 && entry.WatchedAt.Month() != f.Month {
+		return false
+	}
+	return true
+}
+
+// DiaryItem diary entry with watched movie
+type DiaryItem struct {
+	Entry DiaryEntry
+	Movie Movie
+}
@@ -0,0 +1,47 @@
+package models
+
+// FilmographySort field movies of person are ordered by
+type FilmographySort string
+
+const (
+	FilmographySortByYear   FilmographySort = "year"
+	FilmographySortByRating FilmographySort = "rating"
+)
+
+// FilmographyRequest page of movies person is credited in
+type FilmographyRequest struct {
+	// Role lists movies of one role only, first page of every role is listed when empty
+	Role StaffRole
+	Sort FilmographySort
+	Desc bool
+	// Limit movies per role
+	Limit int
+	// Cursor opaque position returned with previous page of Role
+	Cursor string
+}
+
+// FilmographyItem movie person is credited in, character is set only for actors
+type FilmographyItem struct {
+	Movie     Movie
+	Character string
+}
+
+// FilmographyGroup page of movies where person has the same role
+type FilmographyGroup struct {
+	Role       StaffRole
+	Items      []FilmographyItem
+	Total      int
+	NextCursor string
+}
+
+// Career facts about person computed from movie credits
+type Career struct {
+	// Roles person was credited in, in StaffRoles order
+	Roles []StaffRole
+	// Genres most frequent genres of person movies, most frequent first
+	Genres     []Genre
+	TotalFilms int
+	// FirstYear and LastYear release years of the earliest and the latest movies, zero when unknown
+	FirstYear int
+	LastYear  int
+}
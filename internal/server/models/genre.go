@@ -0,0 +1,22 @@
+package models
+
+// Genre movie genre, movies embed only its id and names
+type Genre struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	EnName        string `json:"en_name,omitempty"`
+	Description   string `json:"description,omitempty"`
+	EnDescription string `json:"en_description,omitempty"`
+}
+
+// GenreSummary genre with number of its movies
+type GenreSummary struct {
+	Genre      Genre
+	MovieCount int
+}
+
+// GenreDetails genre page data
+type GenreDetails struct {
+	GenreSummary
+	TopMovies []Movie
+}
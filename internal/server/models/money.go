@@ -0,0 +1,21 @@
+package models
+
+// Currency ISO 4217 currency code
+type Currency string
+
+const (
+	USD Currency = "USD"
+	EUR Currency = "EUR"
+	RUB Currency = "RUB"
+)
+
+// Money amount in minor-less units of currency, e.g. whole dollars
+type Money struct {
+	Amount   int64    `json:"amount"`
+	Currency Currency `json:"currency"`
+}
+
+// IsZero reports whether amount is not set
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
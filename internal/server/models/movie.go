@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// Movie film with its genres, staff and reviews
+type Movie struct {
+	ID              int           `json:"id"`
+	Name            string        `json:"name"`
+	OriginalName    string        `json:"original_name,omitempty"`
+	About           string        `json:"about,omitempty"`
+	Poster          string        `json:"poster,omitempty"`
+	ReleaseYear     int           `json:"release_year,omitempty"`
+	Country         string        `json:"country,omitempty"`
+	Slogan          string        `json:"slogan,omitempty"`
+	Budget          Money         `json:"budget"`
+	BoxOfficeUS     Money         `json:"box_office_us"`
+	BoxOfficeGlobal Money         `json:"box_office_global"`
+	BoxOfficeRussia Money         `json:"box_office_russia"`
+	Premieres       []Premiere    `json:"premieres,omitempty"`
+	Rating          float64       `json:"rating,omitempty"`
+	Duration        int           `json:"duration,omitempty"` // minutes
+	Genres          []Genre       `json:"genres,omitempty"`
+	Staff           []StaffMember `json:"staff,omitempty"`
+	Reviews         []Review      `json:"reviews,omitempty"`
+	// DeletedAt is set when movie is removed from catalog, it is kept for snapshots
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// IsDeleted reports whether movie was removed from catalog
+func (m Movie) IsDeleted() bool {
+	return m.DeletedAt != nil
+}
+
+// PremiereIn returns date of premiere in country, nil if it is unknown
+func (m Movie) PremiereIn(country string) *time.Time {
+	for _, premiere := range m.Premieres {
+		if premiere.Country == country {
+			date := premiere.Date
+			return &date
+		}
+	}
+	return nil
+}
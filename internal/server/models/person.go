@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// Person staff person: actor, director, etc
+type Person struct {
+	ID         int        `json:"id"`
+	FullName   string     `json:"full_name"`
+	EnFullName string     `json:"en_full_name,omitempty"`
+	Photo      string     `json:"photo,omitempty"`
+	About      string     `json:"about,omitempty"`
+	Sex        string     `json:"sex,omitempty"`
+	Growth     int        `json:"growth,omitempty"` // centimeters
+	Birthday   *time.Time `json:"birthday,omitempty"`
+	Death      *time.Time `json:"death,omitempty"`
+}
+
+// Age returns current age of living person or age at death. False when birthday is unknown
+func (p Person) Age(now time.Time) (int, bool) {
+	if p.Birthday == nil {
+		return 0, false
+	}
+
+	moment := now
+	if p.Death != nil {
+		moment = *p.Death
+	}
+
+	years := moment.Year() - p.Birthday.Year()
+	if moment.Month() < p.Birthday.Month() || moment.Month() == p.Birthday.Month() && moment.Day() < p.Birthday.Day() {
+		years--
+	}
+	return years, true
+}
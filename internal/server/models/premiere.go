@@ -0,0 +1,84 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	// PremiereWorld country of world premiere, premieres in countries are keyed by ISO 3166-1 alpha-2 code
+	PremiereWorld  = "world"
+	PremiereRussia = "RU"
+)
+
+// Premiere first showing of movie in cinemas of country
+type Premiere struct {
+	Country string    `json:"country"`
+	Date    time.Time `json:"date"`
+}
+
+// IsPremiereCountry reports whether code is world or two upper case latin letters
+func IsPremiereCountry(code string) bool {
+	if code == PremiereWorld {
+		return true
+	}
+	if len(code) != 2 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// SortPremieres orders premieres by date, premieres of the same day by country
+func SortPremieres(premieres []Premiere) {
+	sort.Slice(premieres, func(i, j int) bool {
+		if !premieres[i].Date.Equal(premieres[j].Date) {
+			return premieres[i].Date.Before(premieres[j].Date)
+		}
+		return premieres[i].Country < premieres[j].Country
+	})
+}
+
+// PremiereSettings release calendar and "Скоро в кино" collection
+type PremiereSettings struct {
+	// DefaultDays length of calendar when end date is not requested
+	DefaultDays int
+	MaxDays     int
+	// ComingSoonDays how far ahead "Скоро в кино" looks
+	ComingSoonDays    int
+	ComingSoonCountry string
+}
+
+// PremiereRequest release calendar query, empty country means premieres in all countries
+type PremiereRequest struct {
+	From    time.Time
+	To      time.Time
+	Country string
+}
+
+// CalendarPremiere movie premiering in country on calendar day
+type CalendarPremiere struct {
+	Movie   Movie
+	Country string
+	Date    time.Time
+	// InWatchlist whether logged in user saved movie, nil for anonymous user
+	InWatchlist *bool
+}
+
+// CalendarDay premieres of one day ordered by movie name
+type CalendarDay struct {
+	Date      time.Time
+	Premieres []CalendarPremiere
+}
+
+// Calendar premieres from first to last day inclusive, days without premieres are skipped
+type Calendar struct {
+	From    time.Time
+	To      time.Time
+	Country string
+	Days    []CalendarDay
+}
@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+// RankingSettings tuning of Top-250
+type RankingSettings struct {
+	// Size number of movies in ranking
+	Size int
+	// MinVotes movies with fewer user ratings are not ranked, it is also weight of global mean in weighted rating
+	MinVotes int
+	// RecomputeInterval how often ranking is recomputed, zero computes it only on start
+	RecomputeInterval time.Duration
+	// MovementPeriod rank movement is shown against ranking this old
+	MovementPeriod time.Duration
+	// HistoryRetention rankings older than this are forgotten
+	HistoryRetention time.Duration
+}
+
+// RankedMovie movie position in ranking with its weighted rating
+type RankedMovie struct {
+	MovieID  int     `json:"movie_id"`
+	Position int     `json:"position"`
+	Score    float64 `json:"score"`
+	Votes    int     `json:"votes"`
+}
+
+// Ranking positions of movies computed at once, movies are ordered by position
+type Ranking struct {
+	ComputedAt time.Time     `json:"computed_at"`
+	Movies     []RankedMovie `json:"movies"`
+}
+
+// SamePositions reports whether rankings place the same movies at the same positions
+func (r Ranking) SamePositions(other Ranking) bool {
+	if len(r.Movies) != len(other.Movies) {
+		return false
+	}
+	for i := range r.Movies {
+		if r.Movies[i].MovieID != other.Movies[i].MovieID {
+			return false
+		}
+	}
+	return true
+}
+
+// Positions returns position of every ranked movie by movie id
+func (r Ranking) Positions() map[int]int {
+	res := make(map[int]int, len(r.Movies))
+	for _, movie := range r.Movies {
+		res[movie.MovieID] = movie.Position
+	}
+	return res
+}
+
+// WeightedRating Bayesian average of movie ratings: average is pulled to mean of all ratings
+// as if movie had minVotes more votes with the mean score, so few high votes do not top ranking
+func WeightedRating(average float64, votes int, mean float64, minVotes int) float64 {
+	v, m := float64(votes), float64(minVotes)
+	if v+m == 0 {
+		return 0
+	}
+	return v/(v+m)*average + m/(v+m)*mean
+}
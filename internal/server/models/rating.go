@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// Rating quick 1-10 score of movie by user, it does not require written review
+type Rating struct {
+	UserID    int       `json:"user_id"`
+	MovieID   int       `json:"movie_id"`
+	Score     int       `json:"score"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// RatingSummary aggregated user ratings of movie
+type RatingSummary struct {
+	Votes   int
+	Average float64
+	// Scores number of votes by score, Scores[i] counts score i+1
+	Scores [10]int
+}
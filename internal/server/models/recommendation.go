@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// SimilarityWeights how much every kind of likeness adds to similarity of two movies
+type SimilarityWeights struct {
+	Genre   float64
+	Staff   float64
+	Era     float64
+	Country float64
+	// EraSpan difference of release years in which movies still count as the same era
+	EraSpan int
+}
+
+// Total the largest possible similarity of two movies
+func (w SimilarityWeights) Total() float64 {
+	return w.Genre + w.Staff + w.Era + w.Country
+}
+
+// RecommendationSettings tuning of movie suggestions
+type RecommendationSettings struct {
+	Similar SimilarityWeights
+	// RecomputeInterval how often likeness of movies is recomputed from user ratings, zero disables recomputation
+	RecomputeInterval time.Duration
+	// ColdStartRatings users with fewer ratings get suggestions mostly by movie content
+	ColdStartRatings int
+}
+
+// RecommendationReason why movie is suggested to user
+type RecommendationReason string
+
+const (
+	// ReasonRatedHighly movie is liked by users who liked movie user rated highly or it looks like one
+	ReasonRatedHighly RecommendationReason = "rated_highly"
+	// ReasonPopular movie is rated highly by everyone, user has not rated enough to know more
+	ReasonPopular RecommendationReason = "popular"
+)
+
+// Recommendation movie suggested to user
+type Recommendation struct {
+	Movie  Movie
+	Reason RecommendationReason
+	// Because movie user rated highly, set only for ReasonRatedHighly
+	Because *Movie
+}
@@ -0,0 +1,170 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// ReviewAuthor user who wrote review
+type ReviewAuthor struct {
+	ID     int    `json:"id"`
+	Login  string `json:"login"`
+	Avatar string `json:"avatar,omitempty"`
+}
+
+// Review user review of movie
+type Review struct {
+	ID        int          `json:"id"`
+	User      ReviewAuthor `json:"user"`
+	Text      string       `json:"text"`
+	Score     int          `json:"score"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt *time.Time   `json:"updated_at,omitempty"`
+	// Reactions of other users by user id
+	Reactions map[int]ReviewReaction `json:"reactions,omitempty"`
+}
+
+// ReviewReaction whether user found review helpful
+type ReviewReaction string
+
+const (
+	ReactionLike    ReviewReaction = "like"
+	ReactionDislike ReviewReaction = "dislike"
+)
+
+// wilsonZ 95% confidence
+const wilsonZ = 1.96
+
+// ReviewSentiment review tone derived from its score
+type ReviewSentiment string
+
+const (
+	SentimentPositive ReviewSentiment = "positive"
+	SentimentNeutral  ReviewSentiment = "neutral"
+	SentimentNegative ReviewSentiment = "negative"
+)
+
+// ReviewSort field reviews are ordered by
+type ReviewSort string
+
+const (
+	ReviewSortByDate        ReviewSort = "date"
+	ReviewSortByScore       ReviewSort = "score"
+	ReviewSortByHelpfulness ReviewSort = "helpfulness"
+)
+
+// ReviewListRequest page of movie reviews request
+type ReviewListRequest struct {
+	// Sentiment empty means all reviews
+	Sentiment ReviewSentiment
+	Sort      ReviewSort
+	Desc      bool
+	Limit     int
+	// Cursor opaque position returned with previous page
+	Cursor string
+}
+
+// ReviewPage page of reviews with total number of reviews matching request
+type ReviewPage struct {
+	Reviews    []Review
+	Total      int
+	NextCursor string
+}
+
+// ReviewSummary aggregated reviews shown on movie page
+type ReviewSummary struct {
+	Count   int
+	Average float64
+	// Scores number of reviews by score, Scores[i] counts score i+1
+	Scores   [10]int
+	Positive int
+	Neutral  int
+	Negative int
+}
+
+// Sentiment 7-10 is positive, 5-6 neutral and 1-4 negative
+func (r Review) Sentiment() ReviewSentiment {
+	switch {
+	case r.Score >= 7:
+		return SentimentPositive
+	case r.Score >= 5:
+		return SentimentNeutral
+	default:
+		return SentimentNegative
+	}
+}
+
+// Likes number of users who found review helpful
+func (r Review) Likes() int {
+	return r.countReactions(ReactionLike)
+}
+
+// Dislikes number of users who found review not helpful
+func (r Review) Dislikes() int {
+	return r.countReactions(ReactionDislike)
+}
+
+func (r Review) countReactions(reaction ReviewReaction) int {
+	res := 0
+	for _, val := range r.Reactions {
+		if val == reaction {
+			res++
+		}
+	}
+	return res
+}
+
+// Helpfulness lower bound of Wilson score interval for share of likes,
+// so review with 10 likes of 10 goes before one with single like
+func (r Review) Helpfulness() float64 {
+	likes := float64(r.Likes())
+	n := likes + float64(r.Dislikes())
+	if n == 0 {
+		return 0
+	}
+
+	p := likes / n
+	z2 := wilsonZ * wilsonZ
+	return (p + z2/(2*n) - wilsonZ*math.Sqrt((p*(1-p)+z2/(4*n))/n)) / (1 + z2/n)
+}
+
+// ReviewIndexByUser returns index of review written by user or -1
+func (m *Movie) ReviewIndexByUser(userID int) int {
+	for i, review := range m.Reviews {
+		if review.User.ID == userID {
+			return i
+		}
+	}
+	return -1
+}
+
+// UpdateRating sets rating to average review score rounded to one decimal
+func (m *Movie) UpdateRating() {
+	m.Rating = m.ReviewSummary().Average
+}
+
+// ReviewSummary aggregates all reviews of movie
+func (m *Movie) ReviewSummary() ReviewSummary {
+	var res ReviewSummary
+	total := 0
+	for _, review := range m.Reviews {
+		if review.Score >= 1 && review.Score <= len(res.Scores) {
+			res.Scores[review.Score-1]++
+		}
+		switch review.Sentiment() {
+		case SentimentPositive:
+			res.Positive++
+		case SentimentNeutral:
+			res.Neutral++
+		case SentimentNegative:
+			res.Negative++
+		}
+		total += review.Score
+	}
+
+	res.Count = len(m.Reviews)
+	if res.Count > 0 {
+		res.Average = math.Round(float64(total)*10/float64(res.Count)) / 10
+	}
+	return res
+}
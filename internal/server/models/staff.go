@@ -0,0 +1,86 @@
+package models
+
+// StaffRole what person did in movie
+type StaffRole string
+
+const (
+	RoleActor    StaffRole = "actor"
+	RoleDirector StaffRole = "director"
+	RoleWriter   StaffRole = "writer"
+	RoleProducer StaffRole = "producer"
+	RoleComposer StaffRole = "composer"
+	RoleOperator StaffRole = "operator"
+)
+
+// StaffRoles all roles in order they are shown on movie page
+var StaffRoles = []StaffRole{RoleDirector, RoleActor, RoleWriter, RoleProducer, RoleOperator, RoleComposer}
+
+// IsKnown reports whether role is one of StaffRoles
+func (r StaffRole) IsKnown() bool {
+	for _, role := range StaffRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// StaffMember person credited in movie, Character is set only for actors
+type StaffMember struct {
+	Person    Person    `json:"person"`
+	Role      StaffRole `json:"role"`
+	Character string    `json:"character,omitempty"`
+}
+
+// StaffLink credit of person by id as it is given by admin or fixtures
+type StaffLink struct {
+	PersonID  int
+	Role      StaffRole
+	Character string
+}
+
+// StaffCredit identifies credit in movie: person has each role once, actor may play several characters
+type StaffCredit struct {
+	PersonID  int
+	Role      StaffRole
+	Character string
+}
+
+// NewStaffCredit returns credit of person in role, character tells apart credits of actors only
+func NewStaffCredit(personID int, role StaffRole, character string) StaffCredit {
+	credit := StaffCredit{PersonID: personID, Role: role}
+	if role == RoleActor {
+		credit.Character = character
+	}
+	return credit
+}
+
+// Credit returns credit identifying member in movie staff
+func (m StaffMember) Credit() StaffCredit {
+	return NewStaffCredit(m.Person.ID, m.Role, m.Character)
+}
+
+// Credit returns credit identifying link in movie staff
+func (l StaffLink) Credit() StaffCredit {
+	return NewStaffCredit(l.PersonID, l.Role, l.Character)
+}
+
+// Short returns person info stored in movie credits
+func (p Person) Short() Person {
+	return Person{
+		ID:         p.ID,
+		FullName:   p.FullName,
+		EnFullName: p.EnFullName,
+		Photo:      p.Photo,
+	}
+}
+
+// HasPerson reports whether person is credited in movie in any role
+func (m Movie) HasPerson(personID int) bool {
+	for _, member := range m.Staff {
+		if member.Person.ID == personID {
+			return true
+		}
+	}
+	return false
+}
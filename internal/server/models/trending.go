@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+// TrendingPeriod window of recent user activity movies are ranked by in "Популярно сейчас"
+type TrendingPeriod string
+
+const (
+	TrendingDay   TrendingPeriod = "day"
+	TrendingWeek  TrendingPeriod = "week"
+	TrendingMonth TrendingPeriod = "month"
+)
+
+// Window how far back activity is counted, false for unknown period
+func (p TrendingPeriod) Window() (time.Duration, bool) {
+	switch p {
+	case TrendingDay:
+		return 24 * time.Hour, true
+	case TrendingWeek:
+		return 7 * 24 * time.Hour, true
+	case TrendingMonth:
+		return 30 * 24 * time.Hour, true
+	default:
+		return 0, false
+	}
+}
+
+// HalfLife age at which user action counts half, so yesterday's views of weekly list weigh more than last week's
+func (p TrendingPeriod) HalfLife() time.Duration {
+	window, _ := p.Window()
+	return window / 4
+}
+
+// TrendingWeights how much every kind of user action adds to movie score
+type TrendingWeights struct {
+	View      float64
+	Rating    float64
+	Review    float64
+	Watchlist float64
+}
+
+// TrendingSettings tuning of "Популярно сейчас"
+type TrendingSettings struct {
+	Weights TrendingWeights
+	// CacheTTL scores are recomputed at most this often
+	CacheTTL time.Duration
+	// ViewBuffer views waiting to be counted, views beyond it are dropped
+	ViewBuffer int
+	// ViewFlushInterval how often counted views are saved
+	ViewFlushInterval time.Duration
+}
+
+// MovieViews number of movie page views in one hour
+type MovieViews struct {
+	MovieID int       `json:"movie_id"`
+	Hour    time.Time `json:"hour"`
+	Count   int       `json:"count"`
+}
+
+// TrendingMovie movie with its score of recent activity
+type TrendingMovie struct {
+	Movie Movie
+	Score float64
+}
+
+// TrendingScores scores of recent activity by movie id and time they were computed at, movies without activity are missing.
+// Scores decay, so they are comparable only with scores computed at the same time
+type TrendingScores struct {
+	Scores     map[int]float64
+	ComputedAt time.Time
+}
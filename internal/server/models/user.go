@@ -3,6 +3,8 @@ package models
 import "time"
 
 type User struct {
+	// ID stable user id, unlike username it is not changed by profile updates
+	ID             int       `json:"id"`
 	Username       string    `json:"username"`
 	HashedPassword string    `json:"-"`
 	Avatar         string    `json:"avatar"`
@@ -0,0 +1,77 @@
+package models
+
+import "time"
+
+// ListVisibility who can see user list
+type ListVisibility string
+
+const (
+	// VisibilityPublic list is shown to everyone and in browsing
+	VisibilityPublic ListVisibility = "public"
+	// VisibilityUnlisted list is available only by share link
+	VisibilityUnlisted ListVisibility = "unlisted"
+	// VisibilityPrivate list is available only to its owner
+	VisibilityPrivate ListVisibility = "private"
+)
+
+// UserListEntry movie in user list with owner note
+type UserListEntry struct {
+	MovieID int    `json:"movie_id"`
+	Note    string `json:"note,omitempty"`
+}
+
+// UserList named list of movies created by user, entries are kept in owner order
+type UserList struct {
+	ID          int            `json:"id"`
+	OwnerID     int            `json:"owner_id"`
+	Title       string         `json:"title"`
+	Description string         `json:"description,omitempty"`
+	Cover       string         `json:"cover,omitempty"`
+	Visibility  ListVisibility `json:"visibility"`
+	// ShareToken secret part of share link, it opens unlisted list
+	ShareToken string          `json:"share_token"`
+	Entries    []UserListEntry `json:"entries,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+	// OwnerLogin current login of owner, it is not stored with list since user may change login
+	OwnerLogin string `json:"-"`
+}
+
+// CanView reports whether user with userID, zero for anonymous, who came with token may see list
+func (l UserList) CanView(userID int, token string) bool {
+	switch {
+	case userID != 0 && userID == l.OwnerID:
+		return true
+	case l.Visibility == VisibilityPublic:
+		return true
+	case l.Visibility == VisibilityUnlisted:
+		return token != "" && token == l.ShareToken
+	default:
+		return false
+	}
+}
+
+// UserListItem list entry with its movie
+type UserListItem struct {
+	Entry UserListEntry
+	Movie Movie
+}
+
+// UserListDetails list with movies of its entries, entries of deleted movies are skipped
+type UserListDetails struct {
+	List  UserList
+	Items []UserListItem
+}
+
+// UserListPage page of lists, the recently updated first
+type UserListPage struct {
+	Lists      []UserList
+	NextCursor string
+}
+
+// UserListRequest page of public lists, optionally of one owner
+type UserListRequest struct {
+	Owner  string
+	Limit  int
+	Cursor string
+}
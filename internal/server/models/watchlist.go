@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// WatchlistEntry movie user saved to watch later
+type WatchlistEntry struct {
+	MovieID int       `json:"movie_id"`
+	AddedAt time.Time `json:"added_at"`
+}
+
+// WatchlistSort field watchlist is ordered by
+type WatchlistSort string
+
+const (
+	WatchlistSortByAdded  WatchlistSort = "added"
+	WatchlistSortByTitle  WatchlistSort = "title"
+	WatchlistSortByRating WatchlistSort = "rating"
+)
+
+// WatchlistRequest page of watchlist request
+type WatchlistRequest struct {
+	Sort  WatchlistSort
+	Desc  bool
+	Limit int
+	// Cursor opaque position returned with previous page
+	Cursor string
+}
+
+// WatchlistItem movie from watchlist with time it was added
+type WatchlistItem struct {
+	Movie   Movie
+	AddedAt time.Time
+}
+
+// WatchlistPage page of watchlist with total number of saved movies
+type WatchlistPage struct {
+	Items      []WatchlistItem
+	Total      int
+	NextCursor string
+}
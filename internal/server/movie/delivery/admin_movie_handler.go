@@ -0,0 +1,231 @@
+package delivery
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/ds"
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/messages"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/delivery/dto"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/validation/movie"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/jsonutil"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// getMovieID parses movie id from path, error response is sent if it is invalid
+func getMovieID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	logger := log.Ctx(r.Context())
+
+	movieID, err := strconv.Atoi(mux.Vars(r)["movie_id"])
+	if err != nil {
+		errMsg := errors.Wrap(err, "movie action: bad movie_id")
+		logger.Error().Err(errMsg).Msg(errMsg.Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, errs.ErrBadPayload)
+		return 0, false
+	}
+
+	return movieID, true
+}
+
+// parseDate parses ISO-8601 date, empty string means date is unknown
+func parseDate(date string) (*time.Time, error) {
+	if date == "" {
+		return nil, nil
+	}
+	res, err := time.Parse(l10n.DateLayout, date)
+	if err != nil {
+		return nil, errors.Wrapf(err, "date %q", date)
+	}
+	return &res, nil
+}
+
+// parseMovie validates request and converts it to movie without genres and staff, they are linked by service
+func parseMovie(req dto.MovieRequest) (models.Movie, error) {
+	res := models.Movie{
+		Name:            strings.TrimSpace(req.Name),
+		OriginalName:    strings.TrimSpace(req.OriginalName),
+		About:           strings.TrimSpace(req.About),
+		Poster:          strings.TrimSpace(req.Poster),
+		ReleaseYear:     req.ReleaseYear,
+		Country:         strings.TrimSpace(req.Country),
+		Slogan:          strings.TrimSpace(req.Slogan),
+		Budget:          req.Budget.ToMoney(),
+		BoxOfficeUS:     req.BoxOfficeUS.ToMoney(),
+		BoxOfficeGlobal: req.BoxOfficeGlobal.ToMoney(),
+		BoxOfficeRussia: req.BoxOfficeRussia.ToMoney(),
+		Duration:        req.Duration,
+	}
+
+	for _, premiere := range req.Premieres {
+		date, err := parseDate(premiere.Date)
+		if err != nil {
+			return res, err
+		}
+		if date == nil {
+			return res, errors.New(errs.ErrInvalidPremiereDate)
+		}
+		res.Premieres = append(res.Premieres, models.Premiere{Country: strings.TrimSpace(premiere.Country), Date: *date})
+	}
+	models.SortPremieres(res.Premieres)
+
+	var err error
+
+	if err = movie.IsValidMovie(res); err != nil {
+		return res, err
+	}
+	if err = movie.IsValidIDs(req.GenreIDs); err != nil {
+		return res, err
+	}
+	if err = movie.IsValidStaff(dto.ToStaffLinks(req.Staff)); err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// sendInvalidMovie responds to request with movie which failed validation
+func sendInvalidMovie(w http.ResponseWriter, r *http.Request, err error) {
+	logger := log.Ctx(r.Context())
+
+	logger.Error().Err(errors.Wrap(err, errs.ErrInvalidMovie)).Msg(errors.Wrap(err, errs.ErrInvalidMovie).Error())
+	jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errors.Wrap(err, errs.ErrInvalidMovieShort).Error(),
+		errors.Wrap(err, errs.ErrInvalidMovie).Error())
+}
+
+// readMovie reads and validates movie from request body, error response is sent if it is invalid
+func readMovie(w http.ResponseWriter, r *http.Request, req *dto.MovieRequest) (models.Movie, bool) {
+	logger := log.Ctx(r.Context())
+
+	if err := jsonutil.ReadJSON(r, req); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrParseJSON)).Msg(errors.Wrap(err, errs.ErrParseJSON).Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errors.Wrap(err, errs.ErrParseJSONShort).Error(), errs.ErrBadPayload)
+		return models.Movie{}, false
+	}
+
+	res, err := parseMovie(*req)
+	if err != nil {
+		sendInvalidMovie(w, r, err)
+		return res, false
+	}
+
+	return res, true
+}
+
+func sendMovieError(ctx context.Context, w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, errs.ErrMovieNotFound) || errors.Is(err, errs.ErrGenreNotFound) || errors.Is(err, errs.ErrPersonNotFound):
+		jsonutil.SendError(ctx, w, http.StatusNotFound, errs.ErrNotFoundShort, err.Error())
+	default:
+		jsonutil.SendError(ctx, w, http.StatusInternalServerError, errs.ErrSomethingWentWrong, errs.ErrSomethingWentWrong)
+	}
+}
+
+// sendMovie responds with saved movie
+func sendMovie(w http.ResponseWriter, r *http.Request, res *models.Movie) {
+	logger := log.Ctx(r.Context())
+
+	if err := jsonutil.SendJSON(r.Context(), w, dto.NewMovieJSON(*res, l10n.ParseLocale(r.URL.Query().Get(localeParam)))); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+	}
+}
+
+// CreateMovie adds movie to catalog
+func (h *MovieHandler) CreateMovie(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	var req dto.MovieRequest
+	movie, ok := readMovie(w, r, &req)
+	if !ok {
+		return
+	}
+
+	logger.Info().Msgf("creating movie %q", movie.Name)
+	res, err := h.movieService.CreateMovie(r.Context(), movie, req.GenreIDs, dto.ToStaffLinks(req.Staff))
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendMovieError(r.Context(), w, err)
+		return
+	}
+
+	sendMovie(w, r, res)
+}
+
+// saveMovie replaces movie with one read from request, req holds values of fields missing in request body
+func (h *MovieHandler) saveMovie(w http.ResponseWriter, r *http.Request, movieID int, req dto.MovieRequest) {
+	logger := log.Ctx(r.Context())
+
+	movie, ok := readMovie(w, r, &req)
+	if !ok {
+		return
+	}
+	movie.ID = movieID
+
+	logger.Info().Msgf("updating movie %d", movieID)
+	res, err := h.movieService.UpdateMovie(r.Context(), movie, req.GenreIDs, dto.ToStaffLinks(req.Staff))
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendMovieError(r.Context(), w, err)
+		return
+	}
+
+	sendMovie(w, r, res)
+}
+
+// UpdateMovie replaces all fields of movie, omitted fields are cleared
+func (h *MovieHandler) UpdateMovie(w http.ResponseWriter, r *http.Request) {
+	movieID, ok := getMovieID(w, r)
+	if !ok {
+		return
+	}
+
+	h.saveMovie(w, r, movieID, dto.MovieRequest{})
+}
+
+// PatchMovie changes only fields present in request body
+func (h *MovieHandler) PatchMovie(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	movieID, ok := getMovieID(w, r)
+	if !ok {
+		return
+	}
+
+	current, err := h.movieService.GetMovieByID(r.Context(), movieID)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendMovieError(r.Context(), w, err)
+		return
+	}
+
+	h.saveMovie(w, r, movieID, dto.NewMovieRequest(*current))
+}
+
+// DeleteMovie removes movie from catalog, it is kept in storage as deleted
+func (h *MovieHandler) DeleteMovie(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	movieID, ok := getMovieID(w, r)
+	if !ok {
+		return
+	}
+
+	logger.Info().Msgf("deleting movie %d", movieID)
+	if err := h.movieService.DeleteMovie(r.Context(), movieID); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendMovieError(r.Context(), w, err)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, ds.Response{Message: messages.SuccessfulMovieDelete}); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
@@ -0,0 +1,58 @@
+package delivery
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	repoGenre "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/genre/repository"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	repoMovie "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/repository"
+	serviceMovie "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/service"
+	repoStaff "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/staff_person/repository"
+	serviceStaff "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/staff_person/service"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMovieHandler_PatchMovieAfterMerge(t *testing.T) {
+	ctx := context.Background()
+
+	pitt := models.Person{ID: 1, FullName: "Брэд Питт"}
+	// pittDuplicate is the same person imported twice
+	pittDuplicate := models.Person{ID: 2, FullName: "Брэд Питт", EnFullName: "Brad Pitt"}
+	persons := mocks.Persons{1: pitt, 2: pittDuplicate}
+	movies := mocks.Movies{
+		1: {ID: 1, Name: "Бесславные ублюдки", ReleaseYear: 2009, Staff: []models.StaffMember{
+			{Person: pitt.Short(), Role: models.RoleActor, Character: "Альдо Рейн"},
+			{Person: pittDuplicate.Short(), Role: models.RoleActor, Character: "Энцо Горломи"},
+		}},
+	}
+	genres := mocks.Genres{}
+
+	movieRepo := repoMovie.NewMovieRepository(&movies)
+	staffRepo := repoStaff.NewStaffPersonRepository(&persons)
+	_, err := serviceStaff.NewStaffPersonService(staffRepo, movieRepo).MergePerson(ctx, 1, 2)
+	require.NoError(t, err)
+
+	h := NewMovieHandler(serviceMovie.NewMovieService(movieRepo, repoGenre.NewGenreRepository(&genres), staffRepo, nil), nil, nil, nil)
+
+	// merged actor plays two characters, movie with such staff can still be edited
+	req := httptest.NewRequest(http.MethodPatch, "/movie/1", strings.NewReader(`{"slogan": "Однажды в оккупированной Франции"}`))
+	req = mux.SetURLVars(req, map[string]string{"movie_id": "1"})
+	rec := httptest.NewRecorder()
+	h.PatchMovie(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	movie, err := movieRepo.GetMovieFromRepoByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Однажды в оккупированной Франции", movie.Slogan)
+	assert.Equal(t, []models.StaffMember{
+		{Person: pitt.Short(), Role: models.RoleActor, Character: "Альдо Рейн"},
+		{Person: pitt.Short(), Role: models.RoleActor, Character: "Энцо Горломи"},
+	}, movie.Staff)
+}
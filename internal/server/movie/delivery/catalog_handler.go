@@ -0,0 +1,93 @@
+package delivery
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/delivery/dto"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/jsonutil"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultCatalogLimit = 20
+	maxCatalogLimit     = 100
+
+	orderAsc  = "asc"
+	orderDesc = "desc"
+)
+
+// parseListRequest reads catalog filter, sorting and pagination from query string
+func parseListRequest(query url.Values) (models.MovieListRequest, error) {
+	req := models.MovieListRequest{
+		Sort:   models.MovieSort(query.Get("sort")),
+		Period: models.TrendingPeriod(query.Get("period")),
+		Limit:  defaultCatalogLimit,
+		Cursor: query.Get("cursor"),
+	}
+	if req.Sort == "" {
+		req.Sort = models.SortByRating
+	}
+
+	// numbers go first by default, titles alphabetically
+	switch query.Get("order") {
+	case "":
+		req.Desc = req.Sort != models.SortByTitle
+	case orderAsc:
+		req.Desc = false
+	case orderDesc:
+		req.Desc = true
+	default:
+		return req, errors.Errorf("unknown order %q", query.Get("order"))
+	}
+
+	if val := query.Get("limit"); val != "" {
+		var err error
+		if req.Limit, err = strconv.Atoi(val); err != nil {
+			return req, errors.Wrap(err, "parameter limit")
+		}
+	}
+	if req.Limit <= 0 || req.Limit > maxCatalogLimit {
+		return req, errors.Errorf("limit must be in 1-%d", maxCatalogLimit)
+	}
+
+	var err error
+	if req.Filter, err = models.ParseMovieFilter(query); err != nil {
+		return req, err
+	}
+
+	return req, nil
+}
+
+func (h *MovieHandler) ListMovies(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	req, err := parseListRequest(r.URL.Query())
+	if err != nil {
+		errMsg := errors.Wrap(err, "listMovies action: bad request")
+		logger.Error().Err(errMsg).Msg(errMsg.Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, err.Error())
+		return
+	}
+
+	page, err := h.movieService.ListMovies(r.Context(), req)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		if errors.Is(err, errs.ErrInvalidCursor) || errors.Is(err, errs.ErrInvalidCatalogRequest) {
+			jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, err.Error())
+			return
+		}
+		jsonutil.SendError(r.Context(), w, http.StatusInternalServerError, errs.ErrSomethingWentWrong, errs.ErrSomethingWentWrong)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, dto.NewMoviesPageJSON(*page, l10n.ParseLocale(r.URL.Query().Get(localeParam)))); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
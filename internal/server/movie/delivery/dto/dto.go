@@ -0,0 +1,349 @@
+package dto
+
+import (
+	"strings"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
+)
+
+type UserJSON struct {
+	ID     int    `json:"id"`
+	Login  string `json:"login"`
+	Avatar string `json:"avatar,omitempty"`
+}
+
+type ReviewJSON struct {
+	ID               int        `json:"id"`
+	User             UserJSON   `json:"user"`
+	ReviewText       string     `json:"review_text"`
+	Score            int        `json:"score"`
+	CreatedAt        time.Time  `json:"created_at"`
+	CreatedAtDisplay string     `json:"created_at_display,omitempty"`
+	UpdatedAt        *time.Time `json:"updated_at,omitempty"`
+	Likes            int        `json:"likes"`
+	Dislikes         int        `json:"dislikes"`
+}
+
+// MoneyJSON currency-tagged amount with optional localized string
+type MoneyJSON struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+	Display  string `json:"display,omitempty"`
+}
+
+// PremiereJSON premiere in country, date is ISO-8601 and country is "world" or ISO 3166-1 alpha-2 code
+type PremiereJSON struct {
+	Country     string `json:"country"`
+	Date        string `json:"date"`
+	DateDisplay string `json:"date_display,omitempty"`
+}
+
+type GenreJSON struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// StaffJSON short info of person credited in movie, character is set only for actors
+type StaffJSON struct {
+	ID         int    `json:"id"`
+	FullName   string `json:"full_name"`
+	EnFullName string `json:"en_full_name,omitempty"`
+	Photo      string `json:"photo,omitempty"`
+	Role       string `json:"role"`
+	Character  string `json:"character,omitempty"`
+}
+
+type MovieJSON struct {
+	ID              int            `json:"id"`
+	Name            string         `json:"name"`
+	OriginalName    string         `json:"original_name,omitempty"`
+	About           string         `json:"about,omitempty"`
+	Poster          string         `json:"poster,omitempty"`
+	ReleaseYear     int            `json:"release_year,omitempty"`
+	Country         string         `json:"country,omitempty"`
+	Slogan          string         `json:"slogan,omitempty"`
+	Budget          *MoneyJSON     `json:"budget,omitempty"`
+	BoxOfficeUS     *MoneyJSON     `json:"box_office_us,omitempty"`
+	BoxOfficeGlobal *MoneyJSON     `json:"box_office_global,omitempty"`
+	BoxOfficeRussia *MoneyJSON     `json:"box_office_russia,omitempty"`
+	Premieres       []PremiereJSON `json:"premieres,omitempty"`
+	// premieres in Russia and world premiere are repeated for older clients, dates are ISO-8601
+	PremierRussia        string  `json:"premier_russia,omitempty"`
+	PremierRussiaDisplay string  `json:"premier_russia_display,omitempty"`
+	PremierGlobal        string  `json:"premier_global,omitempty"`
+	PremierGlobalDisplay string  `json:"premier_global_display,omitempty"`
+	Rating               float64 `json:"rating,omitempty"`
+	// Duration in minutes
+	Duration        int         `json:"duration,omitempty"`
+	DurationDisplay string      `json:"duration_display,omitempty"`
+	Genres          []GenreJSON `json:"genres,omitempty"`
+	Staff           []StaffJSON `json:"staff,omitempty"`
+	// Reviews summary only, reviews themselves are listed page by page
+	Reviews ReviewSummaryJSON `json:"reviews"`
+	// UserRating score current user gave movie, only for logged in user who rated it
+	UserRating *int `json:"user_rating,omitempty"`
+	// InWatchlist whether current user saved movie to watch later, only for logged in user
+	InWatchlist *bool `json:"in_watchlist,omitempty"`
+}
+
+// ReviewSummaryJSON number of reviews, their average score and distribution
+type ReviewSummaryJSON struct {
+	Count   int     `json:"count"`
+	Average float64 `json:"average"`
+	// Scores number of reviews by score, scores[i] counts score i+1
+	Scores   [10]int `json:"scores"`
+	Positive int     `json:"positive"`
+	Neutral  int     `json:"neutral"`
+	Negative int     `json:"negative"`
+}
+
+func NewReviewSummaryJSON(summary models.ReviewSummary) ReviewSummaryJSON {
+	return ReviewSummaryJSON{
+		Count:    summary.Count,
+		Average:  summary.Average,
+		Scores:   summary.Scores,
+		Positive: summary.Positive,
+		Neutral:  summary.Neutral,
+		Negative: summary.Negative,
+	}
+}
+
+// NewGenreJSON returns genre with name in requested locale, russian by default
+func NewGenreJSON(genre models.Genre, locale l10n.Locale) GenreJSON {
+	return GenreJSON{ID: genre.ID, Name: l10n.Pick(locale, genre.Name, genre.EnName)}
+}
+
+func NewStaffJSON(member models.StaffMember) StaffJSON {
+	return StaffJSON{
+		ID:         member.Person.ID,
+		FullName:   member.Person.FullName,
+		EnFullName: member.Person.EnFullName,
+		Photo:      member.Person.Photo,
+		Role:       string(member.Role),
+		Character:  member.Character,
+	}
+}
+
+func NewMoneyJSON(money models.Money, locale l10n.Locale) *MoneyJSON {
+	if money.IsZero() {
+		return nil
+	}
+
+	return &MoneyJSON{
+		Amount:   money.Amount,
+		Currency: string(money.Currency),
+		Display:  l10n.FormatMoney(money.Amount, string(money.Currency), locale),
+	}
+}
+
+func isoDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(l10n.DateLayout)
+}
+
+func displayDate(t *time.Time, locale l10n.Locale) string {
+	if t == nil {
+		return ""
+	}
+	return l10n.FormatDate(*t, locale)
+}
+
+// NewPremiereJSON returns premiere with date display string in requested locale
+func NewPremiereJSON(premiere models.Premiere, locale l10n.Locale) PremiereJSON {
+	return PremiereJSON{
+		Country:     premiere.Country,
+		Date:        isoDate(&premiere.Date),
+		DateDisplay: displayDate(&premiere.Date, locale),
+	}
+}
+
+func NewReviewJSON(review models.Review, locale l10n.Locale) ReviewJSON {
+	return ReviewJSON{
+		ID: review.ID,
+		User: UserJSON{
+			ID:     review.User.ID,
+			Login:  review.User.Login,
+			Avatar: review.User.Avatar,
+		},
+		ReviewText:       review.Text,
+		Score:            review.Score,
+		CreatedAt:        review.CreatedAt,
+		CreatedAtDisplay: displayDate(&review.CreatedAt, locale),
+		UpdatedAt:        review.UpdatedAt,
+		Likes:            review.Likes(),
+		Dislikes:         review.Dislikes(),
+	}
+}
+
+// NewMovieJSON maps movie to response, display strings are filled only for known locale
+func NewMovieJSON(movie models.Movie, locale l10n.Locale) MovieJSON {
+	res := MovieJSON{
+		ID:              movie.ID,
+		Name:            movie.Name,
+		OriginalName:    movie.OriginalName,
+		About:           movie.About,
+		Poster:          movie.Poster,
+		ReleaseYear:     movie.ReleaseYear,
+		Country:         movie.Country,
+		Slogan:          movie.Slogan,
+		Budget:          NewMoneyJSON(movie.Budget, locale),
+		BoxOfficeUS:     NewMoneyJSON(movie.BoxOfficeUS, locale),
+		BoxOfficeGlobal: NewMoneyJSON(movie.BoxOfficeGlobal, locale),
+		BoxOfficeRussia: NewMoneyJSON(movie.BoxOfficeRussia, locale),
+
+		PremierRussia:        isoDate(movie.PremiereIn(models.PremiereRussia)),
+		PremierRussiaDisplay: displayDate(movie.PremiereIn(models.PremiereRussia), locale),
+		PremierGlobal:        isoDate(movie.PremiereIn(models.PremiereWorld)),
+		PremierGlobalDisplay: displayDate(movie.PremiereIn(models.PremiereWorld), locale),
+		Rating:               movie.Rating,
+
+		Duration: movie.Duration,
+		Reviews:  NewReviewSummaryJSON(movie.ReviewSummary()),
+	}
+	if movie.Duration > 0 {
+		res.DurationDisplay = l10n.FormatDuration(movie.Duration, locale)
+	}
+
+	for _, premiere := range movie.Premieres {
+		res.Premieres = append(res.Premieres, NewPremiereJSON(premiere, locale))
+	}
+	for _, genre := range movie.Genres {
+		res.Genres = append(res.Genres, NewGenreJSON(genre, locale))
+	}
+	for _, member := range movie.Staff {
+		res.Staff = append(res.Staff, NewStaffJSON(member))
+	}
+	return res
+}
+
+// MovieShortJSON movie card shown in lists and search results
+type MovieShortJSON struct {
+	ID           int         `json:"id"`
+	Name         string      `json:"name"`
+	OriginalName string      `json:"original_name,omitempty"`
+	Poster       string      `json:"poster,omitempty"`
+	ReleaseYear  int         `json:"release_year,omitempty"`
+	Country      string      `json:"country,omitempty"`
+	Rating       float64     `json:"rating,omitempty"`
+	Duration     int         `json:"duration,omitempty"`
+	Genres       []GenreJSON `json:"genres,omitempty"`
+}
+
+func NewMovieShortJSON(movie models.Movie, locale l10n.Locale) MovieShortJSON {
+	res := MovieShortJSON{
+		ID:           movie.ID,
+		Name:         movie.Name,
+		OriginalName: movie.OriginalName,
+		Poster:       movie.Poster,
+		ReleaseYear:  movie.ReleaseYear,
+		Country:      movie.Country,
+		Rating:       movie.Rating,
+		Duration:     movie.Duration,
+	}
+	for _, genre := range movie.Genres {
+		res.Genres = append(res.Genres, NewGenreJSON(genre, locale))
+	}
+	return res
+}
+
+// MoviesPageJSON catalog page
+type MoviesPageJSON struct {
+	Movies     []MovieShortJSON `json:"movies"`
+	Total      int              `json:"total"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+}
+
+func NewMoviesPageJSON(page models.MoviePage, locale l10n.Locale) MoviesPageJSON {
+	res := MoviesPageJSON{
+		Movies:     make([]MovieShortJSON, 0, len(page.Movies)),
+		Total:      page.Total,
+		NextCursor: page.NextCursor,
+	}
+	for _, movie := range page.Movies {
+		res.Movies = append(res.Movies, NewMovieShortJSON(movie, locale))
+	}
+	return res
+}
+
+// MovieRequest movie edited by admin, genres and staff are linked by ids
+type MovieRequest struct {
+	Name            string         `json:"name"`
+	OriginalName    string         `json:"original_name"`
+	About           string         `json:"about"`
+	Poster          string         `json:"poster"`
+	ReleaseYear     int            `json:"release_year"`
+	Country         string         `json:"country"`
+	Slogan          string         `json:"slogan"`
+	Budget          *MoneyJSON     `json:"budget"`
+	BoxOfficeUS     *MoneyJSON     `json:"box_office_us"`
+	BoxOfficeGlobal *MoneyJSON     `json:"box_office_global"`
+	BoxOfficeRussia *MoneyJSON     `json:"box_office_russia"`
+	Premieres       []PremiereJSON `json:"premieres"`
+	// Duration in minutes
+	Duration int             `json:"duration"`
+	GenreIDs []int           `json:"genre_ids"`
+	Staff    []StaffLinkJSON `json:"staff"`
+}
+
+// StaffLinkJSON credit of person in movie edited by admin
+type StaffLinkJSON struct {
+	PersonID  int    `json:"person_id"`
+	Role      string `json:"role"`
+	Character string `json:"character,omitempty"`
+}
+
+// NewMovieRequest converts stored movie back to request, so that partial update can be applied over it
+func NewMovieRequest(movie models.Movie) MovieRequest {
+	res := MovieRequest{
+		Name:            movie.Name,
+		OriginalName:    movie.OriginalName,
+		About:           movie.About,
+		Poster:          movie.Poster,
+		ReleaseYear:     movie.ReleaseYear,
+		Country:         movie.Country,
+		Slogan:          movie.Slogan,
+		Budget:          NewMoneyJSON(movie.Budget, l10n.LocaleNone),
+		BoxOfficeUS:     NewMoneyJSON(movie.BoxOfficeUS, l10n.LocaleNone),
+		BoxOfficeGlobal: NewMoneyJSON(movie.BoxOfficeGlobal, l10n.LocaleNone),
+		BoxOfficeRussia: NewMoneyJSON(movie.BoxOfficeRussia, l10n.LocaleNone),
+		Premieres:       make([]PremiereJSON, 0, len(movie.Premieres)),
+		Duration:        movie.Duration,
+		GenreIDs:        make([]int, 0, len(movie.Genres)),
+		Staff:           make([]StaffLinkJSON, 0, len(movie.Staff)),
+	}
+	for _, premiere := range movie.Premieres {
+		res.Premieres = append(res.Premieres, NewPremiereJSON(premiere, l10n.LocaleNone))
+	}
+	for _, genre := range movie.Genres {
+		res.GenreIDs = append(res.GenreIDs, genre.ID)
+	}
+	for _, member := range movie.Staff {
+		res.Staff = append(res.Staff, StaffLinkJSON{PersonID: member.Person.ID, Role: string(member.Role), Character: member.Character})
+	}
+	return res
+}
+
+// ToStaffLinks converts credits requested by admin
+func ToStaffLinks(staff []StaffLinkJSON) []models.StaffLink {
+	res := make([]models.StaffLink, 0, len(staff))
+	for _, link := range staff {
+		res = append(res, models.StaffLink{
+			PersonID:  link.PersonID,
+			Role:      models.StaffRole(link.Role),
+			Character: strings.TrimSpace(link.Character),
+		})
+	}
+	return res
+}
+
+// ToMoney converts requested amount, nil means it is unknown
+func (m *MoneyJSON) ToMoney() models.Money {
+	if m == nil {
+		return models.Money{}
+	}
+	return models.Money{Amount: m.Amount, Currency: models.Currency(m.Currency)}
+}
@@ -4,4 +4,10 @@ import "net/http"
 
 type MovieHandlerInterface interface {
 	GetMovie(w http.ResponseWriter, r *http.Request)
+	ListMovies(w http.ResponseWriter, r *http.Request)
+
+	CreateMovie(w http.ResponseWriter, r *http.Request)
+	UpdateMovie(w http.ResponseWriter, r *http.Request)
+	PatchMovie(w http.ResponseWriter, r *http.Request)
+	DeleteMovie(w http.ResponseWriter, r *http.Request)
 }
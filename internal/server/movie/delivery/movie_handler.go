@@ -5,8 +5,11 @@ import (
 	"net/http"
 	"strconv"
 
-	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/middleware"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/delivery/dto"
 	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/jsonutil"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
@@ -14,20 +17,73 @@ import (
 	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
 )
 
+// localeParam query parameter requesting localized display strings
+const localeParam = "locale"
+
 type MovieServiceInterface interface {
-	GetMovieByID(ctx context.Context, movieID int) (*mocks.MovieJSON, error)
+	GetMovieByID(ctx context.Context, movieID int) (*models.Movie, error)
+	ListMovies(ctx context.Context, req models.MovieListRequest) (*models.MoviePage, error)
+
+	CreateMovie(ctx context.Context, movie models.Movie, genreIDs []int, staff []models.StaffLink) (*models.Movie, error)
+	UpdateMovie(ctx context.Context, movie models.Movie, genreIDs []int, staff []models.StaffLink) (*models.Movie, error)
+	DeleteMovie(ctx context.Context, movieID int) error
+}
+
+type RatingServiceInterface interface {
+	GetUserRating(ctx context.Context, movieID int, username string) (*models.Rating, error)
+}
+
+type WatchlistServiceInterface interface {
+	IsInWatchlist(ctx context.Context, username string, movieID int) (bool, error)
+}
+
+// ViewCounterInterface counts movie page views for "Популярно сейчас", it must not block request
+type ViewCounterInterface interface {
+	CountView(movieID int)
 }
 
 type MovieHandler struct {
-	movieService MovieServiceInterface
+	movieService     MovieServiceInterface
+	ratingService    RatingServiceInterface
+	watchlistService WatchlistServiceInterface
+	viewCounter      ViewCounterInterface
 }
 
-func NewMovieHandler(movieService MovieServiceInterface) *MovieHandler {
+func NewMovieHandler(movieService MovieServiceInterface, ratingService RatingServiceInterface, watchlistService WatchlistServiceInterface,
+	viewCounter ViewCounterInterface) *MovieHandler {
 	return &MovieHandler{
-		movieService: movieService,
+		movieService:     movieService,
+		ratingService:    ratingService,
+		watchlistService: watchlistService,
+		viewCounter:      viewCounter,
 	}
 }
 
+// fillUserData adds to movie what is known about it for logged in user
+func (h *MovieHandler) fillUserData(r *http.Request, movieID int, res *dto.MovieJSON) {
+	logger := log.Ctx(r.Context())
+
+	username := middleware.Username(r.Context())
+	if username == "" {
+		return
+	}
+
+	rating, err := h.ratingService.GetUserRating(r.Context(), movieID, username)
+	switch {
+	case err == nil:
+		res.UserRating = &rating.Score
+	case !errors.Is(err, errs.ErrRatingNotFound):
+		logger.Warn().Err(err).Msg("getMovie action: failed to get user rating")
+	}
+
+	inWatchlist, err := h.watchlistService.IsInWatchlist(r.Context(), username, movieID)
+	if err != nil {
+		logger.Warn().Err(err).Msg("getMovie action: failed to check watchlist")
+		return
+	}
+	res.InWatchlist = &inWatchlist
+}
+
 func (h *MovieHandler) GetMovie(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
@@ -49,7 +105,7 @@ func (h *MovieHandler) GetMovie(w http.ResponseWriter, r *http.Request) {
 	}
 
 	logger.Info().Msgf("getting movie by id: %d", movieID)
-	movieJSON, err := h.movieService.GetMovieByID(r.Context(), movieID)
+	movie, err := h.movieService.GetMovieByID(r.Context(), movieID)
 	if err != nil {
 		logger.Error().Err(err).Msg(err.Error())
 		if errors.Is(err, errs.ErrMovieNotFound) {
@@ -60,8 +116,12 @@ func (h *MovieHandler) GetMovie(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	logger.Info().Msgf("successfully got movie data by id: %d", movieID)
+	h.viewCounter.CountView(movieID)
+
+	res := dto.NewMovieJSON(*movie, l10n.ParseLocale(r.URL.Query().Get(localeParam)))
+	h.fillUserData(r, movieID, &res)
 
-	if err := jsonutil.SendJSON(r.Context(), w, movieJSON); err != nil {
+	if err := jsonutil.SendJSON(r.Context(), w, res); err != nil {
 		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
 		return
 	}
@@ -2,28 +2,262 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
 
 	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
 	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
 	"github.com/rs/zerolog/log"
 )
 
+// MovieListener is notified after movies are changed, e.g. to keep search index up to date
+type MovieListener interface {
+	OnMovieUpsert(ctx context.Context, movie models.Movie)
+	OnMovieDelete(ctx context.Context, movieID int)
+}
+
 type MovieRepository struct {
-	db *mocks.Movies
+	mu           sync.RWMutex
+	db           *mocks.Movies
+	listeners    []MovieListener
+	lastReviewID int
 }
 
 func NewMovieRepository(movieDB *mocks.Movies) *MovieRepository {
-	return &MovieRepository{db: movieDB}
+	r := &MovieRepository{db: movieDB}
+	for _, movie := range *movieDB {
+		r.trackReviewIDs(movie)
+	}
+	return r
+}
+
+// trackReviewIDs keeps review ids unique across all movies, must be called under write lock
+func (r *MovieRepository) trackReviewIDs(movie models.Movie) {
+	for _, review := range movie.Reviews {
+		r.lastReviewID = max(r.lastReviewID, review.ID)
+	}
 }
 
-func (r *MovieRepository) GetMovieFromRepoByID(ctx context.Context, movieID int) (*mocks.MovieJSON, error) {
+// NewReviewID reserves id for new review
+func (r *MovieRepository) NewReviewID(ctx context.Context) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastReviewID++
+	return r.lastReviewID
+}
+
+func (r *MovieRepository) GetMovieFromRepoByID(ctx context.Context, movieID int) (*models.Movie, error) {
 	logger := log.Ctx(ctx)
 
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	movie, exists := (*r.db)[movieID]
+	if !exists || movie.IsDeleted() {
+		logger.Err(errs.ErrMovieNotFound).Msg(errs.ErrMovieNotFound.Error())
+		return nil, errs.ErrMovieNotFound
+	}
+
+	return &movie, nil
+}
+
+// GetStoredMovie returns movie by id even if it is deleted
+func (r *MovieRepository) GetStoredMovie(ctx context.Context, movieID int) (*models.Movie, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	movie, exists := (*r.db)[movieID]
 	if !exists {
+		log.Ctx(ctx).Err(errs.ErrMovieNotFound).Msg(errs.ErrMovieNotFound.Error())
+		return nil, errs.ErrMovieNotFound
+	}
+
+	return &movie, nil
+}
+
+// GetAllMovies returns all movies ordered by id, deleted ones are skipped
+func (r *MovieRepository) GetAllMovies(ctx context.Context) ([]models.Movie, error) {
+	return r.movies(false), nil
+}
+
+// GetStoredMovies returns all movies ordered by id including deleted ones, which are kept for snapshots
+func (r *MovieRepository) GetStoredMovies(ctx context.Context) ([]models.Movie, error) {
+	return r.movies(true), nil
+}
+
+func (r *MovieRepository) movies(withDeleted bool) []models.Movie {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	res := make([]models.Movie, 0, len(*r.db))
+	for _, movie := range *r.db {
+		if movie.IsDeleted() && !withDeleted {
+			continue
+		}
+		res = append(res, movie)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ID < res[j].ID
+	})
+
+	return res
+}
+
+// Subscribe registers listener, it is called synchronously after repository lock is released
+func (r *MovieRepository) Subscribe(listener MovieListener) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.listeners = append(r.listeners, listener)
+}
+
+func (r *MovieRepository) getListeners() []MovieListener {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.listeners
+}
+
+// UpsertMovie creates movie or replaces existing one with the same id
+func (r *MovieRepository) UpsertMovie(ctx context.Context, movie models.Movie) error {
+	r.mu.Lock()
+	(*r.db)[movie.ID] = movie
+	r.trackReviewIDs(movie)
+	r.mu.Unlock()
+
+	for _, listener := range r.getListeners() {
+		listener.OnMovieUpsert(ctx, movie)
+	}
+	return nil
+}
+
+// CreateMovie saves movie under new id, ids of deleted movies are not reused
+func (r *MovieRepository) CreateMovie(ctx context.Context, movie models.Movie) (*models.Movie, error) {
+	r.mu.Lock()
+	movie.ID = 0
+	for id := range *r.db {
+		movie.ID = max(movie.ID, id)
+	}
+	movie.ID++
+	(*r.db)[movie.ID] = movie
+	r.trackReviewIDs(movie)
+	r.mu.Unlock()
+
+	for _, listener := range r.getListeners() {
+		listener.OnMovieUpsert(ctx, movie)
+	}
+	return &movie, nil
+}
+
+// DeleteMovie marks movie as deleted, it disappears from catalog but stays in snapshots
+func (r *MovieRepository) DeleteMovie(ctx context.Context, movieID int) error {
+	logger := log.Ctx(ctx)
+
+	r.mu.Lock()
+	movie, exists := (*r.db)[movieID]
+	if !exists || movie.IsDeleted() {
+		r.mu.Unlock()
+		logger.Err(errs.ErrMovieNotFound).Msg(errs.ErrMovieNotFound.Error())
+		return errs.ErrMovieNotFound
+	}
+	deletedAt := time.Now()
+	movie.DeletedAt = &deletedAt
+	(*r.db)[movieID] = movie
+	r.mu.Unlock()
+
+	for _, listener := range r.getListeners() {
+		listener.OnMovieDelete(ctx, movieID)
+	}
+	return nil
+}
+
+// UpdateMovie atomically applies fn to movie, nothing is saved if fn fails.
+// fn runs under repository lock and must not call repository
+func (r *MovieRepository) UpdateMovie(ctx context.Context, movieID int, fn func(movie *models.Movie) error) (*models.Movie, error) {
+	return r.update(ctx, movieID, false, fn)
+}
+
+// UpdateStoredMovie is UpdateMovie which updates deleted movies too, e.g. credits of merged persons
+// must stay valid in movies which may be restored from snapshot. Listeners are not notified about deleted movies
+func (r *MovieRepository) UpdateStoredMovie(ctx context.Context, movieID int, fn func(movie *models.Movie) error) (*models.Movie, error) {
+	return r.update(ctx, movieID, true, fn)
+}
+
+func (r *MovieRepository) update(ctx context.Context, movieID int, withDeleted bool, fn func(movie *models.Movie) error) (*models.Movie, error) {
+	logger := log.Ctx(ctx)
+
+	r.mu.Lock()
+	movie, exists := (*r.db)[movieID]
+	if !exists || (movie.IsDeleted() && !withDeleted) {
+		r.mu.Unlock()
 		logger.Err(errs.ErrMovieNotFound).Msg(errs.ErrMovieNotFound.Error())
 		return nil, errs.ErrMovieNotFound
 	}
 
+	// reviews are copied so that failed update does not touch stored movie
+	movie.Reviews = append([]models.Review(nil), movie.Reviews...)
+	if err := fn(&movie); err != nil {
+		r.mu.Unlock()
+		return nil, err
+	}
+	movie.ID = movieID
+	(*r.db)[movieID] = movie
+	r.trackReviewIDs(movie)
+	r.mu.Unlock()
+
+	if movie.IsDeleted() {
+		return &movie, nil
+	}
+	for _, listener := range r.getListeners() {
+		listener.OnMovieUpsert(ctx, movie)
+	}
 	return &movie, nil
 }
+
+// Snapshot serializes all movies
+func (r *MovieRepository) Snapshot(ctx context.Context) ([]byte, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return json.Marshal(*r.db)
+}
+
+// Restore replaces all movies with ones from snapshot
+func (r *MovieRepository) Restore(ctx context.Context, data []byte) error {
+	var movies mocks.Movies
+	if err := json.Unmarshal(data, &movies); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	var deleted []int
+	for id := range *r.db {
+		if movie, ok := movies[id]; !ok || movie.IsDeleted() {
+			deleted = append(deleted, id)
+		}
+		delete(*r.db, id)
+	}
+	r.lastReviewID = 0
+	for id, movie := range movies {
+		(*r.db)[id] = movie
+		r.trackReviewIDs(movie)
+	}
+	r.mu.Unlock()
+
+	for _, listener := range r.getListeners() {
+		for _, id := range deleted {
+			listener.OnMovieDelete(ctx, id)
+		}
+		for _, movie := range movies {
+			if !movie.IsDeleted() {
+				listener.OnMovieUpsert(ctx, movie)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/rs/zerolog/log"
+)
+
+// linkGenres resolves genres of movie by their ids
+func (s *MovieService) linkGenres(ctx context.Context, genreIDs []int) ([]models.Genre, error) {
+	genres := make([]models.Genre, 0, len(genreIDs))
+	for _, genreID := range genreIDs {
+		genre, err := s.genreRepo.GetGenreByID(ctx, genreID)
+		if err != nil {
+			return nil, err
+		}
+		genres = append(genres, *genre)
+	}
+	return genres, nil
+}
+
+// linkStaff resolves people who made movie by their ids, order is kept as given
+func (s *MovieService) linkStaff(ctx context.Context, links []models.StaffLink) ([]models.StaffMember, error) {
+	staff := make([]models.StaffMember, 0, len(links))
+	for _, link := range links {
+		person, err := s.personRepo.GetPersonFromRepoByID(ctx, link.PersonID)
+		if err != nil {
+			return nil, err
+		}
+		staff = append(staff, models.StaffMember{Person: person.Short(), Role: link.Role, Character: link.Character})
+	}
+	return staff, nil
+}
+
+// link sets genres and staff of movie, movie is left untouched on error
+func (s *MovieService) link(ctx context.Context, movie *models.Movie, genreIDs []int, staff []models.StaffLink) error {
+	genres, err := s.linkGenres(ctx, genreIDs)
+	if err != nil {
+		return err
+	}
+	members, err := s.linkStaff(ctx, staff)
+	if err != nil {
+		return err
+	}
+
+	movie.Genres, movie.Staff = genres, members
+	return nil
+}
+
+// CreateMovie adds movie to catalog, linked genres and persons must exist. New movie has no reviews,
+// so it has no rating. Search index and smart collections are updated by repository listeners
+func (s *MovieService) CreateMovie(ctx context.Context, movie models.Movie, genreIDs []int, staff []models.StaffLink) (*models.Movie, error) {
+	logger := log.Ctx(ctx)
+
+	if err := s.link(ctx, &movie, genreIDs, staff); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+	movie.Reviews = nil
+	movie.UpdateRating()
+	movie.DeletedAt = nil
+
+	res, err := s.movieRepo.CreateMovie(ctx, movie)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// UpdateMovie replaces all editable fields of movie, reviews written by users and rating computed by them are kept
+func (s *MovieService) UpdateMovie(ctx context.Context, movie models.Movie, genreIDs []int, staff []models.StaffLink) (*models.Movie, error) {
+	logger := log.Ctx(ctx)
+
+	if err := s.link(ctx, &movie, genreIDs, staff); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	res, err := s.movieRepo.UpdateMovie(ctx, movie.ID, func(stored *models.Movie) error {
+		movie.Reviews = stored.Reviews
+		movie.UpdateRating()
+		movie.DeletedAt = nil
+		*stored = movie
+		return nil
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// DeleteMovie removes movie from catalog, lists and diaries referring it skip it as they do for unknown movies
+func (s *MovieService) DeleteMovie(ctx context.Context, movieID int) error {
+	logger := log.Ctx(ctx)
+
+	if err := s.movieRepo.DeleteMovie(ctx, movieID); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return err
+	}
+
+	return nil
+}
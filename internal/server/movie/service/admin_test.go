@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	repoGenre "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/genre/repository"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	repoMovie "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/repository"
+	repoStaff "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/staff_person/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// movieEvents records notifications of repository listener
+type movieEvents struct {
+	upserted []int
+	deleted  []int
+}
+
+func (e *movieEvents) OnMovieUpsert(ctx context.Context, movie models.Movie) {
+	e.upserted = append(e.upserted, movie.ID)
+}
+
+func (e *movieEvents) OnMovieDelete(ctx context.Context, movieID int) {
+	e.deleted = append(e.deleted, movieID)
+}
+
+func newAdminMovieService(movies *mocks.Movies) (*MovieService, *movieEvents) {
+	genres := mocks.Genres{1: drama, 2: thriller, 3: comedy}
+	persons := mocks.Persons{10: {ID: 10, FullName: "Дэвид Финчер"}, 11: {ID: 11, FullName: "Брэд Питт"}}
+
+	movieRepo := repoMovie.NewMovieRepository(movies)
+	events := &movieEvents{}
+	movieRepo.Subscribe(events)
+
+	return NewMovieService(movieRepo, repoGenre.NewGenreRepository(&genres), repoStaff.NewStaffPersonRepository(&persons), nil), events
+}
+
+func TestMovieService_CreateMovie(t *testing.T) {
+	ctx := context.Background()
+	movies := catalogMovies()
+	s, events := newAdminMovieService(&movies)
+
+	created, err := s.CreateMovie(ctx, models.Movie{Name: "Зодиак", ReleaseYear: 2007, Rating: 9}, []int{2, 1},
+		[]models.StaffLink{{PersonID: 10, Role: models.RoleDirector}})
+	require.NoError(t, err)
+	assert.Equal(t, 5, created.ID)
+	assert.Zero(t, created.Rating, "movie without reviews has no rating")
+	assert.Equal(t, []models.Genre{thriller, drama}, created.Genres)
+	assert.Equal(t, []models.StaffMember{{Person: models.Person{ID: 10, FullName: "Дэвид Финчер"}, Role: models.RoleDirector}}, created.Staff)
+	assert.Equal(t, []int{5}, events.upserted)
+
+	got, err := s.GetMovieByID(ctx, 5)
+	require.NoError(t, err)
+	assert.Equal(t, "Зодиак", got.Name)
+
+	_, err = s.CreateMovie(ctx, models.Movie{Name: "Зодиак"}, []int{42}, nil)
+	require.ErrorIs(t, err, errs.ErrGenreNotFound)
+
+	_, err = s.CreateMovie(ctx, models.Movie{Name: "Зодиак"}, nil, []models.StaffLink{{PersonID: 42, Role: models.RoleActor}})
+	require.ErrorIs(t, err, errs.ErrPersonNotFound)
+	assert.Len(t, movies, 5)
+}
+
+func TestMovieService_UpdateMovie(t *testing.T) {
+	ctx := context.Background()
+	movies := catalogMovies()
+	for i, score := range []int{9, 8, 7} {
+		movies[1].Reviews[i].Score = score
+	}
+	s, events := newAdminMovieService(&movies)
+
+	updated, err := s.UpdateMovie(ctx, models.Movie{ID: 1, Name: "Бойцовский клуб", ReleaseYear: 1999, Rating: 3}, []int{1}, []models.StaffLink{
+		{PersonID: 10, Role: models.RoleDirector},
+		{PersonID: 11, Role: models.RoleActor, Character: "Тайлер Дёрден"},
+		{PersonID: 11, Role: models.RoleProducer},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []models.Genre{drama}, updated.Genres)
+	require.Len(t, updated.Staff, 3)
+	assert.Equal(t, models.StaffMember{Person: models.Person{ID: 11, FullName: "Брэд Питт"}, Role: models.RoleActor, Character: "Тайлер Дёрден"},
+		updated.Staff[1])
+	// reviews are written by users and are not edited by admin
+	assert.Len(t, updated.Reviews, 3)
+	assert.Equal(t, 8.0, updated.Rating, "rating is computed by reviews")
+	assert.Zero(t, updated.Duration)
+	assert.Equal(t, []int{1}, events.upserted)
+
+	_, err = s.UpdateMovie(ctx, models.Movie{ID: 1, Name: "Бойцовский клуб"}, []int{1}, []models.StaffLink{{PersonID: 42, Role: models.RoleActor}})
+	require.ErrorIs(t, err, errs.ErrPersonNotFound)
+	got, err := s.GetMovieByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, got.Staff, 3)
+
+	_, err = s.UpdateMovie(ctx, models.Movie{ID: 42, Name: "Нет такого"}, nil, nil)
+	require.ErrorIs(t, err, errs.ErrMovieNotFound)
+}
+
+func TestMovieService_DeleteMovie(t *testing.T) {
+	ctx := context.Background()
+	movies := catalogMovies()
+	s, events := newAdminMovieService(&movies)
+
+	require.NoError(t, s.DeleteMovie(ctx, 4))
+	assert.Equal(t, []int{4}, events.deleted)
+
+	_, err := s.GetMovieByID(ctx, 4)
+	require.ErrorIs(t, err, errs.ErrMovieNotFound)
+	require.ErrorIs(t, s.DeleteMovie(ctx, 4), errs.ErrMovieNotFound)
+	_, err = s.UpdateMovie(ctx, models.Movie{ID: 4, Name: "Джокер"}, nil, nil)
+	require.ErrorIs(t, err, errs.ErrMovieNotFound)
+
+	page, err := s.ListMovies(ctx, models.MovieListRequest{Sort: models.SortByRating, Desc: true, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 3, 2}, listIDs(page))
+
+	// movie stays in storage, so its id is not given to new movie
+	require.NotNil(t, movies[4].DeletedAt)
+	created, err := s.CreateMovie(ctx, models.Movie{Name: "Зодиак"}, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 5, created.ID)
+}
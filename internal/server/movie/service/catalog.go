@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/cursor"
+	"github.com/rs/zerolog/log"
+)
+
+// catalogKey returns sort key of movie in catalog, trending scores are used only for SortByTrending
+func catalogKey(movie models.Movie, sortBy models.MovieSort, trending map[int]float64) cursor.Key {
+	key := cursor.Key{ID: movie.ID}
+	switch sortBy {
+	case models.SortByRating:
+		key.Num = movie.Rating
+	case models.SortByYear:
+		key.Num = float64(movie.ReleaseYear)
+	case models.SortByPopularity:
+		key.Num = float64(movie.Popularity())
+	case models.SortByTitle:
+		key.Text = strings.ToLower(movie.Name)
+	case models.SortByTrending:
+		key.Num = trending[movie.ID]
+	}
+	return key
+}
+
+func isKnownSort(sortBy models.MovieSort) bool {
+	switch sortBy {
+	case models.SortByRating, models.SortByYear, models.SortByPopularity, models.SortByTitle, models.SortByTrending:
+		return true
+	default:
+		return false
+	}
+}
+
+// ListMovies returns page of catalog filtered and sorted as requested
+func (s *MovieService) ListMovies(ctx context.Context, req models.MovieListRequest) (*models.MoviePage, error) {
+	logger := log.Ctx(ctx)
+
+	// period matters only for trending sort
+	switch {
+	case req.Sort != models.SortByTrending:
+		req.Period = ""
+	case req.Period == "":
+		req.Period = models.TrendingWeek
+	}
+	if _, ok := req.Period.Window(); !isKnownSort(req.Sort) || req.Limit <= 0 || req.Period != "" && !ok {
+		logger.Error().Str("sort", string(req.Sort)).Str("period", string(req.Period)).Int("limit", req.Limit).Msg(errs.ErrBadPayload)
+		return nil, errs.ErrInvalidCatalogRequest
+	}
+
+	movies, err := s.movieRepo.GetAllMovies(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	// trending scores decay and are recomputed from time to time, so cursor keeps time scores were computed at
+	// and cursor made for scores computed earlier is rejected
+	scope := cursor.Scope(req.Sort, req.Period)
+	var trending map[int]float64
+	if req.Sort == models.SortByTrending {
+		scores, err := s.trendingService.GetTrendingScores(ctx, req.Period)
+		if err != nil {
+			logger.Error().Err(err).Msg(err.Error())
+			return nil, err
+		}
+		trending = scores.Scores
+		scope = cursor.Scope(req.Sort, req.Period, scores.ComputedAt.UnixNano())
+	}
+
+	matched := make([]models.Movie, 0, len(movies))
+	for _, movie := range movies {
+		if req.Filter.Matches(movie) {
+			matched = append(matched, movie)
+		}
+	}
+
+	page, err := cursor.Paginate(matched, func(movie models.Movie) cursor.Key {
+		return catalogKey(movie, req.Sort, trending)
+	}, cursor.Request{Scope: scope, Desc: req.Desc, Limit: req.Limit, Cursor: req.Cursor})
+	if err != nil {
+		logger.Error().Err(err).Str("cursor", req.Cursor).Msg(err.Error())
+		return nil, err
+	}
+
+	return &models.MoviePage{Movies: page.Items, Total: page.Total, NextCursor: page.NextCursor}, nil
+}
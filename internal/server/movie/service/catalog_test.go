@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	repoMovie "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	drama    = models.Genre{ID: 1, Name: "драма"}
+	thriller = models.Genre{ID: 2, Name: "триллер"}
+	comedy   = models.Genre{ID: 3, Name: "комедия"}
+)
+
+func catalogMovies() mocks.Movies {
+	return mocks.Movies{
+		1: {ID: 1, Name: "Бойцовский клуб", ReleaseYear: 1999, Rating: 8.8, Duration: 139, Country: "США, Германия",
+			Genres: []models.Genre{drama, thriller}, Staff: []models.StaffMember{{Person: models.Person{ID: 10}}}, Reviews: make([]models.Review, 3)},
+		2: {ID: 2, Name: "Амели", ReleaseYear: 2001, Rating: 8.0, Duration: 122, Country: "Франция",
+			Genres: []models.Genre{comedy}, Reviews: make([]models.Review, 5)},
+		3: {ID: 3, Name: "Семь", ReleaseYear: 1995, Rating: 8.3, Duration: 127, Country: "США",
+			Genres: []models.Genre{drama, thriller}, Staff: []models.StaffMember{{Person: models.Person{ID: 10}}, {Person: models.Person{ID: 11}}}},
+		4: {ID: 4, Name: "Джокер", ReleaseYear: 2019, Rating: 8.0, Duration: 122, Country: "США",
+			Genres: []models.Genre{drama, thriller}, Reviews: make([]models.Review, 1)},
+	}
+}
+
+func listIDs(page *models.MoviePage) []int {
+	ids := make([]int, 0, len(page.Movies))
+	for _, movie := range page.Movies {
+		ids = append(ids, movie.ID)
+	}
+	return ids
+}
+
+func TestMovieService_ListMovies(t *testing.T) {
+	movies := catalogMovies()
+	s := NewMovieService(repoMovie.NewMovieRepository(&movies), nil, nil, nil)
+
+	personID := 10
+	tests := []struct {
+		name string
+		req  models.MovieListRequest
+		want []int
+	}{
+		{name: "rating desc, ties by id", req: models.MovieListRequest{Sort: models.SortByRating, Desc: true}, want: []int{1, 3, 2, 4}},
+		{name: "year asc", req: models.MovieListRequest{Sort: models.SortByYear}, want: []int{3, 1, 2, 4}},
+		{name: "popularity", req: models.MovieListRequest{Sort: models.SortByPopularity, Desc: true}, want: []int{2, 1, 4, 3}},
+		{name: "title", req: models.MovieListRequest{Sort: models.SortByTitle}, want: []int{2, 1, 4, 3}},
+		{name: "genres", req: models.MovieListRequest{Sort: models.SortByYear, Filter: models.MovieFilter{GenreIDs: []int{1, 2}}}, want: []int{3, 1, 4}},
+		{name: "country", req: models.MovieListRequest{Sort: models.SortByYear, Filter: models.MovieFilter{Country: "германия"}}, want: []int{1}},
+		{name: "years", req: models.MovieListRequest{Sort: models.SortByYear, Filter: models.MovieFilter{YearFrom: 1996, YearTo: 2010}}, want: []int{1, 2}},
+		{name: "rating", req: models.MovieListRequest{Sort: models.SortByYear, Filter: models.MovieFilter{RatingFrom: 8.1}}, want: []int{3, 1}},
+		{name: "duration", req: models.MovieListRequest{Sort: models.SortByYear, Filter: models.MovieFilter{DurationTo: 125}}, want: []int{2, 4}},
+		{name: "person", req: models.MovieListRequest{Sort: models.SortByYear, Filter: models.MovieFilter{PersonID: &personID}}, want: []int{3, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.Limit = 10
+			page, err := s.ListMovies(context.Background(), tt.req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, listIDs(page))
+			assert.Equal(t, len(tt.want), page.Total)
+			assert.Empty(t, page.NextCursor)
+		})
+	}
+}
+
+func TestMovieService_ListMovies_Cursor(t *testing.T) {
+	ctx := context.Background()
+	movies := catalogMovies()
+	movieRepo := repoMovie.NewMovieRepository(&movies)
+	s := NewMovieService(movieRepo, nil, nil, nil)
+
+	req := models.MovieListRequest{Sort: models.SortByRating, Desc: true, Limit: 2}
+	page, err := s.ListMovies(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 3}, listIDs(page))
+	assert.Equal(t, 4, page.Total)
+	require.NotEmpty(t, page.NextCursor)
+
+	// movie added before cursor position must not shift next page
+	require.NoError(t, movieRepo.UpsertMovie(ctx, models.Movie{ID: 5, Name: "Крёстный отец", Rating: 9.2}))
+
+	req.Cursor = page.NextCursor
+	page, err = s.ListMovies(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 4}, listIDs(page))
+	assert.Equal(t, 5, page.Total)
+	assert.Empty(t, page.NextCursor)
+
+	req.Sort = models.SortByYear
+	_, err = s.ListMovies(ctx, req)
+	assert.ErrorIs(t, err, errs.ErrInvalidCursor, "cursor of another sort")
+
+	_, err = s.ListMovies(ctx, models.MovieListRequest{Sort: "budget", Limit: 10})
+	assert.ErrorIs(t, err, errs.ErrInvalidCatalogRequest)
+}
+
+// trendingScores scores of recent activity which are the same for every period
+type trendingScores struct {
+	scores     map[int]float64
+	computedAt time.Time
+}
+
+func (s *trendingScores) GetTrendingScores(ctx context.Context, period models.TrendingPeriod) (*models.TrendingScores, error) {
+	return &models.TrendingScores{Scores: s.scores, ComputedAt: s.computedAt}, nil
+}
+
+func TestMovieService_ListMovies_Trending(t *testing.T) {
+	ctx := context.Background()
+	movies := catalogMovies()
+	trending := &trendingScores{
+		scores:     map[int]float64{4: 12.5, 2: 3, 3: 3},
+		computedAt: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+	s := NewMovieService(repoMovie.NewMovieRepository(&movies), nil, nil, trending)
+
+	req := models.MovieListRequest{Sort: models.SortByTrending, Desc: true, Limit: 2}
+	page, err := s.ListMovies(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, []int{4, 2}, listIDs(page))
+	require.NotEmpty(t, page.NextCursor)
+
+	// movies without recent activity go last
+	req.Cursor = page.NextCursor
+	page, err = s.ListMovies(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 1}, listIDs(page))
+
+	// scores decayed and were recomputed, order of movies may change
+	trending.scores = map[int]float64{4: 6.25, 2: 1.5, 3: 1.5}
+	trending.computedAt = trending.computedAt.Add(time.Minute)
+	_, err = s.ListMovies(ctx, req)
+	assert.ErrorIs(t, err, errs.ErrInvalidCursor, "cursor of recomputed scores")
+
+	req.Cursor = ""
+	page, err = s.ListMovies(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, []int{4, 2}, listIDs(page))
+
+	req.Cursor = page.NextCursor
+	req.Period = models.TrendingMonth
+	_, err = s.ListMovies(ctx, req)
+	assert.ErrorIs(t, err, errs.ErrInvalidCursor, "cursor of another period")
+
+	_, err = s.ListMovies(ctx, models.MovieListRequest{Sort: models.SortByTrending, Period: "year", Limit: 10})
+	assert.ErrorIs(t, err, errs.ErrInvalidCatalogRequest)
+}
@@ -3,32 +3,55 @@ package service
 import (
 	"context"
 
-	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
 	"github.com/rs/zerolog/log"
 )
 
 type MovieRepositoryInterface interface {
-	GetMovieFromRepoByID(ctx context.Context, movieID int) (*mocks.MovieJSON, error)
+	GetMovieFromRepoByID(ctx context.Context, movieID int) (*models.Movie, error)
+	GetAllMovies(ctx context.Context) ([]models.Movie, error)
+	CreateMovie(ctx context.Context, movie models.Movie) (*models.Movie, error)
+	UpdateMovie(ctx context.Context, movieID int, fn func(movie *models.Movie) error) (*models.Movie, error)
+	DeleteMovie(ctx context.Context, movieID int) error
+}
+
+type GenreRepositoryInterface interface {
+	GetGenreByID(ctx context.Context, genreID int) (*models.Genre, error)
+}
+
+type PersonRepositoryInterface interface {
+	GetPersonFromRepoByID(ctx context.Context, personID int) (*models.Person, error)
+}
+
+type TrendingServiceInterface interface {
+	GetTrendingScores(ctx context.Context, period models.TrendingPeriod) (*models.TrendingScores, error)
 }
 
 type MovieService struct {
-	movieRepo MovieRepositoryInterface
+	movieRepo       MovieRepositoryInterface
+	genreRepo       GenreRepositoryInterface
+	personRepo      PersonRepositoryInterface
+	trendingService TrendingServiceInterface
 }
 
-func NewMovieService(movieRepo MovieRepositoryInterface) *MovieService {
+func NewMovieService(movieRepo MovieRepositoryInterface, genreRepo GenreRepositoryInterface, personRepo PersonRepositoryInterface,
+	trendingService TrendingServiceInterface) *MovieService {
 	return &MovieService{
-		movieRepo: movieRepo,
+		movieRepo:       movieRepo,
+		genreRepo:       genreRepo,
+		personRepo:      personRepo,
+		trendingService: trendingService,
 	}
 }
 
-func (s *MovieService) GetMovieByID(ctx context.Context, movieID int) (*mocks.MovieJSON, error) {
+func (s *MovieService) GetMovieByID(ctx context.Context, movieID int) (*models.Movie, error) {
 	logger := log.Ctx(ctx)
 
-	movieJSON, err := s.movieRepo.GetMovieFromRepoByID(ctx, movieID)
+	movie, err := s.movieRepo.GetMovieFromRepoByID(ctx, movieID)
 	if err != nil {
 		logger.Error().Err(err).Msg(err.Error())
 		return nil, err
 	}
 
-	return movieJSON, nil
+	return movie, nil
 }
@@ -0,0 +1,61 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	movieDTO "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/delivery/dto"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
+)
+
+// CalendarPremiereJSON movie premiering in country, country is "world" or ISO 3166-1 alpha-2 code
+type CalendarPremiereJSON struct {
+	Country string                  `json:"country"`
+	Movie   movieDTO.MovieShortJSON `json:"movie"`
+	// InWatchlist whether current user saved movie to watch later, only for logged in user
+	InWatchlist *bool `json:"in_watchlist,omitempty"`
+}
+
+// CalendarDayJSON premieres of one day, date is ISO-8601
+type CalendarDayJSON struct {
+	Date        string                 `json:"date"`
+	DateDisplay string                 `json:"date_display,omitempty"`
+	Premieres   []CalendarPremiereJSON `json:"premieres"`
+}
+
+// CalendarJSON release calendar, days without premieres are skipped
+type CalendarJSON struct {
+	From    string            `json:"from"`
+	To      string            `json:"to"`
+	Country string            `json:"country,omitempty"`
+	Days    []CalendarDayJSON `json:"days"`
+}
+
+func NewCalendarJSON(calendar models.Calendar, locale l10n.Locale) CalendarJSON {
+	res := CalendarJSON{
+		From:    isoDate(calendar.From),
+		To:      isoDate(calendar.To),
+		Country: calendar.Country,
+		Days:    make([]CalendarDayJSON, 0, len(calendar.Days)),
+	}
+	for _, day := range calendar.Days {
+		dayJSON := CalendarDayJSON{
+			Date:        isoDate(day.Date),
+			DateDisplay: l10n.FormatDate(day.Date, locale),
+			Premieres:   make([]CalendarPremiereJSON, 0, len(day.Premieres)),
+		}
+		for _, premiere := range day.Premieres {
+			dayJSON.Premieres = append(dayJSON.Premieres, CalendarPremiereJSON{
+				Country:     premiere.Country,
+				Movie:       movieDTO.NewMovieShortJSON(premiere.Movie, locale),
+				InWatchlist: premiere.InWatchlist,
+			})
+		}
+		res.Days = append(res.Days, dayJSON)
+	}
+	return res
+}
+
+func isoDate(t time.Time) string {
+	return t.Format(l10n.DateLayout)
+}
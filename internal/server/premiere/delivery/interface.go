@@ -0,0 +1,7 @@
+package delivery
+
+import "net/http"
+
+type PremiereHandlerInterface interface {
+	GetCalendar(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,90 @@
+package delivery
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/middleware"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/premiere/delivery/dto"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/jsonutil"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// localeParam query parameter requesting localized dates and genre names
+const localeParam = "locale"
+
+type PremiereServiceInterface interface {
+	GetCalendar(ctx context.Context, req models.PremiereRequest, username string) (*models.Calendar, error)
+}
+
+// PremiereHandler handles requests for release calendar
+type PremiereHandler struct {
+	premiereService PremiereServiceInterface
+}
+
+func NewPremiereHandler(premiereService PremiereServiceInterface) *PremiereHandler {
+	return &PremiereHandler{
+		premiereService: premiereService,
+	}
+}
+
+// parsePremiereRequest reads ISO-8601 dates and country from query string, omitted values are chosen by service
+func parsePremiereRequest(r *http.Request) (models.PremiereRequest, error) {
+	query := r.URL.Query()
+	req := models.PremiereRequest{Country: strings.TrimSpace(query.Get("country"))}
+
+	for _, param := range []struct {
+		name  string
+		value *time.Time
+	}{
+		{"from", &req.From},
+		{"to", &req.To},
+	} {
+		val := query.Get(param.name)
+		if val == "" {
+			continue
+		}
+		date, err := time.Parse(l10n.DateLayout, val)
+		if err != nil {
+			return req, errors.Wrapf(err, "parameter %s", param.name)
+		}
+		*param.value = date
+	}
+
+	return req, nil
+}
+
+// GetCalendar handles GET request for premieres grouped by day, movies in watchlist of logged in user are marked
+func (h *PremiereHandler) GetCalendar(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	req, err := parsePremiereRequest(r)
+	if err != nil {
+		errMsg := errors.Wrap(err, "getCalendar action: bad request")
+		logger.Error().Err(errMsg).Msg(errMsg.Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, err.Error())
+		return
+	}
+
+	calendar, err := h.premiereService.GetCalendar(r.Context(), req, middleware.Username(r.Context()))
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		if errors.Is(err, errs.ErrInvalidPremiereRequest) {
+			jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, err.Error())
+			return
+		}
+		jsonutil.SendError(r.Context(), w, http.StatusInternalServerError, errs.ErrSomethingWentWrong, errs.ErrSomethingWentWrong)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, dto.NewCalendarJSON(*calendar, l10n.ParseLocale(r.URL.Query().Get(localeParam)))); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type MovieRepositoryInterface interface {
+	GetAllMovies(ctx context.Context) ([]models.Movie, error)
+	GetMovieFromRepoByID(ctx context.Context, movieID int) (*models.Movie, error)
+}
+
+type WatchlistServiceInterface interface {
+	WatchlistedMovies(ctx context.Context, username string, movieIDs []int) (map[int]bool, error)
+}
+
+// PremiereService release calendar and "Скоро в кино" by premieres of movies in countries
+type PremiereService struct {
+	movieRepo        MovieRepositoryInterface
+	watchlistService WatchlistServiceInterface
+	settings         models.PremiereSettings
+
+	mu sync.RWMutex
+	// premieres of movies by movie id, it follows catalog changes
+	premieres map[int][]models.Premiere
+}
+
+// moviePremiere premiere of movie found for calendar
+type moviePremiere struct {
+	movieID  int
+	premiere models.Premiere
+}
+
+func NewPremiereService(movieRepo MovieRepositoryInterface, watchlistService WatchlistServiceInterface, settings models.PremiereSettings) *PremiereService {
+	return &PremiereService{
+		movieRepo:        movieRepo,
+		watchlistService: watchlistService,
+		settings:         settings,
+		premieres:        make(map[int][]models.Premiere),
+	}
+}
+
+// Reindex reads premieres of all movies in repository
+func (s *PremiereService) Reindex(ctx context.Context) error {
+	logger := log.Ctx(ctx)
+
+	movies, err := s.movieRepo.GetAllMovies(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return err
+	}
+
+	index := make(map[int][]models.Premiere)
+	for _, movie := range movies {
+		if len(movie.Premieres) > 0 {
+			index[movie.ID] = movie.Premieres
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.premieres = index
+
+	logger.Info().Int("movies", len(index)).Msg("premieres index built")
+	return nil
+}
+
+// OnMovieUpsert keeps premieres of changed movie
+func (s *PremiereService) OnMovieUpsert(ctx context.Context, movie models.Movie) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(movie.Premieres) == 0 {
+		delete(s.premieres, movie.ID)
+		return
+	}
+	s.premieres[movie.ID] = movie.Premieres
+}
+
+// OnMovieDelete removes premieres of deleted movie from calendar
+func (s *PremiereService) OnMovieDelete(ctx context.Context, movieID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.premieres, movieID)
+}
+
+// startOfDay returns midnight of day of t, premiere dates have no time
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// between returns premieres from first to last day inclusive, empty country means all countries
+func (s *PremiereService) between(from, to time.Time, country string) []moviePremiere {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var res []moviePremiere
+	for movieID, premieres := range s.premieres {
+		for _, premiere := range premieres {
+			if country != "" && premiere.Country != country {
+				continue
+			}
+			if !premiere.Date.Before(from) && !premiere.Date.After(to) {
+				res = append(res, moviePremiere{movieID: movieID, premiere: premiere})
+			}
+		}
+	}
+	return res
+}
+
+// GetCalendar returns premieres grouped by day, movies logged in user saved to watchlist are marked.
+// Calendar starts today unless start is requested and lasts default number of days unless end is requested
+func (s *PremiereService) GetCalendar(ctx context.Context, req models.PremiereRequest, username string) (*models.Calendar, error) {
+	return s.calendar(ctx, req, username, time.Now().UTC())
+}
+
+func (s *PremiereService) calendar(ctx context.Context, req models.PremiereRequest, username string, now time.Time) (*models.Calendar, error) {
+	logger := log.Ctx(ctx)
+
+	if req.From.IsZero() {
+		req.From = startOfDay(now)
+	}
+	if req.To.IsZero() {
+		req.To = req.From.AddDate(0, 0, s.settings.DefaultDays-1)
+	}
+	if req.To.Before(req.From) || req.To.After(req.From.AddDate(0, 0, s.settings.MaxDays-1)) {
+		logger.Error().Time("from", req.From).Time("to", req.To).Msg(errs.ErrInvalidPremiereRequest.Error())
+		return nil, errors.Wrapf(errs.ErrInvalidPremiereRequest, "calendar must cover 1-%d days", s.settings.MaxDays)
+	}
+	if req.Country != "" && !models.IsPremiereCountry(req.Country) {
+		logger.Error().Str("country", req.Country).Msg(errs.ErrInvalidPremiereRequest.Error())
+		return nil, errors.Wrapf(errs.ErrInvalidPremiereRequest, "unknown country %q", req.Country)
+	}
+
+	var (
+		premieres []models.CalendarPremiere
+		movieIDs  []int
+	)
+	for _, found := range s.between(req.From, req.To, req.Country) {
+		movie, err := s.movieRepo.GetMovieFromRepoByID(ctx, found.movieID)
+		if errors.Is(err, errs.ErrMovieNotFound) {
+			continue
+		}
+		if err != nil {
+			logger.Error().Err(err).Msg(err.Error())
+			return nil, err
+		}
+		premieres = append(premieres, models.CalendarPremiere{Movie: *movie, Country: found.premiere.Country, Date: found.premiere.Date})
+		movieIDs = append(movieIDs, movie.ID)
+	}
+
+	if username != "" {
+		watchlisted, err := s.watchlistService.WatchlistedMovies(ctx, username, movieIDs)
+		if err != nil {
+			logger.Error().Err(err).Msg(err.Error())
+			return nil, err
+		}
+		for i := range premieres {
+			inWatchlist := watchlisted[premieres[i].Movie.ID]
+			premieres[i].InWatchlist = &inWatchlist
+		}
+	}
+
+	sort.Slice(premieres, func(i, j int) bool {
+		a, b := premieres[i], premieres[j]
+		if !a.Date.Equal(b.Date) {
+			return a.Date.Before(b.Date)
+		}
+		if nameA, nameB := strings.ToLower(a.Movie.Name), strings.ToLower(b.Movie.Name); nameA != nameB {
+			return nameA < nameB
+		}
+		if a.Movie.ID != b.Movie.ID {
+			return a.Movie.ID < b.Movie.ID
+		}
+		return a.Country < b.Country
+	})
+
+	res := &models.Calendar{From: req.From, To: req.To, Country: req.Country}
+	for _, premiere := range premieres {
+		if len(res.Days) == 0 || !res.Days[len(res.Days)-1].Date.Equal(premiere.Date) {
+			res.Days = append(res.Days, models.CalendarDay{Date: premiere.Date})
+		}
+		res.Days[len(res.Days)-1].Premieres = append(res.Days[len(res.Days)-1].Premieres, premiere)
+	}
+
+	return res, nil
+}
+
+// CollectionEntries returns movies of "Скоро в кино" ordered by premiere date. Premiere in configured country
+// is used, world premiere is used for movies without one
+func (s *PremiereService) CollectionEntries(ctx context.Context) ([]models.CollectionEntry, error) {
+	return s.comingSoon(time.Now().UTC()), nil
+}
+
+func (s *PremiereService) comingSoon(now time.Time) []models.CollectionEntry {
+	from := startOfDay(now)
+	to := from.AddDate(0, 0, s.settings.ComingSoonDays)
+
+	var upcoming []moviePremiere
+	s.mu.RLock()
+	for movieID, premieres := range s.premieres {
+		var date, world *time.Time
+		for i := range premieres {
+			switch premieres[i].Country {
+			case s.settings.ComingSoonCountry:
+				date = &premieres[i].Date
+			case models.PremiereWorld:
+				world = &premieres[i].Date
+			}
+		}
+		if date == nil {
+			date = world
+		}
+		if date != nil && !date.Before(from) && !date.After(to) {
+			upcoming = append(upcoming, moviePremiere{movieID: movieID, premiere: models.Premiere{Date: *date}})
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(upcoming, func(i, j int) bool {
+		if !upcoming[i].premiere.Date.Equal(upcoming[j].premiere.Date) {
+			return upcoming[i].premiere.Date.Before(upcoming[j].premiere.Date)
+		}
+		return upcoming[i].movieID < upcoming[j].movieID
+	})
+
+	res := make([]models.CollectionEntry, 0, len(upcoming))
+	for i, movie := range upcoming {
+		date := movie.premiere.Date
+		res = append(res, models.CollectionEntry{Position: i, MovieID: movie.movieID, Premiere: &date})
+	}
+	return res
+}
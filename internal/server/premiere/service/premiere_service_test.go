@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	repoMovie "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/repository"
+	repoUser "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/user/repository"
+	repoWatchlist "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/watchlist/repository"
+	serviceWatchlist "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/watchlist/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	now      = time.Date(2026, time.March, 10, 15, 30, 0, 0, time.UTC)
+	settings = models.PremiereSettings{DefaultDays: 30, MaxDays: 366, ComingSoonDays: 90, ComingSoonCountry: models.PremiereRussia}
+)
+
+func date(month time.Month, day int) time.Time {
+	return time.Date(2026, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func premieres(world, russia time.Time) []models.Premiere {
+	var res []models.Premiere
+	if !world.IsZero() {
+		res = append(res, models.Premiere{Country: models.PremiereWorld, Date: world})
+	}
+	if !russia.IsZero() {
+		res = append(res, models.Premiere{Country: models.PremiereRussia, Date: russia})
+	}
+	models.SortPremieres(res)
+	return res
+}
+
+// newPremiereService returns service over movies with user neo who saved "Дюна 3" to watchlist
+func newPremiereService(t *testing.T, movies *mocks.Movies) (*PremiereService, *repoMovie.MovieRepository) {
+	ctx := context.Background()
+
+	userRepo := repoUser.NewUserRepository()
+	require.NoError(t, userRepo.CreateUser(ctx, &models.User{Username: "neo"}))
+
+	movieRepo := repoMovie.NewMovieRepository(movies)
+	watchlistService := serviceWatchlist.NewWatchlistService(repoWatchlist.NewWatchlistRepository(), movieRepo, userRepo)
+	_, err := watchlistService.AddMovies(ctx, "neo", []int{1})
+	require.NoError(t, err)
+
+	s := NewPremiereService(movieRepo, watchlistService, settings)
+	require.NoError(t, s.Reindex(ctx))
+	movieRepo.Subscribe(s)
+
+	return s, movieRepo
+}
+
+func upcomingMovies() mocks.Movies {
+	return mocks.Movies{
+		1: {ID: 1, Name: "Дюна 3", Premieres: premieres(date(time.March, 18), date(time.March, 19))},
+		2: {ID: 2, Name: "Аватар 4", Premieres: premieres(date(time.March, 18), time.Time{})},
+		3: {ID: 3, Name: "Мастер и Маргарита 2", Premieres: premieres(time.Time{}, date(time.May, 1))},
+		4: {ID: 4, Name: "Бэтмен 2", Premieres: premieres(date(time.February, 1), date(time.March, 10))},
+		5: {ID: 5, Name: "Матрица", Premieres: premieres(time.Date(1999, time.March, 31, 0, 0, 0, 0, time.UTC), time.Time{})},
+		6: {ID: 6, Name: "Без премьеры"},
+	}
+}
+
+type calendarDay struct {
+	date     time.Time
+	movieIDs []int
+}
+
+func calendarDays(calendar *models.Calendar) []calendarDay {
+	var res []calendarDay
+	for _, day := range calendar.Days {
+		ids := make([]int, 0, len(day.Premieres))
+		for _, premiere := range day.Premieres {
+			ids = append(ids, premiere.Movie.ID)
+		}
+		res = append(res, calendarDay{date: day.Date, movieIDs: ids})
+	}
+	return res
+}
+
+func TestPremiereService_GetCalendar(t *testing.T) {
+	ctx := context.Background()
+	movies := upcomingMovies()
+	s, _ := newPremiereService(t, &movies)
+
+	// calendar starts today and lasts 30 days by default, premieres of one day are ordered by name
+	calendar, err := s.calendar(ctx, models.PremiereRequest{}, "", now)
+	require.NoError(t, err)
+	assert.Equal(t, date(time.March, 10), calendar.From)
+	assert.Equal(t, date(time.April, 8), calendar.To)
+	assert.Equal(t, []calendarDay{
+		{date(time.March, 10), []int{4}},
+		{date(time.March, 18), []int{2, 1}},
+		{date(time.March, 19), []int{1}},
+	}, calendarDays(calendar))
+	assert.Nil(t, calendar.Days[0].Premieres[0].InWatchlist, "anonymous user has no watchlist")
+
+	calendar, err = s.calendar(ctx, models.PremiereRequest{From: date(time.March, 1), To: date(time.May, 31), Country: models.PremiereRussia}, "neo", now)
+	require.NoError(t, err)
+	assert.Equal(t, []calendarDay{
+		{date(time.March, 10), []int{4}},
+		{date(time.March, 19), []int{1}},
+		{date(time.May, 1), []int{3}},
+	}, calendarDays(calendar))
+	require.NotNil(t, calendar.Days[0].Premieres[0].InWatchlist)
+	assert.False(t, *calendar.Days[0].Premieres[0].InWatchlist)
+	require.NotNil(t, calendar.Days[1].Premieres[0].InWatchlist)
+	assert.True(t, *calendar.Days[1].Premieres[0].InWatchlist)
+
+	for _, req := range []models.PremiereRequest{
+		{From: date(time.March, 10), To: date(time.March, 9)},
+		{From: date(time.January, 1), To: date(time.January, 1).AddDate(0, 0, settings.MaxDays)},
+		{Country: "Россия"},
+	} {
+		_, err = s.calendar(ctx, req, "", now)
+		assert.ErrorIs(t, err, errs.ErrInvalidPremiereRequest, req)
+	}
+}
+
+func TestPremiereService_CatalogChanges(t *testing.T) {
+	ctx := context.Background()
+	movies := upcomingMovies()
+	s, movieRepo := newPremiereService(t, &movies)
+
+	_, err := movieRepo.UpdateMovie(ctx, 2, func(movie *models.Movie) error {
+		movie.Premieres = premieres(date(time.March, 25), time.Time{})
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, movieRepo.DeleteMovie(ctx, 4))
+
+	calendar, err := s.calendar(ctx, models.PremiereRequest{}, "", now)
+	require.NoError(t, err)
+	assert.Equal(t, []calendarDay{
+		{date(time.March, 18), []int{1}},
+		{date(time.March, 19), []int{1}},
+		{date(time.March, 25), []int{2}},
+	}, calendarDays(calendar))
+}
+
+func TestPremiereService_ComingSoon(t *testing.T) {
+	movies := upcomingMovies()
+	s, _ := newPremiereService(t, &movies)
+
+	// russian premiere is preferred, world premiere is used when it is unknown
+	entries := s.comingSoon(now)
+	ids := make([]int, 0, len(entries))
+	for i, entry := range entries {
+		assert.Equal(t, i, entry.Position)
+		ids = append(ids, entry.MovieID)
+	}
+	assert.Equal(t, []int{4, 2, 1, 3}, ids)
+	require.NotNil(t, entries[2].Premiere)
+	assert.Equal(t, date(time.March, 19), *entries[2].Premiere)
+
+	later := s.comingSoon(date(time.April, 1))
+	require.Len(t, later, 1)
+	assert.Equal(t, 3, later[0].MovieID)
+	assert.Empty(t, s.comingSoon(date(time.August, 1)))
+}
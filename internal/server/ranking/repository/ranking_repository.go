@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+)
+
+// RankingRepository keeps history of computed rankings ordered by time they were computed at
+type RankingRepository struct {
+	mu sync.RWMutex
+	db []models.Ranking
+}
+
+func NewRankingRepository() *RankingRepository {
+	return &RankingRepository{}
+}
+
+// AddRanking adds ranking to history
+func (r *RankingRepository) AddRanking(ctx context.Context, ranking models.Ranking) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := sort.Search(len(r.db), func(i int) bool {
+		return r.db[i].ComputedAt.After(ranking.ComputedAt)
+	})
+	r.db = append(r.db, models.Ranking{})
+	copy(r.db[i+1:], r.db[i:])
+	r.db[i] = ranking
+
+	return nil
+}
+
+// GetLatest returns the last computed ranking
+func (r *RankingRepository) GetLatest(ctx context.Context) (*models.Ranking, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.db) == 0 {
+		return nil, errs.ErrRankingNotFound
+	}
+	res := r.db[len(r.db)-1]
+	return &res, nil
+}
+
+// GetRankingAt returns ranking which was the latest one at given time
+func (r *RankingRepository) GetRankingAt(ctx context.Context, at time.Time) (*models.Ranking, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	i := sort.Search(len(r.db), func(i int) bool {
+		return r.db[i].ComputedAt.After(at)
+	})
+	if i == 0 {
+		return nil, errs.ErrRankingNotFound
+	}
+	res := r.db[i-1]
+	return &res, nil
+}
+
+// Prune forgets rankings replaced before given time, ranking which was the latest one at that time is kept
+func (r *RankingRepository) Prune(ctx context.Context, before time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := sort.Search(len(r.db), func(i int) bool {
+		return r.db[i].ComputedAt.After(before)
+	})
+	if i > 1 {
+		r.db = append([]models.Ranking(nil), r.db[i-1:]...)
+	}
+
+	return nil
+}
+
+// Snapshot serializes history of rankings
+func (r *RankingRepository) Snapshot(ctx context.Context) ([]byte, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	records := make([]models.Ranking, 0, len(r.db))
+	records = append(records, r.db...)
+
+	return json.Marshal(records)
+}
+
+// Restore replaces history of rankings with one from snapshot
+func (r *RankingRepository) Restore(ctx context.Context, data []byte) error {
+	var records []models.Ranking
+	if err := json.Unmarshal(data, &records); err != nil {
+		return err
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].ComputedAt.Before(records[j].ComputedAt)
+	})
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.db = records
+
+	return nil
+}
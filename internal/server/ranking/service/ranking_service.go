@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type MovieRepositoryInterface interface {
+	GetAllMovies(ctx context.Context) ([]models.Movie, error)
+}
+
+type RatingRepositoryInterface interface {
+	GetAllRatings(ctx context.Context) ([]models.Rating, error)
+}
+
+type RankingRepositoryInterface interface {
+	AddRanking(ctx context.Context, ranking models.Ranking) error
+	GetLatest(ctx context.Context) (*models.Ranking, error)
+	GetRankingAt(ctx context.Context, at time.Time) (*models.Ranking, error)
+	Prune(ctx context.Context, before time.Time) error
+}
+
+// RankingService computes Top-250 by weighted rating of user ratings and keeps history of positions
+type RankingService struct {
+	movieRepo   MovieRepositoryInterface
+	ratingRepo  RatingRepositoryInterface
+	rankingRepo RankingRepositoryInterface
+	settings    models.RankingSettings
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewRankingService(movieRepo MovieRepositoryInterface, ratingRepo RatingRepositoryInterface, rankingRepo RankingRepositoryInterface,
+	settings models.RankingSettings) *RankingService {
+	return &RankingService{
+		movieRepo:   movieRepo,
+		ratingRepo:  ratingRepo,
+		rankingRepo: rankingRepo,
+		settings:    settings,
+	}
+}
+
+// rank orders movies of catalog with enough votes by weighted rating, ties are broken by votes and id
+func (s *RankingService) rank(ctx context.Context, now time.Time) (models.Ranking, error) {
+	res := models.Ranking{ComputedAt: now}
+
+	movies, err := s.movieRepo.GetAllMovies(ctx)
+	if err != nil {
+		return res, err
+	}
+	inCatalog := make(map[int]bool, len(movies))
+	for _, movie := range movies {
+		inCatalog[movie.ID] = true
+	}
+
+	ratings, err := s.ratingRepo.GetAllRatings(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	type votes struct {
+		count int
+		sum   int
+	}
+	byMovie := make(map[int]*votes)
+	total, sum := 0, 0
+	for _, rating := range ratings {
+		if !inCatalog[rating.MovieID] {
+			continue
+		}
+		if byMovie[rating.MovieID] == nil {
+			byMovie[rating.MovieID] = &votes{}
+		}
+		byMovie[rating.MovieID].count++
+		byMovie[rating.MovieID].sum += rating.Score
+		total++
+		sum += rating.Score
+	}
+	if total == 0 {
+		return res, nil
+	}
+	mean := float64(sum) / float64(total)
+
+	for movieID, v := range byMovie {
+		if v.count < max(s.settings.MinVotes, 1) {
+			continue
+		}
+		res.Movies = append(res.Movies, models.RankedMovie{
+			MovieID: movieID,
+			Score:   models.WeightedRating(float64(v.sum)/float64(v.count), v.count, mean, s.settings.MinVotes),
+			Votes:   v.count,
+		})
+	}
+	sort.Slice(res.Movies, func(i, j int) bool {
+		a, b := res.Movies[i], res.Movies[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Votes != b.Votes {
+			return a.Votes > b.Votes
+		}
+		return a.MovieID < b.MovieID
+	})
+
+	res.Movies = res.Movies[:min(len(res.Movies), s.settings.Size)]
+	for i := range res.Movies {
+		res.Movies[i].Position = i
+	}
+	return res, nil
+}
+
+// recompute ranks movies at given time, ranking is added to history only when positions change
+func (s *RankingService) recompute(ctx context.Context, now time.Time) error {
+	ranking, err := s.rank(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	latest, err := s.rankingRepo.GetLatest(ctx)
+	if err != nil && !errors.Is(err, errs.ErrRankingNotFound) {
+		return err
+	}
+	if latest == nil || !latest.SamePositions(ranking) {
+		if err = s.rankingRepo.AddRanking(ctx, ranking); err != nil {
+			return err
+		}
+	}
+
+	if s.settings.HistoryRetention > 0 {
+		return s.rankingRepo.Prune(ctx, now.Add(-s.settings.HistoryRetention))
+	}
+	return nil
+}
+
+// Recompute ranks movies by weighted rating of user ratings now
+func (s *RankingService) Recompute(ctx context.Context) error {
+	logger := log.Ctx(ctx)
+
+	if err := s.recompute(ctx, time.Now().UTC()); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return err
+	}
+
+	logger.Info().Msg("top ranking recomputed")
+	return nil
+}
+
+// Start recomputes ranking now and then periodically until Stop is called.
+// Zero interval means ranking is computed once
+func (s *RankingService) Start(ctx context.Context) {
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+
+		if err := s.Recompute(ctx); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg(err.Error())
+		}
+		if s.settings.RecomputeInterval <= 0 {
+			return
+		}
+
+		ticker := time.NewTicker(s.settings.RecomputeInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := s.Recompute(ctx); err != nil {
+					log.Ctx(ctx).Error().Err(err).Msg(err.Error())
+				}
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops periodic recomputation and waits for running one to finish
+func (s *RankingService) Stop() {
+	if s.stop != nil {
+		close(s.stop)
+		<-s.done
+		s.stop = nil
+	}
+}
+
+// entries returns movies of the latest ranking with their positions in ranking which was the latest movement period before now
+func (s *RankingService) entries(ctx context.Context, now time.Time) ([]models.CollectionEntry, error) {
+	latest, err := s.rankingRepo.GetLatest(ctx)
+	if errors.Is(err, errs.ErrRankingNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var previous map[int]int
+	earlier, err := s.rankingRepo.GetRankingAt(ctx, now.Add(-s.settings.MovementPeriod))
+	switch {
+	case err == nil:
+		previous = earlier.Positions()
+	case !errors.Is(err, errs.ErrRankingNotFound):
+		return nil, err
+	}
+
+	res := make([]models.CollectionEntry, 0, len(latest.Movies))
+	for _, movie := range latest.Movies {
+		entry := models.CollectionEntry{Position: movie.Position, MovieID: movie.MovieID}
+		if position, ok := previous[movie.MovieID]; ok {
+			entry.PreviousPosition = &position
+		}
+		res = append(res, entry)
+	}
+	return res, nil
+}
+
+// CollectionEntries returns movies of Top-250 by position, previous positions are set when there is earlier ranking
+func (s *RankingService) CollectionEntries(ctx context.Context) ([]models.CollectionEntry, error) {
+	logger := log.Ctx(ctx)
+
+	res, err := s.entries(ctx, time.Now().UTC())
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+	return res, nil
+}
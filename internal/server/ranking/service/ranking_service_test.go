@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	repoMovie "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/repository"
+	repoRanking "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/ranking/repository"
+	repoRating "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/rating/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2025, time.April, 10, 12, 0, 0, 0, time.UTC)
+
+func newRankingService(t *testing.T, settings models.RankingSettings) (*RankingService, *repoRating.RatingRepository, *repoRanking.RankingRepository) {
+	movies := mocks.Movies{
+		1: {ID: 1, Name: "Бойцовский клуб"},
+		2: {ID: 2, Name: "Амели"},
+		3: {ID: 3, Name: "Семь"},
+		4: {ID: 4, Name: "Джокер"},
+	}
+	ratingRepo := repoRating.NewRatingRepository()
+	rankingRepo := repoRanking.NewRankingRepository()
+	return NewRankingService(repoMovie.NewMovieRepository(&movies), ratingRepo, rankingRepo, settings), ratingRepo, rankingRepo
+}
+
+// rate sets scores of movie by users with ids from first
+func rate(t *testing.T, ratingRepo *repoRating.RatingRepository, movieID int, first int, scores ...int) {
+	for i, score := range scores {
+		require.NoError(t, ratingRepo.SetRating(context.Background(), models.Rating{UserID: first + i, MovieID: movieID, Score: score}))
+	}
+}
+
+func rankedIDs(ranking models.Ranking) []int {
+	ids := make([]int, 0, len(ranking.Movies))
+	for _, movie := range ranking.Movies {
+		ids = append(ids, movie.MovieID)
+	}
+	return ids
+}
+
+func TestWeightedRating(t *testing.T) {
+	assert.InDelta(t, 7.5, models.WeightedRating(10, 2, 5, 2), 1e-9)
+	assert.InDelta(t, 9.5, models.WeightedRating(10, 18, 5, 2), 1e-9)
+	assert.Zero(t, models.WeightedRating(0, 0, 0, 0))
+}
+
+func TestRankingService_Rank(t *testing.T) {
+	ctx := context.Background()
+	s, ratingRepo, _ := newRankingService(t, models.RankingSettings{Size: 2, MinVotes: 3})
+
+	// two perfect scores are below threshold, many good ones beat few great ones
+	rate(t, ratingRepo, 1, 1, 10, 10)
+	rate(t, ratingRepo, 2, 1, 10, 10, 10, 9, 9, 9)
+	rate(t, ratingRepo, 3, 1, 10, 10, 9)
+	rate(t, ratingRepo, 4, 1, 3, 4, 2)
+	// ratings of movies missing in catalog are not counted
+	rate(t, ratingRepo, 42, 1, 1, 1, 1)
+
+	ranking, err := s.rank(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 3}, rankedIDs(ranking))
+	assert.Equal(t, 1, ranking.Movies[1].Position)
+	assert.Equal(t, 6, ranking.Movies[0].Votes)
+
+	// score of movie 3 with 3 votes is pulled half way to mean of all 14 ratings
+	assert.InDelta(t, (29.0/3+115.0/14)/2, ranking.Movies[1].Score, 1e-9)
+}
+
+func TestRankingService_Movement(t *testing.T) {
+	ctx := context.Background()
+	s, ratingRepo, rankingRepo := newRankingService(t, models.RankingSettings{Size: 10, MinVotes: 2, MovementPeriod: 24 * time.Hour, HistoryRetention: 72 * time.Hour})
+
+	rate(t, ratingRepo, 1, 1, 9, 9)
+	rate(t, ratingRepo, 2, 1, 8, 8)
+	require.NoError(t, s.recompute(ctx, now))
+
+	// there is no ranking a day old yet
+	entries, err := s.entries(ctx, now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Nil(t, entries[0].PreviousPosition)
+
+	rate(t, ratingRepo, 2, 3, 10, 10, 10)
+	rate(t, ratingRepo, 3, 1, 7, 7)
+	require.NoError(t, s.recompute(ctx, now.Add(30*time.Hour)))
+	// unchanged ranking is not added to history
+	require.NoError(t, s.recompute(ctx, now.Add(31*time.Hour)))
+
+	entries, err = s.entries(ctx, now.Add(32*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, 2, entries[0].MovieID)
+	require.NotNil(t, entries[0].PreviousPosition)
+	assert.Equal(t, 1, *entries[0].PreviousPosition)
+	assert.Equal(t, 1, entries[1].MovieID)
+	assert.Equal(t, 0, *entries[1].PreviousPosition)
+	assert.Nil(t, entries[2].PreviousPosition, "new in ranking")
+
+	// a day after change ranking has not moved
+	entries, err = s.entries(ctx, now.Add(55*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0, *entries[0].PreviousPosition)
+
+	// history keeps ranking which was current at retention border
+	require.NoError(t, s.recompute(ctx, now.Add(200*time.Hour)))
+	_, err = rankingRepo.GetRankingAt(ctx, now.Add(time.Hour))
+	require.Error(t, err)
+	ranking, err := rankingRepo.GetRankingAt(ctx, now.Add(129*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 1, 3}, rankedIDs(*ranking))
+}
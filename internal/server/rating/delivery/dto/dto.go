@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+)
+
+// RatingRequest score set by user
+type RatingRequest struct {
+	Score int `json:"score"`
+}
+
+type RatingJSON struct {
+	MovieID   int       `json:"movie_id"`
+	Score     int       `json:"score"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewRatingJSON(rating models.Rating) RatingJSON {
+	return RatingJSON{
+		MovieID:   rating.MovieID,
+		Score:     rating.Score,
+		UpdatedAt: rating.UpdatedAt,
+	}
+}
+
+// RatingSummaryJSON average user rating of movie with histogram
+type RatingSummaryJSON struct {
+	Votes   int     `json:"votes"`
+	Average float64 `json:"average"`
+	// Scores number of votes by score, scores[i] counts score i+1
+	Scores [10]int `json:"scores"`
+	// UserScore rating of current user, only for logged in user who rated movie
+	UserScore *int `json:"user_score,omitempty"`
+}
+
+func NewRatingSummaryJSON(summary models.RatingSummary, userRating *models.Rating) RatingSummaryJSON {
+	res := RatingSummaryJSON{
+		Votes:   summary.Votes,
+		Average: summary.Average,
+		Scores:  summary.Scores,
+	}
+	if userRating != nil {
+		res.UserScore = &userRating.Score
+	}
+	return res
+}
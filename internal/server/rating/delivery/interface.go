@@ -0,0 +1,9 @@
+package delivery
+
+import "net/http"
+
+type RatingHandlerInterface interface {
+	GetRating(w http.ResponseWriter, r *http.Request)
+	SetRating(w http.ResponseWriter, r *http.Request)
+	DeleteRating(w http.ResponseWriter, r *http.Request)
+}
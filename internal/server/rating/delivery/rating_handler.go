@@ -0,0 +1,153 @@
+package delivery
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/ds"
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/messages"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/middleware"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/rating/delivery/dto"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/validation/review"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/jsonutil"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type RatingServiceInterface interface {
+	SetRating(ctx context.Context, movieID int, username string, score int) (*models.Rating, error)
+	DeleteRating(ctx context.Context, movieID int, username string) error
+	GetUserRating(ctx context.Context, movieID int, username string) (*models.Rating, error)
+	GetMovieRating(ctx context.Context, movieID int) (*models.RatingSummary, error)
+}
+
+type RatingHandler struct {
+	ratingService RatingServiceInterface
+}
+
+func NewRatingHandler(ratingService RatingServiceInterface) *RatingHandler {
+	return &RatingHandler{
+		ratingService: ratingService,
+	}
+}
+
+// getMovieID parses movie id from path, error response is sent if it is invalid
+func getMovieID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	logger := log.Ctx(r.Context())
+
+	movieID, err := strconv.Atoi(mux.Vars(r)["movie_id"])
+	if err != nil {
+		errMsg := errors.Wrap(err, "rating action: bad movie_id")
+		logger.Error().Err(errMsg).Msg(errMsg.Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, errs.ErrBadPayload)
+		return 0, false
+	}
+
+	return movieID, true
+}
+
+func sendRatingError(ctx context.Context, w http.ResponseWriter, err error) {
+	if errors.Is(err, errs.ErrMovieNotFound) || errors.Is(err, errs.ErrRatingNotFound) {
+		jsonutil.SendError(ctx, w, http.StatusNotFound, errs.ErrNotFoundShort, err.Error())
+		return
+	}
+	jsonutil.SendError(ctx, w, http.StatusInternalServerError, errs.ErrSomethingWentWrong, errs.ErrSomethingWentWrong)
+}
+
+// GetRating returns average rating of movie, own score is added for logged in user
+func (h *RatingHandler) GetRating(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	movieID, ok := getMovieID(w, r)
+	if !ok {
+		return
+	}
+
+	summary, err := h.ratingService.GetMovieRating(r.Context(), movieID)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendRatingError(r.Context(), w, err)
+		return
+	}
+
+	// anonymous users get rating without own score
+	var userRating *models.Rating
+	if username := middleware.Username(r.Context()); username != "" {
+		userRating, err = h.ratingService.GetUserRating(r.Context(), movieID, username)
+		if err != nil && !errors.Is(err, errs.ErrRatingNotFound) {
+			logger.Warn().Err(err).Msg("getRating action: failed to get user rating")
+		}
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, dto.NewRatingSummaryJSON(*summary, userRating)); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
+
+func (h *RatingHandler) SetRating(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	username, ok := middleware.RequireUsername(w, r)
+	if !ok {
+		return
+	}
+	movieID, ok := getMovieID(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.RatingRequest
+	if err := jsonutil.ReadJSON(r, &req); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrParseJSON)).Msg(errors.Wrap(err, errs.ErrParseJSON).Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errors.Wrap(err, errs.ErrParseJSONShort).Error(), errs.ErrBadPayload)
+		return
+	}
+	if err := review.IsValidScore(req.Score); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, err.Error())
+		return
+	}
+
+	logger.Info().Msgf("rating movie %d by %s", movieID, username)
+	rating, err := h.ratingService.SetRating(r.Context(), movieID, username, req.Score)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendRatingError(r.Context(), w, err)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, dto.NewRatingJSON(*rating)); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
+
+func (h *RatingHandler) DeleteRating(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	username, ok := middleware.RequireUsername(w, r)
+	if !ok {
+		return
+	}
+	movieID, ok := getMovieID(w, r)
+	if !ok {
+		return
+	}
+
+	logger.Info().Msgf("deleting rating of movie %d by %s", movieID, username)
+	if err := h.ratingService.DeleteRating(r.Context(), movieID, username); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendRatingError(r.Context(), w, err)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, ds.Response{Message: messages.SuccessfulRatingDelete}); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
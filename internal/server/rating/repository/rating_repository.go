@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+)
+
+// RatingRepository keeps ratings by movie id and user id
+type RatingRepository struct {
+	mu sync.RWMutex
+	db map[int]map[int]models.Rating
+}
+
+func NewRatingRepository() *RatingRepository {
+	return &RatingRepository{
+		db: make(map[int]map[int]models.Rating),
+	}
+}
+
+// SetRating creates rating or replaces previous rating of the same user
+func (r *RatingRepository) SetRating(ctx context.Context, rating models.Rating) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.db[rating.MovieID] == nil {
+		r.db[rating.MovieID] = make(map[int]models.Rating)
+	}
+	r.db[rating.MovieID][rating.UserID] = rating
+
+	return nil
+}
+
+func (r *RatingRepository) GetRating(ctx context.Context, movieID int, userID int) (*models.Rating, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rating, ok := r.db[movieID][userID]
+	if !ok {
+		return nil, errs.ErrRatingNotFound
+	}
+
+	return &rating, nil
+}
+
+func (r *RatingRepository) DeleteRating(ctx context.Context, movieID int, userID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.db[movieID][userID]; !ok {
+		return errs.ErrRatingNotFound
+	}
+	delete(r.db[movieID], userID)
+	if len(r.db[movieID]) == 0 {
+		delete(r.db, movieID)
+	}
+
+	return nil
+}
+
+// GetMovieRatings returns all ratings of movie ordered by user id
+func (r *RatingRepository) GetMovieRatings(ctx context.Context, movieID int) ([]models.Rating, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	res := make([]models.Rating, 0, len(r.db[movieID]))
+	for _, rating := range r.db[movieID] {
+		res = append(res, rating)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].UserID < res[j].UserID
+	})
+
+	return res, nil
+}
+
+// GetAllRatings returns ratings of all users ordered by user id and movie id
+func (r *RatingRepository) GetAllRatings(ctx context.Context) ([]models.Rating, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var res []models.Rating
+	for _, ratings := range r.db {
+		for _, rating := range ratings {
+			res = append(res, rating)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].UserID != res[j].UserID {
+			return res[i].UserID < res[j].UserID
+		}
+		return res[i].MovieID < res[j].MovieID
+	})
+
+	return res, nil
+}
+
+// GetUserRatings returns all ratings of user ordered by movie id
+func (r *RatingRepository) GetUserRatings(ctx context.Context, userID int) ([]models.Rating, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var res []models.Rating
+	for _, ratings := range r.db {
+		if rating, ok := ratings[userID]; ok {
+			res = append(res, rating)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].MovieID < res[j].MovieID
+	})
+
+	return res, nil
+}
+
+// Snapshot serializes all ratings
+func (r *RatingRepository) Snapshot(ctx context.Context) ([]byte, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	records := make([]models.Rating, 0)
+	for _, ratings := range r.db {
+		for _, rating := range ratings {
+			records = append(records, rating)
+		}
+	}
+
+	return json.Marshal(records)
+}
+
+// Restore replaces all ratings with ones from snapshot
+func (r *RatingRepository) Restore(ctx context.Context, data []byte) error {
+	var records []models.Rating
+	if err := json.Unmarshal(data, &records); err != nil {
+		return err
+	}
+
+	db := make(map[int]map[int]models.Rating)
+	for _, rating := range records {
+		if db[rating.MovieID] == nil {
+			db[rating.MovieID] = make(map[int]models.Rating)
+		}
+		db[rating.MovieID][rating.UserID] = rating
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.db = db
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/rs/zerolog/log"
+)
+
+type RatingRepositoryInterface interface {
+	SetRating(ctx context.Context, rating models.Rating) error
+	GetRating(ctx context.Context, movieID int, userID int) (*models.Rating, error)
+	DeleteRating(ctx context.Context, movieID int, userID int) error
+	GetMovieRatings(ctx context.Context, movieID int) ([]models.Rating, error)
+}
+
+type MovieRepositoryInterface interface {
+	GetMovieFromRepoByID(ctx context.Context, movieID int) (*models.Movie, error)
+}
+
+type UserRepositoryInterface interface {
+	GetUser(ctx context.Context, login string) (*models.User, error)
+}
+
+// RatingService manages quick star ratings, one rating per user per movie
+type RatingService struct {
+	ratingRepo RatingRepositoryInterface
+	movieRepo  MovieRepositoryInterface
+	userRepo   UserRepositoryInterface
+}
+
+func NewRatingService(ratingRepo RatingRepositoryInterface, movieRepo MovieRepositoryInterface, userRepo UserRepositoryInterface) *RatingService {
+	return &RatingService{
+		ratingRepo: ratingRepo,
+		movieRepo:  movieRepo,
+		userRepo:   userRepo,
+	}
+}
+
+// SetRating rates movie by user, previous rating of user is replaced
+func (s *RatingService) SetRating(ctx context.Context, movieID int, username string, score int) (*models.Rating, error) {
+	logger := log.Ctx(ctx)
+
+	if _, err := s.movieRepo.GetMovieFromRepoByID(ctx, movieID); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetUser(ctx, username)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	rating := models.Rating{
+		UserID:    user.ID,
+		MovieID:   movieID,
+		Score:     score,
+		UpdatedAt: time.Now().UTC(),
+	}
+	if err = s.ratingRepo.SetRating(ctx, rating); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return &rating, nil
+}
+
+func (s *RatingService) DeleteRating(ctx context.Context, movieID int, username string) error {
+	logger := log.Ctx(ctx)
+
+	user, err := s.userRepo.GetUser(ctx, username)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return err
+	}
+
+	if err = s.ratingRepo.DeleteRating(ctx, movieID, user.ID); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// GetUserRating returns rating of movie by user, errs.ErrRatingNotFound if user has not rated it
+func (s *RatingService) GetUserRating(ctx context.Context, movieID int, username string) (*models.Rating, error) {
+	user, err := s.userRepo.GetUser(ctx, username)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return s.ratingRepo.GetRating(ctx, movieID, user.ID)
+}
+
+// GetMovieRating aggregates all user ratings of movie
+func (s *RatingService) GetMovieRating(ctx context.Context, movieID int) (*models.RatingSummary, error) {
+	logger := log.Ctx(ctx)
+
+	if _, err := s.movieRepo.GetMovieFromRepoByID(ctx, movieID); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	ratings, err := s.ratingRepo.GetMovieRatings(ctx, movieID)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	var res models.RatingSummary
+	total := 0
+	for _, rating := range ratings {
+		if rating.Score >= 1 && rating.Score <= len(res.Scores) {
+			res.Scores[rating.Score-1]++
+		}
+		total += rating.Score
+	}
+
+	res.Votes = len(ratings)
+	if res.Votes > 0 {
+		res.Average = math.Round(float64(total)*10/float64(res.Votes)) / 10
+	}
+	return &res, nil
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	repoMovie "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/repository"
+	repoRating "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/rating/repository"
+	repoUser "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/user/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRatingService(t *testing.T) {
+	ctx := context.Background()
+
+	movies := mocks.Movies{1: {ID: 1, Name: "Матрица"}}
+	userRepo := repoUser.NewUserRepository()
+	for _, login := range []string{"neo", "trinity", "morpheus"} {
+		require.NoError(t, userRepo.CreateUser(ctx, &models.User{Username: login}))
+	}
+	ratingRepo := repoRating.NewRatingRepository()
+	s := NewRatingService(ratingRepo, repoMovie.NewMovieRepository(&movies), userRepo)
+
+	summary, err := s.GetMovieRating(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, models.RatingSummary{}, *summary)
+
+	_, err = s.SetRating(ctx, 1, "neo", 10)
+	require.NoError(t, err)
+	_, err = s.SetRating(ctx, 1, "trinity", 7)
+	require.NoError(t, err)
+	_, err = s.SetRating(ctx, 1, "morpheus", 3)
+	require.NoError(t, err)
+
+	// rating is changed, not added
+	rating, err := s.SetRating(ctx, 1, "morpheus", 8)
+	require.NoError(t, err)
+	assert.Equal(t, 8, rating.Score)
+
+	summary, err = s.GetMovieRating(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 3, summary.Votes)
+	assert.Equal(t, 8.3, summary.Average)
+	assert.Equal(t, [10]int{0, 0, 0, 0, 0, 0, 1, 1, 0, 1}, summary.Scores)
+
+	own, err := s.GetUserRating(ctx, 1, "trinity")
+	require.NoError(t, err)
+	assert.Equal(t, 7, own.Score)
+
+	require.NoError(t, s.DeleteRating(ctx, 1, "trinity"))
+	_, err = s.GetUserRating(ctx, 1, "trinity")
+	assert.ErrorIs(t, err, errs.ErrRatingNotFound)
+	assert.ErrorIs(t, s.DeleteRating(ctx, 1, "trinity"), errs.ErrRatingNotFound)
+
+	_, err = s.SetRating(ctx, 2, "neo", 5)
+	assert.ErrorIs(t, err, errs.ErrMovieNotFound)
+	_, err = s.GetMovieRating(ctx, 2)
+	assert.ErrorIs(t, err, errs.ErrMovieNotFound)
+
+	// ratings survive backup
+	data, err := ratingRepo.Snapshot(ctx)
+	require.NoError(t, err)
+	restored := repoRating.NewRatingRepository()
+	require.NoError(t, restored.Restore(ctx, data))
+	ratings, err := restored.GetMovieRatings(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, ratings, 2)
+}
@@ -0,0 +1,70 @@
+package dto
+
+import (
+	"fmt"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	movieDTO "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/delivery/dto"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
+)
+
+// SimilarMoviesJSON movies similar to movie, the most similar first
+type SimilarMoviesJSON struct {
+	MovieID int                       `json:"movie_id"`
+	Movies  []movieDTO.MovieShortJSON `json:"movies"`
+}
+
+func NewSimilarMoviesJSON(movieID int, movies []models.Movie, locale l10n.Locale) SimilarMoviesJSON {
+	res := SimilarMoviesJSON{
+		MovieID: movieID,
+		Movies:  make([]movieDTO.MovieShortJSON, 0, len(movies)),
+	}
+	for _, movie := range movies {
+		res.Movies = append(res.Movies, movieDTO.NewMovieShortJSON(movie, locale))
+	}
+	return res
+}
+
+// RecommendationJSON movie suggested to user with explanation why
+type RecommendationJSON struct {
+	Movie       movieDTO.MovieShortJSON `json:"movie"`
+	Reason      string                  `json:"reason"`
+	Explanation string                  `json:"explanation"`
+	// BecauseMovieID movie user rated highly, set only for rated_highly reason
+	BecauseMovieID int `json:"because_movie_id,omitempty"`
+}
+
+// RecommendationsJSON "Рекомендуем вам" feed, the most suitable first
+type RecommendationsJSON struct {
+	Recommendations []RecommendationJSON `json:"recommendations"`
+}
+
+// explain returns explanation of recommendation in requested locale
+func explain(recommendation models.Recommendation, locale l10n.Locale) string {
+	if recommendation.Reason == models.ReasonRatedHighly && recommendation.Because != nil {
+		because := recommendation.Because
+		enName := because.OriginalName
+		if enName == "" {
+			enName = because.Name
+		}
+		return l10n.Pick(locale, fmt.Sprintf("потому что вы высоко оценили «%s»", because.Name),
+			fmt.Sprintf("because you rated “%s” highly", enName))
+	}
+	return l10n.Pick(locale, "популярно у зрителей", "popular with viewers")
+}
+
+func NewRecommendationsJSON(recommendations []models.Recommendation, locale l10n.Locale) RecommendationsJSON {
+	res := RecommendationsJSON{Recommendations: make([]RecommendationJSON, 0, len(recommendations))}
+	for _, recommendation := range recommendations {
+		item := RecommendationJSON{
+			Movie:       movieDTO.NewMovieShortJSON(recommendation.Movie, locale),
+			Reason:      string(recommendation.Reason),
+			Explanation: explain(recommendation, locale),
+		}
+		if recommendation.Because != nil {
+			item.BecauseMovieID = recommendation.Because.ID
+		}
+		res.Recommendations = append(res.Recommendations, item)
+	}
+	return res
+}
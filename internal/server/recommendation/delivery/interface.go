@@ -0,0 +1,8 @@
+package delivery
+
+import "net/http"
+
+type RecommendationHandlerInterface interface {
+	GetSimilarMovies(w http.ResponseWriter, r *http.Request)
+	GetRecommendations(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,123 @@
+package delivery
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/middleware"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/recommendation/delivery/dto"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/jsonutil"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultLimit = 10
+	maxLimit     = 50
+
+	// localeParam query parameter requesting localized genre names and explanations
+	localeParam = "locale"
+)
+
+type RecommendationServiceInterface interface {
+	GetSimilarMovies(ctx context.Context, movieID int, username string, limit int) ([]models.Movie, error)
+	GetRecommendations(ctx context.Context, username string, limit int) ([]models.Recommendation, error)
+}
+
+// RecommendationHandler handles requests for movies to watch next
+type RecommendationHandler struct {
+	recommendationService RecommendationServiceInterface
+}
+
+func NewRecommendationHandler(recommendationService RecommendationServiceInterface) *RecommendationHandler {
+	return &RecommendationHandler{
+		recommendationService: recommendationService,
+	}
+}
+
+// parseLimit reads number of movies to return from query string
+func parseLimit(r *http.Request) (int, error) {
+	limit := defaultLimit
+	if val := r.URL.Query().Get("limit"); val != "" {
+		var err error
+		if limit, err = strconv.Atoi(val); err != nil {
+			return 0, errors.Wrap(err, "parameter limit")
+		}
+	}
+	if limit <= 0 || limit > maxLimit {
+		return 0, errors.Errorf("limit must be in 1-%d", maxLimit)
+	}
+	return limit, nil
+}
+
+// GetSimilarMovies handles GET request for movies similar to movie, movies rated by logged in user are skipped
+func (h *RecommendationHandler) GetSimilarMovies(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	movieID, err := strconv.Atoi(mux.Vars(r)["movie_id"])
+	if err != nil {
+		errMsg := errors.Wrap(err, "getSimilarMovies action: bad movie_id")
+		logger.Error().Err(errMsg).Msg(errMsg.Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, errs.ErrBadPayload)
+		return
+	}
+
+	limit, err := parseLimit(r)
+	if err != nil {
+		errMsg := errors.Wrap(err, "getSimilarMovies action: bad request")
+		logger.Error().Err(errMsg).Msg(errMsg.Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, err.Error())
+		return
+	}
+
+	movies, err := h.recommendationService.GetSimilarMovies(r.Context(), movieID, middleware.Username(r.Context()), limit)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		if errors.Is(err, errs.ErrMovieNotFound) {
+			jsonutil.SendError(r.Context(), w, http.StatusNotFound, errs.ErrNotFoundShort, err.Error())
+			return
+		}
+		jsonutil.SendError(r.Context(), w, http.StatusInternalServerError, errs.ErrSomethingWentWrong, errs.ErrSomethingWentWrong)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, dto.NewSimilarMoviesJSON(movieID, movies, l10n.ParseLocale(r.URL.Query().Get(localeParam)))); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
+
+// GetRecommendations handles GET request for movies suggested to logged in user by ratings
+func (h *RecommendationHandler) GetRecommendations(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	username, ok := middleware.RequireUsername(w, r)
+	if !ok {
+		return
+	}
+
+	limit, err := parseLimit(r)
+	if err != nil {
+		errMsg := errors.Wrap(err, "getRecommendations action: bad request")
+		logger.Error().Err(errMsg).Msg(errMsg.Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, err.Error())
+		return
+	}
+
+	recommendations, err := h.recommendationService.GetRecommendations(r.Context(), username, limit)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		jsonutil.SendError(r.Context(), w, http.StatusInternalServerError, errs.ErrSomethingWentWrong, errs.ErrSomethingWentWrong)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, dto.NewRecommendationsJSON(recommendations, l10n.ParseLocale(r.URL.Query().Get(localeParam)))); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
@@ -0,0 +1,325 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// maxNeighbours most movies rated alike kept for every movie
+	maxNeighbours = 50
+	// minCommonUsers movies rated by fewer same users are not counted as rated alike
+	minCommonUsers = 2
+	// likedScore ratings from this score are taken as movies user liked
+	likedScore = 7
+)
+
+// Recompute finds for every movie movies rated alike by the same users,
+// it is item-based collaborative filtering with adjusted cosine similarity
+func (s *RecommendationService) Recompute(ctx context.Context) error {
+	logger := log.Ctx(ctx)
+
+	ratings, err := s.ratingRepo.GetAllRatings(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return err
+	}
+
+	// ratings are ordered by user, so ratings of one user go in a row
+	type deviation struct {
+		movieID int
+		value   float64
+	}
+	byUser := make(map[int][]deviation)
+	for from := 0; from < len(ratings); {
+		to := from
+		sum := 0
+		for to < len(ratings) && ratings[to].UserID == ratings[from].UserID {
+			sum += ratings[to].Score
+			to++
+		}
+		mean := float64(sum) / float64(to-from)
+		for _, rating := range ratings[from:to] {
+			byUser[rating.UserID] = append(byUser[rating.UserID], deviation{movieID: rating.MovieID, value: float64(rating.Score) - mean})
+		}
+		from = to
+	}
+
+	type pair struct{ a, b int }
+	dot := make(map[pair]float64)
+	common := make(map[pair]int)
+	norm := make(map[int]float64)
+	for _, deviations := range byUser {
+		for i, a := range deviations {
+			norm[a.movieID] += a.value * a.value
+			for _, b := range deviations[i+1:] {
+				key := pair{a.movieID, b.movieID}
+				dot[key] += a.value * b.value
+				common[key]++
+			}
+		}
+	}
+
+	neighbours := make(map[int][]scoredMovie)
+	for key, product := range dot {
+		if common[key] < minCommonUsers || product <= 0 {
+			continue
+		}
+		score := product / math.Sqrt(norm[key.a]*norm[key.b])
+		neighbours[key.a] = append(neighbours[key.a], scoredMovie{id: key.b, score: score})
+		neighbours[key.b] = append(neighbours[key.b], scoredMovie{id: key.a, score: score})
+	}
+	for movieID, movies := range neighbours {
+		sortScored(movies)
+		neighbours[movieID] = movies[:min(len(movies), maxNeighbours)]
+	}
+
+	s.mu.Lock()
+	s.neighbours = neighbours
+	s.mu.Unlock()
+
+	logger.Info().Int("ratings", len(ratings)).Int("movies", len(neighbours)).Msg("recommendations recomputed")
+	return nil
+}
+
+// Start recomputes recommendations now and then periodically until Stop is called.
+// Zero interval means recommendations are computed once
+func (s *RecommendationService) Start(ctx context.Context) {
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+
+		if err := s.Recompute(ctx); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg(err.Error())
+		}
+		if s.settings.RecomputeInterval <= 0 {
+			return
+		}
+
+		ticker := time.NewTicker(s.settings.RecomputeInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := s.Recompute(ctx); err != nil {
+					log.Ctx(ctx).Error().Err(err).Msg(err.Error())
+				}
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops periodic recomputation and waits for running one to finish
+func (s *RecommendationService) Stop() {
+	if s.stop != nil {
+		close(s.stop)
+		<-s.done
+		s.stop = nil
+	}
+}
+
+// candidate movie which may be suggested with movie user rated which it is suggested because of
+type candidate struct {
+	score   float64
+	because int
+	best    float64
+}
+
+func (c *candidate) add(score float64, because int) {
+	c.score += score
+	if score > c.best {
+		c.best, c.because = score, because
+	}
+}
+
+// normalize scales scores of candidates to 0..1
+func normalize(candidates map[int]*candidate) {
+	top := 0.0
+	for _, c := range candidates {
+		top = max(top, c.score)
+	}
+	for _, c := range candidates {
+		if top > 0 {
+			c.score /= top
+		}
+	}
+}
+
+// collaborative scores movies by how much users who rated alike the movies user rated above own average liked them
+func (s *RecommendationService) collaborative(ratings []models.Rating, rated map[int]bool) map[int]*candidate {
+	mean := 0.0
+	for _, rating := range ratings {
+		mean += float64(rating.Score)
+	}
+	mean /= float64(len(ratings))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := make(map[int]*candidate)
+	for _, rating := range ratings {
+		for _, neighbour := range s.neighbours[rating.MovieID] {
+			if rated[neighbour.id] {
+				continue
+			}
+			if res[neighbour.id] == nil {
+				res[neighbour.id] = &candidate{}
+			}
+			res[neighbour.id].add(neighbour.score*(float64(rating.Score)-mean), rating.MovieID)
+		}
+	}
+	normalize(res)
+	return res
+}
+
+// content scores movies by likeness to movies user liked
+func (s *RecommendationService) content(ratings []models.Rating, rated map[int]bool) map[int]*candidate {
+	total := s.settings.Similar.Total()
+
+	res := make(map[int]*candidate)
+	for _, rating := range ratings {
+		if rating.Score < likedScore {
+			continue
+		}
+		for _, similar := range s.similarTo(rating.MovieID) {
+			if rated[similar.id] {
+				continue
+			}
+			if res[similar.id] == nil {
+				res[similar.id] = &candidate{}
+			}
+			res[similar.id].add(similar.score/total, rating.MovieID)
+		}
+	}
+	normalize(res)
+	return res
+}
+
+// GetRecommendations returns at most limit movies user is likely to enjoy, the most likely first.
+// Movies liked by users with the same taste are blended with movies like ones user liked, the fewer ratings
+// user has the more weight has content. The rest is filled with the best rated movies user has not rated
+func (s *RecommendationService) GetRecommendations(ctx context.Context, username string, limit int) ([]models.Recommendation, error) {
+	logger := log.Ctx(ctx)
+
+	user, err := s.userRepo.GetUser(ctx, username)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+	ratings, err := s.ratingRepo.GetUserRatings(ctx, user.ID)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	rated := make(map[int]bool, len(ratings))
+	for _, rating := range ratings {
+		rated[rating.MovieID] = true
+	}
+
+	blended := make(map[int]*candidate)
+	if len(ratings) > 0 {
+		collaborative := s.collaborative(ratings, rated)
+		content := s.content(ratings, rated)
+
+		weight := 1.0
+		if s.settings.ColdStartRatings > 0 {
+			weight = min(1, float64(len(ratings))/float64(s.settings.ColdStartRatings))
+		}
+		if len(collaborative) == 0 {
+			weight = 0
+		}
+
+		for id, c := range collaborative {
+			blended[id] = &candidate{}
+			blended[id].add(weight*c.score, c.because)
+		}
+		for id, c := range content {
+			if blended[id] == nil {
+				blended[id] = &candidate{}
+			}
+			blended[id].add((1-weight)*c.score, c.because)
+		}
+	}
+
+	scored := make([]scoredMovie, 0, len(blended))
+	for id, c := range blended {
+		if c.score > 0 {
+			scored = append(scored, scoredMovie{id: id, score: c.score})
+		}
+	}
+	sortScored(scored)
+
+	res := make([]models.Recommendation, 0, limit)
+	for _, movie := range scored {
+		if len(res) == limit {
+			break
+		}
+
+		recommended, err := s.movieRepo.GetMovieFromRepoByID(ctx, movie.id)
+		if errors.Is(err, errs.ErrMovieNotFound) {
+			// movie was deleted after recommendations were computed
+			continue
+		}
+		if err != nil {
+			logger.Error().Err(err).Msg(err.Error())
+			return nil, err
+		}
+		because, err := s.movieRepo.GetMovieFromRepoByID(ctx, blended[movie.id].because)
+		if errors.Is(err, errs.ErrMovieNotFound) {
+			continue
+		}
+		if err != nil {
+			logger.Error().Err(err).Msg(err.Error())
+			return nil, err
+		}
+
+		rated[movie.id] = true
+		res = append(res, models.Recommendation{Movie: *recommended, Reason: models.ReasonRatedHighly, Because: because})
+	}
+
+	if len(res) < limit {
+		if res, err = s.addPopular(ctx, res, rated, limit); err != nil {
+			logger.Error().Err(err).Msg(err.Error())
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// addPopular fills recommendations up to limit with the best rated movies which are not skipped
+func (s *RecommendationService) addPopular(ctx context.Context, res []models.Recommendation, skip map[int]bool, limit int) ([]models.Recommendation, error) {
+	movies, err := s.movieRepo.GetAllMovies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(movies, func(i, j int) bool {
+		if movies[i].Rating != movies[j].Rating {
+			return movies[i].Rating > movies[j].Rating
+		}
+		return movies[i].ID < movies[j].ID
+	})
+
+	for _, movie := range movies {
+		if len(res) == limit {
+			break
+		}
+		if !skip[movie.ID] {
+			res = append(res, models.Recommendation{Movie: movie, Reason: models.ReasonPopular})
+		}
+	}
+	return res, nil
+}
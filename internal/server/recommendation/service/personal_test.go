@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func recommendedIDs(recommendations []models.Recommendation) ([]int, []models.RecommendationReason) {
+	ids := make([]int, 0, len(recommendations))
+	reasons := make([]models.RecommendationReason, 0, len(recommendations))
+	for _, recommendation := range recommendations {
+		ids = append(ids, recommendation.Movie.ID)
+		reasons = append(reasons, recommendation.Reason)
+	}
+	return ids, reasons
+}
+
+func TestRecommendationService_Recompute(t *testing.T) {
+	ctx := context.Background()
+	movies := similarMovies()
+	s, _, ratingRepo, _ := newRecommendationService(t, &movies)
+
+	// users who liked "Бойцовский клуб" liked "Престиж" and did not like "Амели"
+	for _, rating := range []models.Rating{
+		{UserID: 100, MovieID: 1, Score: 10}, {UserID: 100, MovieID: 3, Score: 9}, {UserID: 100, MovieID: 4, Score: 2},
+		{UserID: 101, MovieID: 1, Score: 9}, {UserID: 101, MovieID: 3, Score: 10}, {UserID: 101, MovieID: 4, Score: 3},
+		{UserID: 102, MovieID: 1, Score: 9}, {UserID: 102, MovieID: 5, Score: 3},
+	} {
+		require.NoError(t, ratingRepo.SetRating(ctx, rating))
+	}
+
+	s.Start(ctx)
+	s.Stop()
+
+	require.Len(t, s.neighbours[1], 1)
+	assert.Equal(t, 3, s.neighbours[1][0].id)
+	assert.Greater(t, s.neighbours[1][0].score, 0.5)
+	// movies rated by one same user only are not counted as rated alike
+	assert.Empty(t, s.neighbours[5])
+}
+
+func TestRecommendationService_GetRecommendations(t *testing.T) {
+	ctx := context.Background()
+	movies := similarMovies()
+	s, _, ratingRepo, userID := newRecommendationService(t, &movies)
+
+	for _, rating := range []models.Rating{
+		{UserID: 100, MovieID: 1, Score: 10}, {UserID: 100, MovieID: 3, Score: 9}, {UserID: 100, MovieID: 4, Score: 2},
+		{UserID: 101, MovieID: 1, Score: 9}, {UserID: 101, MovieID: 3, Score: 10}, {UserID: 101, MovieID: 4, Score: 3},
+		{UserID: userID, MovieID: 1, Score: 10}, {UserID: userID, MovieID: 2, Score: 4},
+	} {
+		require.NoError(t, ratingRepo.SetRating(ctx, rating))
+	}
+	require.NoError(t, s.Recompute(ctx))
+
+	recommendations, err := s.GetRecommendations(ctx, "neo", 3)
+	require.NoError(t, err)
+	ids, reasons := recommendedIDs(recommendations)
+	assert.Equal(t, []int{3, 4, 5}, ids)
+	assert.Equal(t, []models.RecommendationReason{models.ReasonRatedHighly, models.ReasonRatedHighly, models.ReasonPopular}, reasons)
+	require.NotNil(t, recommendations[0].Because)
+	assert.Equal(t, 1, recommendations[0].Because.ID)
+	assert.Nil(t, recommendations[2].Because)
+}
+
+func TestRecommendationService_GetRecommendationsColdStart(t *testing.T) {
+	ctx := context.Background()
+	movies := similarMovies()
+	movies[4] = models.Movie{ID: 4, Name: "Амели", Rating: 8.0}
+	movies[5] = models.Movie{ID: 5, Name: "Сталкер", Rating: 8.1}
+	s, _, ratingRepo, userID := newRecommendationService(t, &movies)
+	require.NoError(t, ratingRepo.SetRating(ctx, models.Rating{UserID: userID, MovieID: 2, Score: 4}))
+	require.NoError(t, s.Recompute(ctx))
+
+	// "Семь" is not liked, so there is nothing to look like and the best rated movies are suggested
+	recommendations, err := s.GetRecommendations(ctx, "neo", 2)
+	require.NoError(t, err)
+	ids, reasons := recommendedIDs(recommendations)
+	assert.Equal(t, []int{5, 4}, ids)
+	assert.Equal(t, []models.RecommendationReason{models.ReasonPopular, models.ReasonPopular}, reasons)
+
+	require.NoError(t, ratingRepo.SetRating(ctx, models.Rating{UserID: userID, MovieID: 1, Score: 9}))
+	recommendations, err = s.GetRecommendations(ctx, "neo", 2)
+	require.NoError(t, err)
+	ids, reasons = recommendedIDs(recommendations)
+	assert.Equal(t, []int{3, 5}, ids)
+	assert.Equal(t, []models.RecommendationReason{models.ReasonRatedHighly, models.ReasonPopular}, reasons)
+}
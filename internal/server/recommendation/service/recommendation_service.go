@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// maxSimilar most similar movies kept in cache for every movie,
+// more than are shown at once, so rated movies can be skipped
+const maxSimilar = 100
+
+type MovieRepositoryInterface interface {
+	GetMovieFromRepoByID(ctx context.Context, movieID int) (*models.Movie, error)
+	GetAllMovies(ctx context.Context) ([]models.Movie, error)
+}
+
+type RatingRepositoryInterface interface {
+	GetUserRatings(ctx context.Context, userID int) ([]models.Rating, error)
+	GetAllRatings(ctx context.Context) ([]models.Rating, error)
+}
+
+type UserRepositoryInterface interface {
+	GetUser(ctx context.Context, login string) (*models.User, error)
+}
+
+// RecommendationService suggests movies to watch next
+type RecommendationService struct {
+	movieRepo  MovieRepositoryInterface
+	ratingRepo RatingRepositoryInterface
+	userRepo   UserRepositoryInterface
+	settings   models.RecommendationSettings
+
+	mu       sync.Mutex
+	features map[int]features
+	// similar the most similar movies by movie id, it is dropped when features of any movie change
+	similar map[int][]scoredMovie
+	// neighbours movies rated alike by the same users, it is recomputed by background job
+	neighbours map[int][]scoredMovie
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// scoredMovie movie with its likeness to another movie
+type scoredMovie struct {
+	id    int
+	score float64
+}
+
+func NewRecommendationService(movieRepo MovieRepositoryInterface, ratingRepo RatingRepositoryInterface, userRepo UserRepositoryInterface,
+	settings models.RecommendationSettings) *RecommendationService {
+	return &RecommendationService{
+		movieRepo:  movieRepo,
+		ratingRepo: ratingRepo,
+		userRepo:   userRepo,
+		settings:   settings,
+		features:   make(map[int]features),
+		similar:    make(map[int][]scoredMovie),
+		neighbours: make(map[int][]scoredMovie),
+	}
+}
+
+// Reindex reads features of all movies in repository
+func (s *RecommendationService) Reindex(ctx context.Context) error {
+	logger := log.Ctx(ctx)
+
+	movies, err := s.movieRepo.GetAllMovies(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return err
+	}
+
+	index := make(map[int]features, len(movies))
+	for _, movie := range movies {
+		index[movie.ID] = featuresOf(movie)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.features = index
+	s.similar = make(map[int][]scoredMovie)
+
+	logger.Info().Int("movies", len(index)).Msg("recommendation index built")
+	return nil
+}
+
+// OnMovieUpsert drops cached similar movies, when movie changes in a way which affects similarity.
+// Reviews and ratings change movie too, but they keep cache
+func (s *RecommendationService) OnMovieUpsert(ctx context.Context, movie models.Movie) {
+	f := featuresOf(movie)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if current, ok := s.features[movie.ID]; ok && current.equal(f) {
+		return
+	}
+	s.features[movie.ID] = f
+	s.similar = make(map[int][]scoredMovie)
+}
+
+// OnMovieDelete drops cached similar movies, deleted movie may be among them
+func (s *RecommendationService) OnMovieDelete(ctx context.Context, movieID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.features, movieID)
+	s.similar = make(map[int][]scoredMovie)
+}
+
+// sortScored orders movies by score, the highest first, ties are broken by movie id
+func sortScored(movies []scoredMovie) {
+	sort.Slice(movies, func(i, j int) bool {
+		if movies[i].score != movies[j].score {
+			return movies[i].score > movies[j].score
+		}
+		return movies[i].id < movies[j].id
+	})
+}
+
+// similarTo returns movies most similar to movie by content, the most similar first
+func (s *RecommendationService) similarTo(movieID int) []scoredMovie {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if res, ok := s.similar[movieID]; ok {
+		return res
+	}
+
+	target := s.features[movieID]
+	var candidates []scoredMovie
+	for id, f := range s.features {
+		if id == movieID {
+			continue
+		}
+		if score := similarity(target, f, s.settings.Similar); score > 0 {
+			candidates = append(candidates, scoredMovie{id: id, score: score})
+		}
+	}
+	sortScored(candidates)
+
+	res := candidates[:min(len(candidates), maxSimilar)]
+	s.similar[movieID] = res
+	return res
+}
+
+// ratedMovies returns ids of movies user rated, anonymous user has not rated anything
+func (s *RecommendationService) ratedMovies(ctx context.Context, username string) (map[int]bool, error) {
+	res := make(map[int]bool)
+	if username == "" {
+		return res, nil
+	}
+
+	user, err := s.userRepo.GetUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	ratings, err := s.ratingRepo.GetUserRatings(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rating := range ratings {
+		res[rating.MovieID] = true
+	}
+	return res, nil
+}
+
+// GetSimilarMovies returns at most limit movies similar to movie, the most similar first.
+// Movies rated by user are skipped, username is empty for anonymous user
+func (s *RecommendationService) GetSimilarMovies(ctx context.Context, movieID int, username string, limit int) ([]models.Movie, error) {
+	logger := log.Ctx(ctx)
+
+	if _, err := s.movieRepo.GetMovieFromRepoByID(ctx, movieID); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	rated, err := s.ratedMovies(ctx, username)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	res := make([]models.Movie, 0, limit)
+	for _, similar := range s.similarTo(movieID) {
+		if len(res) == limit {
+			break
+		}
+		if rated[similar.id] {
+			continue
+		}
+
+		movie, err := s.movieRepo.GetMovieFromRepoByID(ctx, similar.id)
+		if errors.Is(err, errs.ErrMovieNotFound) {
+			// movie was deleted after cache was built, cache will be dropped by repository
+			continue
+		}
+		if err != nil {
+			logger.Error().Err(err).Msg(err.Error())
+			return nil, err
+		}
+		res = append(res, *movie)
+	}
+
+	return res, nil
+}
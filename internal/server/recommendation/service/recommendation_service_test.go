@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	repoMovie "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/repository"
+	repoRating "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/rating/repository"
+	repoUser "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/user/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	drama    = models.Genre{ID: 1, Name: "драма"}
+	thriller = models.Genre{ID: 2, Name: "триллер"}
+	comedy   = models.Genre{ID: 3, Name: "комедия"}
+
+	weights  = models.SimilarityWeights{Genre: 3, Staff: 2, Era: 1, Country: 1, EraSpan: 10}
+	settings = models.RecommendationSettings{Similar: weights, ColdStartRatings: 4}
+)
+
+func staff(personIDs ...int) []models.StaffMember {
+	res := make([]models.StaffMember, 0, len(personIDs))
+	for _, id := range personIDs {
+		res = append(res, models.StaffMember{Person: models.Person{ID: id}, Role: models.RoleActor})
+	}
+	return res
+}
+
+func similarMovies() mocks.Movies {
+	return mocks.Movies{
+		1: {ID: 1, Name: "Бойцовский клуб", ReleaseYear: 1999, Country: "США, Германия", Genres: []models.Genre{drama, thriller}, Staff: staff(10, 11)},
+		2: {ID: 2, Name: "Семь", ReleaseYear: 1995, Country: "США", Genres: []models.Genre{drama, thriller}, Staff: staff(10, 12)},
+		3: {ID: 3, Name: "Престиж", ReleaseYear: 2006, Country: "Великобритания", Genres: []models.Genre{drama, thriller}},
+		4: {ID: 4, Name: "Амели", ReleaseYear: 2001, Country: "Франция", Genres: []models.Genre{comedy}},
+		5: {ID: 5, Name: "Сталкер", ReleaseYear: 1979, Country: "СССР", Genres: []models.Genre{comedy}},
+	}
+}
+
+func movieIDs(movies []models.Movie) []int {
+	ids := make([]int, 0, len(movies))
+	for _, movie := range movies {
+		ids = append(ids, movie.ID)
+	}
+	return ids
+}
+
+// newRecommendationService returns service over movies with user neo who rated "Семь"
+func newRecommendationService(t *testing.T, movies *mocks.Movies) (*RecommendationService, *repoMovie.MovieRepository, *repoRating.RatingRepository, int) {
+	ctx := context.Background()
+
+	userRepo := repoUser.NewUserRepository()
+	require.NoError(t, userRepo.CreateUser(ctx, &models.User{Username: "neo"}))
+	user, err := userRepo.GetUser(ctx, "neo")
+	require.NoError(t, err)
+
+	ratingRepo := repoRating.NewRatingRepository()
+	require.NoError(t, ratingRepo.SetRating(ctx, models.Rating{UserID: user.ID, MovieID: 2, Score: 9}))
+
+	movieRepo := repoMovie.NewMovieRepository(movies)
+	s := NewRecommendationService(movieRepo, ratingRepo, userRepo, settings)
+	require.NoError(t, s.Reindex(ctx))
+	movieRepo.Subscribe(s)
+
+	return s, movieRepo, ratingRepo, user.ID
+}
+
+func TestSimilarity(t *testing.T) {
+	movies := similarMovies()
+	fightClub, seven, amelie := featuresOf(movies[1]), featuresOf(movies[2]), featuresOf(movies[4])
+
+	// all genres, one of two actors, the only country of "Семь" and 4 of 10 years of era
+	assert.InDelta(t, 3+2*0.5+1+0.6, similarity(fightClub, seven, weights), 1e-9)
+	assert.InDelta(t, similarity(fightClub, seven, weights), similarity(seven, fightClub, weights), 1e-9)
+	assert.InDelta(t, 0.8, similarity(fightClub, amelie, weights), 1e-9)
+	assert.Zero(t, similarity(fightClub, featuresOf(movies[5]), weights))
+}
+
+func TestRecommendationService_GetSimilarMovies(t *testing.T) {
+	ctx := context.Background()
+	movies := similarMovies()
+	s, _, _, _ := newRecommendationService(t, &movies)
+
+	similar, err := s.GetSimilarMovies(ctx, 1, "", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 3, 4}, movieIDs(similar))
+
+	similar, err = s.GetSimilarMovies(ctx, 1, "", 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int{2}, movieIDs(similar))
+
+	// movies user rated are not suggested
+	similar, err = s.GetSimilarMovies(ctx, 1, "neo", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 4}, movieIDs(similar))
+
+	_, err = s.GetSimilarMovies(ctx, 42, "", 10)
+	require.ErrorIs(t, err, errs.ErrMovieNotFound)
+}
+
+func TestRecommendationService_CatalogChanges(t *testing.T) {
+	ctx := context.Background()
+	movies := similarMovies()
+	s, movieRepo, _, _ := newRecommendationService(t, &movies)
+
+	_, err := s.GetSimilarMovies(ctx, 1, "", 10)
+	require.NoError(t, err)
+	require.Contains(t, s.similar, 1)
+
+	// new reviews and ratings do not change similarity
+	_, err = movieRepo.UpdateMovie(ctx, 3, func(movie *models.Movie) error {
+		movie.Rating = 9.1
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Contains(t, s.similar, 1)
+
+	_, err = movieRepo.UpdateMovie(ctx, 5, func(movie *models.Movie) error {
+		movie.ReleaseYear = 1998
+		movie.Genres = []models.Genre{drama}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.NotContains(t, s.similar, 1)
+
+	similar, err := s.GetSimilarMovies(ctx, 1, "", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 5, 3, 4}, movieIDs(similar))
+
+	require.NoError(t, movieRepo.DeleteMovie(ctx, 2))
+	similar, err = s.GetSimilarMovies(ctx, 1, "", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int{5, 3, 4}, movieIDs(similar))
+}
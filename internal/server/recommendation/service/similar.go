@@ -0,0 +1,83 @@
+package service
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+)
+
+// features parts of movie its similarity to other movies is computed from, all slices are sorted
+type features struct {
+	genres    []int
+	persons   []int
+	countries []string
+	year      int
+}
+
+func featuresOf(movie models.Movie) features {
+	f := features{year: movie.ReleaseYear}
+	for _, genre := range movie.Genres {
+		f.genres = append(f.genres, genre.ID)
+	}
+	for _, member := range movie.Staff {
+		f.persons = append(f.persons, member.Person.ID)
+	}
+	for _, country := range strings.Split(movie.Country, ",") {
+		if country = strings.ToLower(strings.TrimSpace(country)); country != "" {
+			f.countries = append(f.countries, country)
+		}
+	}
+
+	sort.Ints(f.genres)
+	sort.Ints(f.persons)
+	sort.Strings(f.countries)
+	f.persons = slices.Compact(f.persons)
+	return f
+}
+
+func (f features) equal(other features) bool {
+	return f.year == other.year && slices.Equal(f.genres, other.genres) &&
+		slices.Equal(f.persons, other.persons) && slices.Equal(f.countries, other.countries)
+}
+
+// overlap share of the smaller of two sorted sets which is present in the other one
+func overlap[T int | string](a, b []T) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			shared++
+			i++
+			j++
+		case a[i] < b[j]:
+			i++
+		default:
+			j++
+		}
+	}
+	return float64(shared) / float64(min(len(a), len(b)))
+}
+
+// similarity of two movies, zero means movies have nothing in common
+func similarity(a, b features, weights models.SimilarityWeights) float64 {
+	res := weights.Genre*overlap(a.genres, b.genres) +
+		weights.Staff*overlap(a.persons, b.persons) +
+		weights.Country*overlap(a.countries, b.countries)
+
+	if a.year > 0 && b.year > 0 && weights.EraSpan > 0 {
+		diff := a.year - b.year
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff < weights.EraSpan {
+			res += weights.Era * (1 - float64(diff)/float64(weights.EraSpan))
+		}
+	}
+	return res
+}
@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	movieDTO "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/delivery/dto"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
+)
+
+// ReviewRequest text and score of created or edited review
+type ReviewRequest struct {
+	Text  string `json:"text"`
+	Score int    `json:"score"`
+}
+
+// ReactionRequest user reaction to review, "like" or "dislike"
+type ReactionRequest struct {
+	Reaction string `json:"reaction"`
+}
+
+// ReviewsPageJSON page of movie reviews
+type ReviewsPageJSON struct {
+	Reviews    []movieDTO.ReviewJSON `json:"reviews"`
+	Total      int                   `json:"total"`
+	NextCursor string                `json:"next_cursor,omitempty"`
+}
+
+func NewReviewsPageJSON(page models.ReviewPage, locale l10n.Locale) ReviewsPageJSON {
+	res := ReviewsPageJSON{
+		Reviews:    make([]movieDTO.ReviewJSON, 0, len(page.Reviews)),
+		Total:      page.Total,
+		NextCursor: page.NextCursor,
+	}
+	for _, review := range page.Reviews {
+		res.Reviews = append(res.Reviews, movieDTO.NewReviewJSON(review, locale))
+	}
+	return res
+}
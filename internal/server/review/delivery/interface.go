@@ -0,0 +1,12 @@
+package delivery
+
+import "net/http"
+
+type ReviewHandlerInterface interface {
+	ListReviews(w http.ResponseWriter, r *http.Request)
+	CreateReview(w http.ResponseWriter, r *http.Request)
+	UpdateReview(w http.ResponseWriter, r *http.Request)
+	DeleteReview(w http.ResponseWriter, r *http.Request)
+	React(w http.ResponseWriter, r *http.Request)
+	DeleteReaction(w http.ResponseWriter, r *http.Request)
+}
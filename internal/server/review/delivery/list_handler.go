@@ -0,0 +1,90 @@
+package delivery
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/review/delivery/dto"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/jsonutil"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultListLimit = 10
+	maxListLimit     = 50
+
+	orderAsc  = "asc"
+	orderDesc = "desc"
+)
+
+// parseListRequest reads sentiment filter, sorting and pagination from query string
+func parseListRequest(query url.Values) (models.ReviewListRequest, error) {
+	req := models.ReviewListRequest{
+		Sentiment: models.ReviewSentiment(query.Get("sentiment")),
+		Sort:      models.ReviewSort(query.Get("sort")),
+		Desc:      true,
+		Limit:     defaultListLimit,
+		Cursor:    query.Get("cursor"),
+	}
+	if req.Sort == "" {
+		req.Sort = models.ReviewSortByHelpfulness
+	}
+
+	switch query.Get("order") {
+	case "", orderDesc:
+	case orderAsc:
+		req.Desc = false
+	default:
+		return req, errors.Errorf("unknown order %q", query.Get("order"))
+	}
+
+	if val := query.Get("limit"); val != "" {
+		var err error
+		if req.Limit, err = strconv.Atoi(val); err != nil {
+			return req, errors.Wrap(err, "parameter limit")
+		}
+	}
+	if req.Limit <= 0 || req.Limit > maxListLimit {
+		return req, errors.Errorf("limit must be in 1-%d", maxListLimit)
+	}
+
+	return req, nil
+}
+
+func (h *ReviewHandler) ListReviews(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	movieID, ok := getMovieID(w, r)
+	if !ok {
+		return
+	}
+
+	req, err := parseListRequest(r.URL.Query())
+	if err != nil {
+		errMsg := errors.Wrap(err, "listReviews action: bad request")
+		logger.Error().Err(errMsg).Msg(errMsg.Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, err.Error())
+		return
+	}
+
+	page, err := h.reviewService.ListReviews(r.Context(), movieID, req)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		if errors.Is(err, errs.ErrInvalidCursor) || errors.Is(err, errs.ErrInvalidReviewRequest) {
+			jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, err.Error())
+			return
+		}
+		sendReviewError(r.Context(), w, err)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, dto.NewReviewsPageJSON(*page, l10n.ParseLocale(r.URL.Query().Get(localeParam)))); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
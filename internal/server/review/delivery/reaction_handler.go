@@ -0,0 +1,99 @@
+package delivery
+
+import (
+	"net/http"
+	"strconv"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/middleware"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	movieDTO "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/delivery/dto"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/review/delivery/dto"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/jsonutil"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// getReviewID parses review id from path, error response is sent if it is invalid
+func getReviewID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	logger := log.Ctx(r.Context())
+
+	reviewID, err := strconv.Atoi(mux.Vars(r)["review_id"])
+	if err != nil {
+		errMsg := errors.Wrap(err, "reaction action: bad review_id")
+		logger.Error().Err(errMsg).Msg(errMsg.Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, errs.ErrBadPayload)
+		return 0, false
+	}
+
+	return reviewID, true
+}
+
+func (h *ReviewHandler) React(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	username, ok := middleware.RequireUsername(w, r)
+	if !ok {
+		return
+	}
+	movieID, ok := getMovieID(w, r)
+	if !ok {
+		return
+	}
+	reviewID, ok := getReviewID(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.ReactionRequest
+	if err := jsonutil.ReadJSON(r, &req); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrParseJSON)).Msg(errors.Wrap(err, errs.ErrParseJSON).Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errors.Wrap(err, errs.ErrParseJSONShort).Error(), errs.ErrBadPayload)
+		return
+	}
+
+	logger.Info().Msgf("reacting %q to review %d by %s", req.Reaction, reviewID, username)
+	res, err := h.reviewService.React(r.Context(), movieID, reviewID, username, models.ReviewReaction(req.Reaction))
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendReviewError(r.Context(), w, err)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, movieDTO.NewReviewJSON(*res, l10n.ParseLocale(r.URL.Query().Get(localeParam)))); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
+
+func (h *ReviewHandler) DeleteReaction(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	username, ok := middleware.RequireUsername(w, r)
+	if !ok {
+		return
+	}
+	movieID, ok := getMovieID(w, r)
+	if !ok {
+		return
+	}
+	reviewID, ok := getReviewID(w, r)
+	if !ok {
+		return
+	}
+
+	logger.Info().Msgf("deleting reaction to review %d by %s", reviewID, username)
+	res, err := h.reviewService.DeleteReaction(r.Context(), movieID, reviewID, username)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendReviewError(r.Context(), w, err)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, movieDTO.NewReviewJSON(*res, l10n.ParseLocale(r.URL.Query().Get(localeParam)))); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
@@ -0,0 +1,182 @@
+package delivery
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/ds"
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/messages"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/middleware"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	movieDTO "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/delivery/dto"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/review/delivery/dto"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/validation/review"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/jsonutil"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// localeParam query parameter requesting localized display strings
+const localeParam = "locale"
+
+type ReviewServiceInterface interface {
+	CreateReview(ctx context.Context, movieID int, username string, text string, score int) (*models.Review, error)
+	UpdateReview(ctx context.Context, movieID int, username string, text string, score int) (*models.Review, error)
+	DeleteReview(ctx context.Context, movieID int, username string) error
+	ListReviews(ctx context.Context, movieID int, req models.ReviewListRequest) (*models.ReviewPage, error)
+	React(ctx context.Context, movieID int, reviewID int, username string, reaction models.ReviewReaction) (*models.Review, error)
+	DeleteReaction(ctx context.Context, movieID int, reviewID int, username string) (*models.Review, error)
+}
+
+type ReviewHandler struct {
+	reviewService ReviewServiceInterface
+}
+
+func NewReviewHandler(reviewService ReviewServiceInterface) *ReviewHandler {
+	return &ReviewHandler{
+		reviewService: reviewService,
+	}
+}
+
+// getMovieID parses movie id from path, error response is sent if it is invalid
+func getMovieID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	logger := log.Ctx(r.Context())
+
+	movieID, err := strconv.Atoi(mux.Vars(r)["movie_id"])
+	if err != nil {
+		errMsg := errors.Wrap(err, "review action: bad movie_id")
+		logger.Error().Err(errMsg).Msg(errMsg.Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, errs.ErrBadPayload)
+		return 0, false
+	}
+
+	return movieID, true
+}
+
+// readReview reads and validates review from request body, error response is sent if it is invalid
+func readReview(w http.ResponseWriter, r *http.Request) (*dto.ReviewRequest, bool) {
+	logger := log.Ctx(r.Context())
+
+	var req dto.ReviewRequest
+	if err := jsonutil.ReadJSON(r, &req); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrParseJSON)).Msg(errors.Wrap(err, errs.ErrParseJSON).Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errors.Wrap(err, errs.ErrParseJSONShort).Error(), errs.ErrBadPayload)
+		return nil, false
+	}
+
+	for _, err := range []error{review.IsValidText(req.Text), review.IsValidScore(req.Score)} {
+		if err != nil {
+			logger.Error().Err(errors.Wrap(err, errs.ErrInvalidReview)).Msg(errors.Wrap(err, errs.ErrInvalidReview).Error())
+			jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errors.Wrap(err, errs.ErrInvalidReviewShort).Error(),
+				errors.Wrap(err, errs.ErrInvalidReview).Error())
+			return nil, false
+		}
+	}
+
+	return &req, true
+}
+
+func sendReviewError(ctx context.Context, w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, errs.ErrMovieNotFound), errors.Is(err, errs.ErrReviewNotFound),
+		errors.Is(err, errs.ErrReviewNotExist), errors.Is(err, errs.ErrReactionNotFound):
+		jsonutil.SendError(ctx, w, http.StatusNotFound, errs.ErrNotFoundShort, err.Error())
+	case errors.Is(err, errs.ErrReviewExists):
+		jsonutil.SendError(ctx, w, http.StatusConflict, errs.ErrAlreadyExistsShort, err.Error())
+	case errors.Is(err, errs.ErrOwnReviewReaction):
+		jsonutil.SendError(ctx, w, http.StatusForbidden, errs.ErrForbiddenShort, err.Error())
+	case errors.Is(err, errs.ErrInvalidReaction):
+		jsonutil.SendError(ctx, w, http.StatusBadRequest, errs.ErrBadPayload, err.Error())
+	default:
+		jsonutil.SendError(ctx, w, http.StatusInternalServerError, errs.ErrSomethingWentWrong, errs.ErrSomethingWentWrong)
+	}
+}
+
+func (h *ReviewHandler) CreateReview(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	username, ok := middleware.RequireUsername(w, r)
+	if !ok {
+		return
+	}
+	movieID, ok := getMovieID(w, r)
+	if !ok {
+		return
+	}
+	req, ok := readReview(w, r)
+	if !ok {
+		return
+	}
+
+	logger.Info().Msgf("creating review of movie %d by %s", movieID, username)
+	res, err := h.reviewService.CreateReview(r.Context(), movieID, username, req.Text, req.Score)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendReviewError(r.Context(), w, err)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, movieDTO.NewReviewJSON(*res, l10n.ParseLocale(r.URL.Query().Get(localeParam)))); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
+
+func (h *ReviewHandler) UpdateReview(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	username, ok := middleware.RequireUsername(w, r)
+	if !ok {
+		return
+	}
+	movieID, ok := getMovieID(w, r)
+	if !ok {
+		return
+	}
+	req, ok := readReview(w, r)
+	if !ok {
+		return
+	}
+
+	logger.Info().Msgf("updating review of movie %d by %s", movieID, username)
+	res, err := h.reviewService.UpdateReview(r.Context(), movieID, username, req.Text, req.Score)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendReviewError(r.Context(), w, err)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, movieDTO.NewReviewJSON(*res, l10n.ParseLocale(r.URL.Query().Get(localeParam)))); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
+
+func (h *ReviewHandler) DeleteReview(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	username, ok := middleware.RequireUsername(w, r)
+	if !ok {
+		return
+	}
+	movieID, ok := getMovieID(w, r)
+	if !ok {
+		return
+	}
+
+	logger.Info().Msgf("deleting review of movie %d by %s", movieID, username)
+	if err := h.reviewService.DeleteReview(r.Context(), movieID, username); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendReviewError(r.Context(), w, err)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, ds.Response{Message: messages.SuccessfulReviewDelete}); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
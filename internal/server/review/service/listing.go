@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/cursor"
+	"github.com/rs/zerolog/log"
+)
+
+func reviewKey(review models.Review, sortBy models.ReviewSort) cursor.Key {
+	key := cursor.Key{ID: review.ID}
+	switch sortBy {
+	case models.ReviewSortByDate:
+		key.Num = float64(review.CreatedAt.UnixMilli())
+	case models.ReviewSortByScore:
+		key.Num = float64(review.Score)
+	case models.ReviewSortByHelpfulness:
+		key.Num = review.Helpfulness()
+	}
+	return key
+}
+
+func isValidListRequest(req models.ReviewListRequest) bool {
+	switch req.Sort {
+	case models.ReviewSortByDate, models.ReviewSortByScore, models.ReviewSortByHelpfulness:
+	default:
+		return false
+	}
+
+	switch req.Sentiment {
+	case "", models.SentimentPositive, models.SentimentNeutral, models.SentimentNegative:
+	default:
+		return false
+	}
+
+	return req.Limit > 0
+}
+
+// ListReviews returns page of movie reviews filtered and sorted as requested
+func (s *ReviewService) ListReviews(ctx context.Context, movieID int, req models.ReviewListRequest) (*models.ReviewPage, error) {
+	logger := log.Ctx(ctx)
+
+	if !isValidListRequest(req) {
+		logger.Error().Str("sort", string(req.Sort)).Str("sentiment", string(req.Sentiment)).Int("limit", req.Limit).Msg(errs.ErrBadPayload)
+		return nil, errs.ErrInvalidReviewRequest
+	}
+
+	movie, err := s.movieRepo.GetMovieFromRepoByID(ctx, movieID)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	reviews := make([]models.Review, 0, len(movie.Reviews))
+	for _, review := range movie.Reviews {
+		if req.Sentiment == "" || review.Sentiment() == req.Sentiment {
+			reviews = append(reviews, review)
+		}
+	}
+
+	page, err := cursor.Paginate(reviews, func(review models.Review) cursor.Key {
+		return reviewKey(review, req.Sort)
+	}, cursor.Request{Scope: cursor.Scope(req.Sort), Desc: req.Desc, Limit: req.Limit, Cursor: req.Cursor})
+	if err != nil {
+		logger.Error().Err(err).Str("cursor", req.Cursor).Msg(err.Error())
+		return nil, err
+	}
+
+	return &models.ReviewPage{Reviews: page.Items, Total: page.Total, NextCursor: page.NextCursor}, nil
+}
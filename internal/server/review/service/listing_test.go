@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	repoMovie "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func reviewIDs(reviews []models.Review) []int {
+	ids := make([]int, 0, len(reviews))
+	for _, review := range reviews {
+		ids = append(ids, review.ID)
+	}
+	return ids
+}
+
+// reactions returns likes and dislikes of distinct users
+func reactions(likes, dislikes int) map[int]models.ReviewReaction {
+	res := make(map[int]models.ReviewReaction, likes+dislikes)
+	for i := 0; i < likes; i++ {
+		res[len(res)+1] = models.ReactionLike
+	}
+	for i := 0; i < dislikes; i++ {
+		res[len(res)+1] = models.ReactionDislike
+	}
+	return res
+}
+
+func TestReviewService_ListReviews(t *testing.T) {
+	ctx := context.Background()
+
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	movies := mocks.Movies{
+		1: {ID: 1, Reviews: []models.Review{
+			{ID: 1, Score: 9, CreatedAt: day(1), Reactions: reactions(1, 0)},
+			{ID: 2, Score: 3, CreatedAt: day(4), Reactions: reactions(5, 1)},
+			{ID: 3, Score: 6, CreatedAt: day(2)},
+			{ID: 4, Score: 7, CreatedAt: day(3), Reactions: reactions(2, 0)},
+			{ID: 5, Score: 9, CreatedAt: day(5), Reactions: reactions(0, 2)},
+		}},
+	}
+	s := NewReviewService(repoMovie.NewMovieRepository(&movies), nil)
+
+	tests := []struct {
+		name string
+		req  models.ReviewListRequest
+		want []int
+	}{
+		{name: "newest", req: models.ReviewListRequest{Sort: models.ReviewSortByDate, Desc: true, Limit: 10}, want: []int{5, 2, 4, 3, 1}},
+		{name: "oldest", req: models.ReviewListRequest{Sort: models.ReviewSortByDate, Limit: 10}, want: []int{1, 3, 4, 2, 5}},
+		{name: "score, ties by id", req: models.ReviewListRequest{Sort: models.ReviewSortByScore, Desc: true, Limit: 10}, want: []int{1, 5, 4, 3, 2}},
+		{name: "helpfulness", req: models.ReviewListRequest{Sort: models.ReviewSortByHelpfulness, Desc: true, Limit: 10}, want: []int{2, 4, 1, 5, 3}},
+		{name: "positive", req: models.ReviewListRequest{Sentiment: models.SentimentPositive, Sort: models.ReviewSortByDate, Desc: true, Limit: 10}, want: []int{5, 4, 1}},
+		{name: "neutral", req: models.ReviewListRequest{Sentiment: models.SentimentNeutral, Sort: models.ReviewSortByDate, Desc: true, Limit: 10}, want: []int{3}},
+		{name: "negative", req: models.ReviewListRequest{Sentiment: models.SentimentNegative, Sort: models.ReviewSortByDate, Desc: true, Limit: 10}, want: []int{2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := s.ListReviews(ctx, 1, tt.req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, reviewIDs(page.Reviews))
+			assert.Equal(t, len(tt.want), page.Total)
+			assert.Empty(t, page.NextCursor)
+		})
+	}
+
+	t.Run("pagination", func(t *testing.T) {
+		req := models.ReviewListRequest{Sort: models.ReviewSortByScore, Desc: true, Limit: 2}
+
+		var got []int
+		for {
+			page, err := s.ListReviews(ctx, 1, req)
+			require.NoError(t, err)
+			got = append(got, reviewIDs(page.Reviews)...)
+			if page.NextCursor == "" {
+				break
+			}
+			req.Cursor = page.NextCursor
+		}
+		assert.Equal(t, []int{1, 5, 4, 3, 2}, got)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := s.ListReviews(ctx, 1, models.ReviewListRequest{Sort: "likes", Limit: 10})
+		assert.ErrorIs(t, err, errs.ErrInvalidReviewRequest)
+
+		_, err = s.ListReviews(ctx, 1, models.ReviewListRequest{Sort: models.ReviewSortByDate, Sentiment: "angry", Limit: 10})
+		assert.ErrorIs(t, err, errs.ErrInvalidReviewRequest)
+
+		_, err = s.ListReviews(ctx, 1, models.ReviewListRequest{Sort: models.ReviewSortByDate, Limit: 10, Cursor: "???"})
+		assert.ErrorIs(t, err, errs.ErrInvalidCursor)
+
+		_, err = s.ListReviews(ctx, 2, models.ReviewListRequest{Sort: models.ReviewSortByDate, Limit: 10})
+		assert.ErrorIs(t, err, errs.ErrMovieNotFound)
+	})
+}
+
+func TestMovie_ReviewSummary(t *testing.T) {
+	movie := models.Movie{Reviews: []models.Review{{Score: 10}, {Score: 7}, {Score: 5}, {Score: 1}, {Score: 10}}}
+
+	summary := movie.ReviewSummary()
+	assert.Equal(t, 5, summary.Count)
+	assert.Equal(t, 6.6, summary.Average)
+	assert.Equal(t, [10]int{1, 0, 0, 0, 1, 0, 1, 0, 0, 2}, summary.Scores)
+	assert.Equal(t, 3, summary.Positive)
+	assert.Equal(t, 1, summary.Neutral)
+	assert.Equal(t, 1, summary.Negative)
+}
+
+func TestReview_Helpfulness(t *testing.T) {
+	assert.Zero(t, models.Review{}.Helpfulness())
+
+	// many likes outweigh a single one with the same share
+	assert.Greater(t, models.Review{Reactions: reactions(10, 0)}.Helpfulness(), models.Review{Reactions: reactions(1, 0)}.Helpfulness())
+	assert.Greater(t, models.Review{Reactions: reactions(90, 10)}.Helpfulness(), models.Review{Reactions: reactions(3, 0)}.Helpfulness())
+	assert.Less(t, models.Review{Reactions: reactions(1, 5)}.Helpfulness(), models.Review{Reactions: reactions(1, 0)}.Helpfulness())
+}
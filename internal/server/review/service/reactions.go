@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"maps"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/rs/zerolog/log"
+)
+
+func reviewIndexByID(movie *models.Movie, reviewID int) int {
+	for i, review := range movie.Reviews {
+		if review.ID == reviewID {
+			return i
+		}
+	}
+	return -1
+}
+
+// setReaction sets or with empty reaction removes reaction of user to review
+func (s *ReviewService) setReaction(ctx context.Context, movieID int, reviewID int, username string, reaction models.ReviewReaction) (*models.Review, error) {
+	logger := log.Ctx(ctx)
+
+	user, err := s.userRepo.GetUser(ctx, username)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	var res models.Review
+	_, err = s.movieRepo.UpdateMovie(ctx, movieID, func(movie *models.Movie) error {
+		idx := reviewIndexByID(movie, reviewID)
+		if idx < 0 {
+			return errs.ErrReviewNotExist
+		}
+
+		review := &movie.Reviews[idx]
+		if review.User.ID == user.ID {
+			return errs.ErrOwnReviewReaction
+		}
+		if _, ok := review.Reactions[user.ID]; !ok && reaction == "" {
+			return errs.ErrReactionNotFound
+		}
+
+		// map is copied, stored movie may be read concurrently
+		reactions := maps.Clone(review.Reactions)
+		if reactions == nil {
+			reactions = make(map[int]models.ReviewReaction)
+		}
+		if reaction == "" {
+			delete(reactions, user.ID)
+		} else {
+			reactions[user.ID] = reaction
+		}
+		review.Reactions = reactions
+
+		res = *review
+		return nil
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// React marks review of other user as helpful or not, previous reaction of user is replaced
+func (s *ReviewService) React(ctx context.Context, movieID int, reviewID int, username string, reaction models.ReviewReaction) (*models.Review, error) {
+	if reaction != models.ReactionLike && reaction != models.ReactionDislike {
+		log.Ctx(ctx).Error().Str("reaction", string(reaction)).Msg(errs.ErrInvalidReaction.Error())
+		return nil, errs.ErrInvalidReaction
+	}
+
+	return s.setReaction(ctx, movieID, reviewID, username, reaction)
+}
+
+// DeleteReaction removes reaction of user to review
+func (s *ReviewService) DeleteReaction(ctx context.Context, movieID int, reviewID int, username string) (*models.Review, error) {
+	return s.setReaction(ctx, movieID, reviewID, username, "")
+}
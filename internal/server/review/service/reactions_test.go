@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewService_React(t *testing.T) {
+	ctx := context.Background()
+	s, movieRepo := setup(t)
+
+	// review 7 is written by other user
+	review, err := s.React(ctx, 1, 7, "neo", models.ReactionLike)
+	require.NoError(t, err)
+	assert.Equal(t, 1, review.Likes())
+
+	// reaction is changed, not added
+	review, err = s.React(ctx, 1, 7, "neo", models.ReactionDislike)
+	require.NoError(t, err)
+	assert.Equal(t, 0, review.Likes())
+	assert.Equal(t, 1, review.Dislikes())
+
+	movie, err := movieRepo.GetMovieFromRepoByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, movie.Reviews[0].Dislikes())
+
+	review, err = s.DeleteReaction(ctx, 1, 7, "neo")
+	require.NoError(t, err)
+	assert.Empty(t, review.Reactions)
+	_, err = s.DeleteReaction(ctx, 1, 7, "neo")
+	assert.ErrorIs(t, err, errs.ErrReactionNotFound)
+
+	_, err = s.React(ctx, 1, 7, "neo", "love")
+	assert.ErrorIs(t, err, errs.ErrInvalidReaction)
+	_, err = s.React(ctx, 1, 100, "neo", models.ReactionLike)
+	assert.ErrorIs(t, err, errs.ErrReviewNotExist)
+
+	own, err := s.CreateReview(ctx, 1, "neo", "Красная или синяя таблетка", 5)
+	require.NoError(t, err)
+	_, err = s.React(ctx, 1, own.ID, "neo", models.ReactionLike)
+	assert.ErrorIs(t, err, errs.ErrOwnReviewReaction)
+}
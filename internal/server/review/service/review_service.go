@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/rs/zerolog/log"
+)
+
+type MovieRepositoryInterface interface {
+	GetMovieFromRepoByID(ctx context.Context, movieID int) (*models.Movie, error)
+	UpdateMovie(ctx context.Context, movieID int, fn func(movie *models.Movie) error) (*models.Movie, error)
+	NewReviewID(ctx context.Context) int
+}
+
+type UserRepositoryInterface interface {
+	GetUser(ctx context.Context, login string) (*models.User, error)
+}
+
+// ReviewService manages reviews of authenticated users, one review per user per movie
+type ReviewService struct {
+	movieRepo MovieRepositoryInterface
+	userRepo  UserRepositoryInterface
+}
+
+func NewReviewService(movieRepo MovieRepositoryInterface, userRepo UserRepositoryInterface) *ReviewService {
+	return &ReviewService{
+		movieRepo: movieRepo,
+		userRepo:  userRepo,
+	}
+}
+
+func newReviewAuthor(user *models.User) models.ReviewAuthor {
+	return models.ReviewAuthor{ID: user.ID, Login: user.Username, Avatar: user.Avatar}
+}
+
+// CreateReview adds user review to movie and recomputes movie rating
+func (s *ReviewService) CreateReview(ctx context.Context, movieID int, username string, text string, score int) (*models.Review, error) {
+	logger := log.Ctx(ctx)
+
+	user, err := s.userRepo.GetUser(ctx, username)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	// id is reserved outside of update, repository is locked while fn runs
+	res := models.Review{ID: s.movieRepo.NewReviewID(ctx)}
+	_, err = s.movieRepo.UpdateMovie(ctx, movieID, func(movie *models.Movie) error {
+		if movie.ReviewIndexByUser(user.ID) >= 0 {
+			return errs.ErrReviewExists
+		}
+
+		res = models.Review{
+			ID:        res.ID,
+			User:      newReviewAuthor(user),
+			Text:      strings.TrimSpace(text),
+			Score:     score,
+			CreatedAt: time.Now().UTC(),
+		}
+		movie.Reviews = append(movie.Reviews, res)
+		movie.UpdateRating()
+		return nil
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// UpdateReview replaces text and score of user review and recomputes movie rating
+func (s *ReviewService) UpdateReview(ctx context.Context, movieID int, username string, text string, score int) (*models.Review, error) {
+	logger := log.Ctx(ctx)
+
+	user, err := s.userRepo.GetUser(ctx, username)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	var res models.Review
+	_, err = s.movieRepo.UpdateMovie(ctx, movieID, func(movie *models.Movie) error {
+		idx := movie.ReviewIndexByUser(user.ID)
+		if idx < 0 {
+			return errs.ErrReviewNotFound
+		}
+
+		now := time.Now().UTC()
+		review := &movie.Reviews[idx]
+		// author is refreshed, user may have changed login or avatar since review was written
+		review.User = newReviewAuthor(user)
+		review.Text = strings.TrimSpace(text)
+		review.Score = score
+		review.UpdatedAt = &now
+		movie.UpdateRating()
+
+		res = *review
+		return nil
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// DeleteReview removes user review from movie and recomputes movie rating
+func (s *ReviewService) DeleteReview(ctx context.Context, movieID int, username string) error {
+	logger := log.Ctx(ctx)
+
+	user, err := s.userRepo.GetUser(ctx, username)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return err
+	}
+
+	_, err = s.movieRepo.UpdateMovie(ctx, movieID, func(movie *models.Movie) error {
+		idx := movie.ReviewIndexByUser(user.ID)
+		if idx < 0 {
+			return errs.ErrReviewNotFound
+		}
+
+		movie.Reviews = append(movie.Reviews[:idx], movie.Reviews[idx+1:]...)
+		movie.UpdateRating()
+		return nil
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	repoMovie "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/repository"
+	repoUser "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/user/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setup(t *testing.T) (*ReviewService, *repoMovie.MovieRepository) {
+	ctx := context.Background()
+
+	movies := mocks.Movies{
+		1: {ID: 1, Name: "Матрица", Rating: 8, Reviews: []models.Review{
+			{ID: 7, User: models.ReviewAuthor{ID: 100, Login: "other"}, Text: "Классика жанра", Score: 8},
+		}},
+	}
+	movieRepo := repoMovie.NewMovieRepository(&movies)
+
+	userRepo := repoUser.NewUserRepository()
+	require.NoError(t, userRepo.CreateUser(ctx, &models.User{ID: 1, Username: "neo", Avatar: "/static/avatars/neo.png"}))
+
+	return NewReviewService(movieRepo, userRepo), movieRepo
+}
+
+func TestReviewService_CreateReview(t *testing.T) {
+	ctx := context.Background()
+	s, movieRepo := setup(t)
+
+	review, err := s.CreateReview(ctx, 1, "neo", "  Красная или синяя таблетка  ", 5)
+	require.NoError(t, err)
+	assert.Equal(t, 8, review.ID)
+	assert.Equal(t, models.ReviewAuthor{ID: 1, Login: "neo", Avatar: "/static/avatars/neo.png"}, review.User)
+	assert.Equal(t, "Красная или синяя таблетка", review.Text)
+	assert.False(t, review.CreatedAt.IsZero())
+
+	movie, err := movieRepo.GetMovieFromRepoByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, movie.Reviews, 2)
+	assert.Equal(t, 6.5, movie.Rating)
+
+	_, err = s.CreateReview(ctx, 1, "neo", "Посмотрел второй раз", 9)
+	assert.ErrorIs(t, err, errs.ErrReviewExists)
+
+	_, err = s.CreateReview(ctx, 2, "neo", "Такого фильма нет", 9)
+	assert.ErrorIs(t, err, errs.ErrMovieNotFound)
+
+	_, err = s.CreateReview(ctx, 1, "unknown", "Пользователя нет", 9)
+	assert.Error(t, err)
+}
+
+func TestReviewService_UpdateDeleteReview(t *testing.T) {
+	ctx := context.Background()
+	s, movieRepo := setup(t)
+
+	_, err := s.UpdateReview(ctx, 1, "neo", "Ещё не писал рецензию", 9)
+	assert.ErrorIs(t, err, errs.ErrReviewNotFound)
+	assert.ErrorIs(t, s.DeleteReview(ctx, 1, "neo"), errs.ErrReviewNotFound)
+
+	created, err := s.CreateReview(ctx, 1, "neo", "Красная или синяя таблетка", 5)
+	require.NoError(t, err)
+
+	updated, err := s.UpdateReview(ctx, 1, "neo", "Пересмотрел, стало лучше", 9)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, updated.ID)
+	assert.Equal(t, created.CreatedAt, updated.CreatedAt)
+	assert.NotNil(t, updated.UpdatedAt)
+	assert.Equal(t, 9, updated.Score)
+
+	movie, err := movieRepo.GetMovieFromRepoByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 8.5, movie.Rating)
+
+	require.NoError(t, s.DeleteReview(ctx, 1, "neo"))
+	movie, err = movieRepo.GetMovieFromRepoByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, movie.Reviews, 1)
+	assert.Equal(t, 8.0, movie.Rating)
+}
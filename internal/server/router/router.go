@@ -4,11 +4,21 @@ import (
 	"net/http"
 
 	authDelivery "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/auth/delivery"
+	backupDelivery "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/backup/delivery"
 	collectionDelivery "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/collection/delivery"
+	diaryDelivery "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/diary/delivery"
+	genreDelivery "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/genre/delivery"
 	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/middleware"
 	movieDelivery "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/delivery"
+	premiereDelivery "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/premiere/delivery"
+	ratingDelivery "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/rating/delivery"
+	recommendationDelivery "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/recommendation/delivery"
+	reviewDelivery "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/review/delivery"
+	searchDelivery "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/search/delivery"
 	staffDelivery "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/staff_person/delivery"
+	trendingDelivery "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/trending/delivery"
 	userDelivery "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/user/delivery/http"
+	watchlistDelivery "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/watchlist/delivery"
 	"github.com/gorilla/mux"
 )
 
@@ -27,24 +37,115 @@ func SetupAuth(router *mux.Router, authHandler authDelivery.AuthHandlerInterface
 	authSubRouter.HandleFunc("/session", authHandler.Session).Methods(http.MethodGet, http.MethodOptions).Name("SessionRoute")
 }
 
-func SetupCollections(router *mux.Router, collectionHandler collectionDelivery.CollectionHandlerInterface) {
+func SetupCollections(router *mux.Router, collectionHandler collectionDelivery.CollectionHandlerInterface, adminMiddleware mux.MiddlewareFunc) {
 	router.HandleFunc("/collections/", collectionHandler.GetMainPageCollections).Methods(http.MethodGet, http.MethodOptions).Name("CollectionsRoute")
+	router.HandleFunc("/collections/{collection_id}", collectionHandler.GetCollection).Methods(http.MethodGet, http.MethodOptions).Name("CollectionRoute")
+	router.HandleFunc("/lists", collectionHandler.BrowseLists).Methods(http.MethodGet, http.MethodOptions).Name("BrowseListsRoute")
+	router.HandleFunc("/lists", collectionHandler.CreateList).Methods(http.MethodPost, http.MethodOptions).Name("CreateListRoute")
+	router.HandleFunc("/lists/{list_id}", collectionHandler.GetList).Methods(http.MethodGet, http.MethodOptions).Name("ListRoute")
+	router.HandleFunc("/lists/{list_id}", collectionHandler.UpdateList).Methods(http.MethodPut, http.MethodOptions).Name("UpdateListRoute")
+	router.HandleFunc("/lists/{list_id}", collectionHandler.DeleteList).Methods(http.MethodDelete, http.MethodOptions).Name("DeleteListRoute")
+	router.HandleFunc("/lists/{list_id}/share", collectionHandler.ResetShareToken).Methods(http.MethodPost, http.MethodOptions).Name("ResetListShareRoute")
+	router.HandleFunc("/users/me/lists", collectionHandler.GetMyLists).Methods(http.MethodGet, http.MethodOptions).Name("MyListsRoute")
+
+	adminSubRouter := router.PathPrefix("/admin/collections").Subrouter()
+	adminSubRouter.Use(adminMiddleware)
+
+	adminSubRouter.HandleFunc("", collectionHandler.GetAllCollections).Methods(http.MethodGet, http.MethodOptions).Name("AdminCollectionsRoute")
+	adminSubRouter.HandleFunc("", collectionHandler.CreateCollection).Methods(http.MethodPost, http.MethodOptions).Name("CreateCollectionRoute")
+	adminSubRouter.HandleFunc("/{collection_id}", collectionHandler.UpdateCollection).Methods(http.MethodPut, http.MethodOptions).Name("UpdateCollectionRoute")
+	adminSubRouter.HandleFunc("/{collection_id}", collectionHandler.DeleteCollection).Methods(http.MethodDelete, http.MethodOptions).Name("DeleteCollectionRoute")
+	adminSubRouter.HandleFunc("/{collection_id}/movies", collectionHandler.UpdateCollectionMovies).Methods(http.MethodPut, http.MethodOptions).Name("UpdateCollectionMoviesRoute")
+	adminSubRouter.HandleFunc("/{collection_id}/publish", collectionHandler.PublishCollection).Methods(http.MethodPut, http.MethodOptions).Name("PublishCollectionRoute")
 }
 
-func SetupStaffPersonHandlers(router *mux.Router, staffPersonHandler staffDelivery.StaffPersonHandlerInterface) {
+func SetupStaffPersonHandlers(router *mux.Router, staffPersonHandler staffDelivery.StaffPersonHandlerInterface, adminMiddleware mux.MiddlewareFunc) {
 	router.HandleFunc("/name/{person_id}", staffPersonHandler.GetPerson).Methods(http.MethodGet, http.MethodOptions).Name("StaffPersonRoute")
+	router.HandleFunc("/name/{person_id}/movies", staffPersonHandler.GetFilmography).Methods(http.MethodGet, http.MethodOptions).Name("FilmographyRoute")
+
+	router.Handle("/name", adminMiddleware(http.HandlerFunc(staffPersonHandler.CreatePerson))).Methods(http.MethodPost, http.MethodOptions).Name("CreatePersonRoute")
+	router.Handle("/name/{person_id}", adminMiddleware(http.HandlerFunc(staffPersonHandler.UpdatePerson))).Methods(http.MethodPut, http.MethodOptions).Name("UpdatePersonRoute")
+	router.Handle("/name/{person_id}", adminMiddleware(http.HandlerFunc(staffPersonHandler.DeletePerson))).Methods(http.MethodDelete, http.MethodOptions).Name("DeletePersonRoute")
+	router.Handle("/name/{person_id}/merge", adminMiddleware(http.HandlerFunc(staffPersonHandler.MergePerson))).Methods(http.MethodPost, http.MethodOptions).Name("MergePersonRoute")
 }
 
-func SetupMovieHandlers(router *mux.Router, movieHandler movieDelivery.MovieHandlerInterface) {
+func SetupMovieHandlers(router *mux.Router, movieHandler movieDelivery.MovieHandlerInterface, adminMiddleware mux.MiddlewareFunc) {
 	router.HandleFunc("/movie/{movie_id}", movieHandler.GetMovie).Methods(http.MethodGet, http.MethodOptions).Name("MovieRoute")
+	router.HandleFunc("/movies", movieHandler.ListMovies).Methods(http.MethodGet, http.MethodOptions).Name("MoviesRoute")
+
+	// catalog is edited on public paths, so admin check wraps handlers instead of subrouter
+	router.Handle("/movie", adminMiddleware(http.HandlerFunc(movieHandler.CreateMovie))).Methods(http.MethodPost, http.MethodOptions).Name("CreateMovieRoute")
+	router.Handle("/movie/{movie_id}", adminMiddleware(http.HandlerFunc(movieHandler.UpdateMovie))).Methods(http.MethodPut, http.MethodOptions).Name("UpdateMovieRoute")
+	router.Handle("/movie/{movie_id}", adminMiddleware(http.HandlerFunc(movieHandler.PatchMovie))).Methods(http.MethodPatch, http.MethodOptions).Name("PatchMovieRoute")
+	router.Handle("/movie/{movie_id}", adminMiddleware(http.HandlerFunc(movieHandler.DeleteMovie))).Methods(http.MethodDelete, http.MethodOptions).Name("DeleteMovieRoute")
+}
+
+func SetupReviewHandlers(router *mux.Router, reviewHandler reviewDelivery.ReviewHandlerInterface) {
+	router.HandleFunc("/movie/{movie_id}/reviews", reviewHandler.ListReviews).Methods(http.MethodGet, http.MethodOptions).Name("ReviewsRoute")
+	router.HandleFunc("/movie/{movie_id}/reviews", reviewHandler.CreateReview).Methods(http.MethodPost, http.MethodOptions).Name("CreateReviewRoute")
+	router.HandleFunc("/movie/{movie_id}/reviews", reviewHandler.UpdateReview).Methods(http.MethodPut, http.MethodOptions).Name("UpdateReviewRoute")
+	router.HandleFunc("/movie/{movie_id}/reviews", reviewHandler.DeleteReview).Methods(http.MethodDelete, http.MethodOptions).Name("DeleteReviewRoute")
+	router.HandleFunc("/movie/{movie_id}/reviews/{review_id}/reaction", reviewHandler.React).Methods(http.MethodPut, http.MethodOptions).Name("ReactionRoute")
+	router.HandleFunc("/movie/{movie_id}/reviews/{review_id}/reaction", reviewHandler.DeleteReaction).Methods(http.MethodDelete, http.MethodOptions).Name("DeleteReactionRoute")
+}
+
+func SetupRatingHandlers(router *mux.Router, ratingHandler ratingDelivery.RatingHandlerInterface) {
+	router.HandleFunc("/movie/{movie_id}/rating", ratingHandler.GetRating).Methods(http.MethodGet, http.MethodOptions).Name("RatingRoute")
+	router.HandleFunc("/movie/{movie_id}/rating", ratingHandler.SetRating).Methods(http.MethodPut, http.MethodOptions).Name("SetRatingRoute")
+	router.HandleFunc("/movie/{movie_id}/rating", ratingHandler.DeleteRating).Methods(http.MethodDelete, http.MethodOptions).Name("DeleteRatingRoute")
+}
+
+func SetupGenreHandlers(router *mux.Router, genreHandler genreDelivery.GenreHandlerInterface) {
+	router.HandleFunc("/genres", genreHandler.GetGenres).Methods(http.MethodGet, http.MethodOptions).Name("GenresRoute")
+	router.HandleFunc("/genres/{genre_id}", genreHandler.GetGenre).Methods(http.MethodGet, http.MethodOptions).Name("GenreRoute")
+}
+
+func SetupSearchHandlers(router *mux.Router, searchHandler searchDelivery.SearchHandlerInterface) {
+	router.HandleFunc("/search/movies", searchHandler.SearchMovies).Methods(http.MethodGet, http.MethodOptions).Name("SearchMoviesRoute")
+}
+
+func SetupRecommendationHandlers(router *mux.Router, recommendationHandler recommendationDelivery.RecommendationHandlerInterface) {
+	router.HandleFunc("/movie/{movie_id}/similar", recommendationHandler.GetSimilarMovies).Methods(http.MethodGet, http.MethodOptions).Name("SimilarMoviesRoute")
+	router.HandleFunc("/users/me/recommendations", recommendationHandler.GetRecommendations).Methods(http.MethodGet, http.MethodOptions).Name("RecommendationsRoute")
+}
+
+func SetupTrendingHandlers(router *mux.Router, trendingHandler trendingDelivery.TrendingHandlerInterface) {
+	router.HandleFunc("/movies/trending", trendingHandler.GetTrending).Methods(http.MethodGet, http.MethodOptions).Name("TrendingRoute")
+}
+
+func SetupPremiereHandlers(router *mux.Router, premiereHandler premiereDelivery.PremiereHandlerInterface) {
+	router.HandleFunc("/premieres", premiereHandler.GetCalendar).Methods(http.MethodGet, http.MethodOptions).Name("PremieresRoute")
 }
 
 func SetupUserHandlers(router *mux.Router, userHandler userDelivery.UserHandlerInterface) {
 	router.HandleFunc("/users", userHandler.UpdateUser).Methods(http.MethodPost, http.MethodOptions).Name("UpdateUserRoute")
 }
 
-func ApplyMiddlewares(router *mux.Router) {
+func SetupWatchlistHandlers(router *mux.Router, watchlistHandler watchlistDelivery.WatchlistHandlerInterface) {
+	router.HandleFunc("/users/me/watchlist", watchlistHandler.GetWatchlist).Methods(http.MethodGet, http.MethodOptions).Name("WatchlistRoute")
+	router.HandleFunc("/users/me/watchlist", watchlistHandler.AddMovies).Methods(http.MethodPost, http.MethodOptions).Name("AddToWatchlistRoute")
+	router.HandleFunc("/users/me/watchlist", watchlistHandler.RemoveMovies).Methods(http.MethodDelete, http.MethodOptions).Name("RemoveFromWatchlistRoute")
+}
+
+func SetupDiaryHandlers(router *mux.Router, diaryHandler diaryDelivery.DiaryHandlerInterface) {
+	router.HandleFunc("/users/me/diary", diaryHandler.GetDiary).Methods(http.MethodGet, http.MethodOptions).Name("DiaryRoute")
+	router.HandleFunc("/users/me/diary", diaryHandler.CreateEntry).Methods(http.MethodPost, http.MethodOptions).Name("CreateDiaryEntryRoute")
+	router.HandleFunc("/users/me/diary/{entry_id}", diaryHandler.UpdateEntry).Methods(http.MethodPut, http.MethodOptions).Name("UpdateDiaryEntryRoute")
+	router.HandleFunc("/users/me/diary/{entry_id}", diaryHandler.DeleteEntry).Methods(http.MethodDelete, http.MethodOptions).Name("DeleteDiaryEntryRoute")
+}
+
+func SetupBackupHandlers(router *mux.Router, backupHandler backupDelivery.BackupHandlerInterface, adminMiddleware mux.MiddlewareFunc) {
+	adminSubRouter := router.PathPrefix("/admin").Subrouter()
+	adminSubRouter.Use(adminMiddleware)
+
+	adminSubRouter.HandleFunc("/backup", backupHandler.Backup).Methods(http.MethodPost, http.MethodOptions).Name("BackupRoute")
+	adminSubRouter.HandleFunc("/restore", backupHandler.Restore).Methods(http.MethodPost, http.MethodOptions).Name("RestoreRoute")
+}
+
+// ApplyMiddlewares adds middlewares of all routes, authMiddleware puts login of logged in user to request context
+func ApplyMiddlewares(router *mux.Router, authMiddleware mux.MiddlewareFunc) {
 	router.Use(middleware.RequestWithLoggerMiddleware)
 	router.Use(middleware.PreventPanicMiddleware)
 	router.Use(middleware.MiddlewareCors)
+	router.Use(authMiddleware)
 }
@@ -8,16 +8,43 @@ import (
 	deliveryAuth "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/auth/delivery"
 	repoAuthSessions "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/auth/repository"
 	serviceAuth "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/auth/service"
+	deliveryBackup "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/backup/delivery"
+	serviceBackup "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/backup/service"
+	deliveryDiary "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/diary/delivery"
+	repoDiary "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/diary/repository"
+	serviceDiary "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/diary/service"
+	deliveryGenre "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/genre/delivery"
+	repoGenre "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/genre/repository"
+	serviceGenre "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/genre/service"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/middleware"
 	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
 	deliveryMovie "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/delivery"
 	repoMovie "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/repository"
 	serviceMovie "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/service"
+	deliveryPremiere "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/premiere/delivery"
+	servicePremiere "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/premiere/service"
+	deliveryRating "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/rating/delivery"
+	repoRating "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/rating/repository"
+	serviceRating "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/rating/service"
+	deliveryRecommendation "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/recommendation/delivery"
+	serviceRecommendation "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/recommendation/service"
+	deliveryReview "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/review/delivery"
+	serviceReview "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/review/service"
+	deliverySearch "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/search/delivery"
+	serviceSearch "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/search/service"
 	deliveryStaff "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/staff_person/delivery"
 	repoStaff "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/staff_person/repository"
 	serviceStaff "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/staff_person/service"
+	deliveryTrending "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/trending/delivery"
+	repoTrending "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/trending/repository"
+	serviceTrending "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/trending/service"
 	deliveryUsers "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/user/delivery/http"
 	repoUsers "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/user/repository"
 	serviceUsers "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/user/service"
+	deliveryWatchlist "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/watchlist/delivery"
+	repoWatchlist "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/watchlist/repository"
+	serviceWatchlist "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/watchlist/service"
 	"github.com/rs/zerolog/log"
 	"github.com/stretchr/testify/require"
 
@@ -40,31 +67,82 @@ func TestSetup(t *testing.T) {
 	sessionService := serviceAuth.NewSessionService(config.WrapCookieContext(context.Background(), &cfg.Cookie), sessionRepo)
 
 	userRepo := repoUsers.NewUserRepository()
-	userService := serviceUsers.NewUserService(userRepo)
+	userService := serviceUsers.NewUserService(userRepo, cfg.Admin.Logins())
 	userHandler := deliveryUsers.NewUserHandler(config.WrapCookieContext(context.Background(), &cfg.Cookie), userService, sessionService)
 
 	authHandler := deliveryAuth.NewAuthHandler(config.WrapCookieContext(context.Background(), &cfg.Cookie), userService, sessionService)
 
 	staffPersonRepo := repoStaff.NewStaffPersonRepository(&mocks.ExistingActors)
-	staffPersonService := serviceStaff.NewStaffPersonService(staffPersonRepo)
+	genreRepo := repoGenre.NewGenreRepository(&mocks.ExistingGenres)
+
+	movieRepo := repoMovie.NewMovieRepository(&mocks.ExistingMovies)
+	ratingRepo := repoRating.NewRatingRepository()
+	watchlistRepo := repoWatchlist.NewWatchlistRepository()
+
+	trendingService := serviceTrending.NewTrendingService(movieRepo, ratingRepo, watchlistRepo, repoTrending.NewViewRepository(), models.TrendingSettings{})
+	trendingHandler := deliveryTrending.NewTrendingHandler(trendingService)
+
+	movieService := serviceMovie.NewMovieService(movieRepo, genreRepo, staffPersonRepo, trendingService)
+
+	staffPersonService := serviceStaff.NewStaffPersonService(staffPersonRepo, movieRepo)
 	staffPersonHandler := deliveryStaff.NewStaffPersonHandler(staffPersonService)
 
 	collectionRepo := repoCollection.NewCollectionRepository(&mocks.MainPageCollections)
-	collectionService := serviceCollection.NewCollectionService(collectionRepo)
-	collectionHandler := deliveryCollection.NewCollectionHandler(collectionService)
+	userListRepo := repoCollection.NewUserListRepository()
+	collectionService := serviceCollection.NewCollectionService(collectionRepo, userListRepo, movieRepo, userRepo, movieService)
 
-	movieRepo := repoMovie.NewMovieRepository(&mocks.ExistingMovies)
-	movieService := serviceMovie.NewMovieService(movieRepo)
-	movieHandler := deliveryMovie.NewMovieHandler(movieService)
+	ratingService := serviceRating.NewRatingService(ratingRepo, movieRepo, userRepo)
+	ratingHandler := deliveryRating.NewRatingHandler(ratingService)
+
+	watchlistService := serviceWatchlist.NewWatchlistService(watchlistRepo, movieRepo, userRepo)
+	watchlistHandler := deliveryWatchlist.NewWatchlistHandler(watchlistService)
+
+	collectionHandler := deliveryCollection.NewCollectionHandler(collectionService, watchlistService)
+
+	premiereService := servicePremiere.NewPremiereService(movieRepo, watchlistService, models.PremiereSettings{})
+	premiereHandler := deliveryPremiere.NewPremiereHandler(premiereService)
+
+	diaryRepo := repoDiary.NewDiaryRepository()
+	diaryService := serviceDiary.NewDiaryService(diaryRepo, ratingRepo, movieRepo, userRepo)
+	diaryHandler := deliveryDiary.NewDiaryHandler(diaryService)
+
+	movieHandler := deliveryMovie.NewMovieHandler(movieService, ratingService, watchlistService, trendingService)
+
+	reviewService := serviceReview.NewReviewService(movieRepo, userRepo)
+	reviewHandler := deliveryReview.NewReviewHandler(reviewService)
+
+	genreService := serviceGenre.NewGenreService(genreRepo, movieRepo)
+	genreHandler := deliveryGenre.NewGenreHandler(genreService)
+
+	searchService := serviceSearch.NewSearchService(movieRepo)
+	searchHandler := deliverySearch.NewSearchHandler(searchService)
+
+	recommendationService := serviceRecommendation.NewRecommendationService(movieRepo, ratingRepo, userRepo, models.RecommendationSettings{})
+	recommendationHandler := deliveryRecommendation.NewRecommendationHandler(recommendationService)
+
+	backupService := serviceBackup.NewBackupService(cfg.Snapshot.Path, cfg.Snapshot.Interval)
+	backupHandler := deliveryBackup.NewBackupHandler(backupService)
+	authMiddleware := middleware.NewAuthMiddleware(cfg.Cookie.SessionName, sessionService)
+	adminMiddleware := middleware.NewAdminMiddleware(cfg.Cookie.SessionName, sessionService, cfg.Admin.Logins())
 
 	mx := NewRouter()
 
 	log.Info().Msg("Configuring routes")
 
-	ApplyMiddlewares(mx)
+	ApplyMiddlewares(mx, authMiddleware)
 	SetupAuth(mx, authHandler)
-	SetupCollections(mx, collectionHandler)
-	SetupStaffPersonHandlers(mx, staffPersonHandler)
+	SetupCollections(mx, collectionHandler, adminMiddleware)
+	SetupStaffPersonHandlers(mx, staffPersonHandler, adminMiddleware)
 	SetupUserHandlers(mx, userHandler)
-	SetupMovieHandlers(mx, movieHandler)
+	SetupMovieHandlers(mx, movieHandler, adminMiddleware)
+	SetupReviewHandlers(mx, reviewHandler)
+	SetupRatingHandlers(mx, ratingHandler)
+	SetupWatchlistHandlers(mx, watchlistHandler)
+	SetupDiaryHandlers(mx, diaryHandler)
+	SetupGenreHandlers(mx, genreHandler)
+	SetupSearchHandlers(mx, searchHandler)
+	SetupRecommendationHandlers(mx, recommendationHandler)
+	SetupTrendingHandlers(mx, trendingHandler)
+	SetupPremiereHandlers(mx, premiereHandler)
+	SetupBackupHandlers(mx, backupHandler, adminMiddleware)
 }
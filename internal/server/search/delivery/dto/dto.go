@@ -0,0 +1,24 @@
+package dto
+
+import (
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	movieDTO "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/delivery/dto"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
+)
+
+// SearchMoviesJSON found movies ordered by relevance
+type SearchMoviesJSON struct {
+	Query  string                    `json:"query"`
+	Movies []movieDTO.MovieShortJSON `json:"movies"`
+}
+
+func NewSearchMoviesJSON(query string, movies []models.Movie, locale l10n.Locale) SearchMoviesJSON {
+	res := SearchMoviesJSON{
+		Query:  query,
+		Movies: make([]movieDTO.MovieShortJSON, 0, len(movies)),
+	}
+	for _, movie := range movies {
+		res.Movies = append(res.Movies, movieDTO.NewMovieShortJSON(movie, locale))
+	}
+	return res
+}
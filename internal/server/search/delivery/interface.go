@@ -0,0 +1,7 @@
+package delivery
+
+import "net/http"
+
+type SearchHandlerInterface interface {
+	SearchMovies(w http.ResponseWriter, r *http.Request)
+}
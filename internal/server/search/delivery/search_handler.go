@@ -0,0 +1,82 @@
+package delivery
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/search/delivery/dto"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/jsonutil"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+
+	// localeParam query parameter requesting localized genre names
+	localeParam = "locale"
+)
+
+type SearchServiceInterface interface {
+	SearchMovies(ctx context.Context, query string, filter models.MovieFilter, limit int) ([]models.Movie, error)
+}
+
+type SearchHandler struct {
+	searchService SearchServiceInterface
+}
+
+func NewSearchHandler(searchService SearchServiceInterface) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+	}
+}
+
+func (h *SearchHandler) SearchMovies(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		logger.Error().Msg(errs.ErrEmptySearchQuery)
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrEmptySearchQueryShort, errs.ErrEmptySearchQuery)
+		return
+	}
+
+	limit := defaultLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 || limit > maxLimit {
+			logger.Error().Str("limit", limitStr).Msg("searchMovies action: bad limit")
+			jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, errs.ErrBadPayload)
+			return
+		}
+	}
+
+	// search results are filtered the same way as catalog
+	filter, err := models.ParseMovieFilter(r.URL.Query())
+	if err != nil {
+		errMsg := errors.Wrap(err, "searchMovies action: bad filter")
+		logger.Error().Err(errMsg).Msg(errMsg.Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, err.Error())
+		return
+	}
+
+	logger.Info().Msgf("searching movies: %q", query)
+	movies, err := h.searchService.SearchMovies(r.Context(), query, filter, limit)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		jsonutil.SendError(r.Context(), w, http.StatusInternalServerError, errs.ErrSomethingWentWrong, errs.ErrSomethingWentWrong)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, dto.NewSearchMoviesJSON(query, movies, l10n.ParseLocale(r.URL.Query().Get(localeParam)))); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/textsearch"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// relevance weights of movie fields
+const (
+	nameWeight         = 3
+	originalNameWeight = 3
+	staffWeight        = 2
+	sloganWeight       = 1
+	aboutWeight        = 0.5
+)
+
+type MovieRepositoryInterface interface {
+	GetMovieFromRepoByID(ctx context.Context, movieID int) (*models.Movie, error)
+	GetAllMovies(ctx context.Context) ([]models.Movie, error)
+}
+
+// SearchService full-text movie search over in-memory index
+type SearchService struct {
+	movieRepo MovieRepositoryInterface
+	index     *textsearch.Index
+}
+
+func NewSearchService(movieRepo MovieRepositoryInterface) *SearchService {
+	return &SearchService{
+		movieRepo: movieRepo,
+		index:     textsearch.NewIndex(),
+	}
+}
+
+// Reindex builds index from all movies in repository
+func (s *SearchService) Reindex(ctx context.Context) error {
+	logger := log.Ctx(ctx)
+
+	movies, err := s.movieRepo.GetAllMovies(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return err
+	}
+
+	for _, movie := range movies {
+		s.index.Upsert(movie.ID, movieFields(movie)...)
+	}
+
+	logger.Info().Int("movies", s.index.Len()).Msg("search index built")
+	return nil
+}
+
+// OnMovieUpsert keeps index up to date with movie repository
+func (s *SearchService) OnMovieUpsert(ctx context.Context, movie models.Movie) {
+	s.index.Upsert(movie.ID, movieFields(movie)...)
+}
+
+// OnMovieDelete keeps index up to date with movie repository
+func (s *SearchService) OnMovieDelete(ctx context.Context, movieID int) {
+	s.index.Remove(movieID)
+}
+
+func movieFields(movie models.Movie) []textsearch.Field {
+	staff := make([]string, 0, 2*len(movie.Staff))
+	for _, member := range movie.Staff {
+		staff = append(staff, member.Person.FullName, member.Person.EnFullName)
+	}
+
+	return []textsearch.Field{
+		{Text: movie.Name, Weight: nameWeight},
+		{Text: movie.OriginalName, Weight: originalNameWeight},
+		{Text: strings.Join(staff, " "), Weight: staffWeight},
+		{Text: movie.Slogan, Weight: sloganWeight},
+		{Text: movie.About, Weight: aboutWeight},
+	}
+}
+
+// SearchMovies returns at most limit movies matching query and filter, the most relevant first.
+// Non-positive limit means no limit
+func (s *SearchService) SearchMovies(ctx context.Context, query string, filter models.MovieFilter, limit int) ([]models.Movie, error) {
+	logger := log.Ctx(ctx)
+
+	// filter is applied after ranking, so index is asked for all hits
+	hits := s.index.Search(query, 0)
+
+	var res []models.Movie
+	for _, hit := range hits {
+		if limit > 0 && len(res) == limit {
+			break
+		}
+
+		movie, err := s.movieRepo.GetMovieFromRepoByID(ctx, hit.ID)
+		if errors.Is(err, errs.ErrMovieNotFound) {
+			// movie was deleted after search, index will be updated by repository
+			continue
+		}
+		if err != nil {
+			logger.Error().Err(err).Msg(err.Error())
+			return nil, err
+		}
+		if filter.Matches(*movie) {
+			res = append(res, *movie)
+		}
+	}
+
+	return res, nil
+}
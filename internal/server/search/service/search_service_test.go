@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	repoMovie "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func movieIDs(movies []models.Movie) []int {
+	ids := make([]int, 0, len(movies))
+	for _, movie := range movies {
+		ids = append(ids, movie.ID)
+	}
+	return ids
+}
+
+func TestSearchService_SearchMovies(t *testing.T) {
+	ctx := context.Background()
+
+	movies := mocks.Movies{
+		1: {ID: 1, Name: "Бойцовский клуб", OriginalName: "Fight Club", Staff: []models.StaffMember{{Person: models.Person{FullName: "Брэд Питт"}}}},
+		2: {ID: 2, Name: "Матрица", OriginalName: "The Matrix", Slogan: "Добро пожаловать в реальный мир"},
+		3: {ID: 3, Name: "Клуб первых жён", About: "Три подруги мстят бывшим мужьям", Genres: []models.Genre{{ID: 2}}},
+	}
+	movieRepo := repoMovie.NewMovieRepository(&movies)
+
+	s := NewSearchService(movieRepo)
+	require.NoError(t, s.Reindex(ctx))
+	movieRepo.Subscribe(s)
+
+	tests := []struct {
+		name   string
+		query  string
+		filter models.MovieFilter
+		limit  int
+		want   []int
+	}{
+		{name: "name", query: "бойцовский клуб", want: []int{1, 3}},
+		{name: "limit", query: "бойцовский клуб", limit: 1, want: []int{1}},
+		{name: "genre", query: "клуб", filter: models.MovieFilter{GenreIDs: []int{2}}, want: []int{3}},
+		{name: "original name", query: "matrix", want: []int{2}},
+		{name: "transliteration", query: "bojcovskij", want: []int{1}},
+		{name: "typo", query: "матрийа", want: []int{2}},
+		{name: "staff", query: "питт", want: []int{1}},
+		{name: "slogan", query: "реальном мире", want: []int{2}},
+		{name: "about", query: "подруг", want: []int{3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.SearchMovies(ctx, tt.query, tt.filter, tt.limit)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, movieIDs(res))
+		})
+	}
+}
+
+func TestSearchService_IncrementalUpdates(t *testing.T) {
+	ctx := context.Background()
+
+	movies := mocks.Movies{1: {ID: 1, Name: "Матрица"}}
+	movieRepo := repoMovie.NewMovieRepository(&movies)
+
+	s := NewSearchService(movieRepo)
+	require.NoError(t, s.Reindex(ctx))
+	movieRepo.Subscribe(s)
+
+	require.NoError(t, movieRepo.UpsertMovie(ctx, models.Movie{ID: 2, Name: "Терминатор"}))
+	res, err := s.SearchMovies(ctx, "терминатор", models.MovieFilter{}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []int{2}, movieIDs(res))
+
+	snapshot, err := repoMovie.NewMovieRepository(&mocks.Movies{3: {ID: 3, Name: "Чужой"}}).Snapshot(ctx)
+	require.NoError(t, err)
+	require.NoError(t, movieRepo.Restore(ctx, snapshot))
+
+	res, err = s.SearchMovies(ctx, "матрица терминатор", models.MovieFilter{}, 0)
+	require.NoError(t, err)
+	assert.Empty(t, res)
+
+	res, err = s.SearchMovies(ctx, "чужой", models.MovieFilter{}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []int{3}, movieIDs(res))
+}
@@ -2,13 +2,19 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/go-park-mail-ru/2025_1_sigmaScript/config"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/generator"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/importer"
 	deliveryAuth "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/auth/delivery"
 	repoAuthSessions "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/auth/repository"
 	serviceAuth "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/auth/service"
+	deliveryBackup "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/backup/delivery"
+	serviceBackup "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/backup/service"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/middleware"
 	repoUsers "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/user/repository"
 
 	deliveryUsers "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/user/delivery/http"
@@ -18,6 +24,7 @@ import (
 	repoCollection "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/collection/repository"
 	serviceCollection "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/collection/service"
 	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
 	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/router"
 
 	deliveryStaff "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/staff_person/delivery"
@@ -28,17 +35,91 @@ import (
 	repoMovie "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/repository"
 	serviceMovie "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/service"
 
+	deliveryDiary "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/diary/delivery"
+	repoDiary "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/diary/repository"
+	serviceDiary "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/diary/service"
+	deliveryRating "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/rating/delivery"
+	repoRating "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/rating/repository"
+	serviceRating "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/rating/service"
+	deliveryReview "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/review/delivery"
+	serviceReview "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/review/service"
+	deliveryWatchlist "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/watchlist/delivery"
+	repoWatchlist "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/watchlist/repository"
+	serviceWatchlist "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/watchlist/service"
+
+	deliveryGenre "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/genre/delivery"
+	repoGenre "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/genre/repository"
+	serviceGenre "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/genre/service"
+
+	deliveryPremiere "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/premiere/delivery"
+	servicePremiere "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/premiere/service"
+	repoRanking "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/ranking/repository"
+	serviceRanking "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/ranking/service"
+	deliveryRecommendation "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/recommendation/delivery"
+	serviceRecommendation "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/recommendation/service"
+	deliverySearch "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/search/delivery"
+	serviceSearch "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/search/service"
+	deliveryTrending "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/trending/delivery"
+	repoTrending "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/trending/repository"
+	serviceTrending "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/trending/service"
+
 	"github.com/rs/zerolog/log"
 )
 
 type Server struct {
-	Config     *config.Config
-	httpServer *http.Server
+	Config                *config.Config
+	httpServer            *http.Server
+	backupService         *serviceBackup.BackupService
+	recommendationService *serviceRecommendation.RecommendationService
+	trendingService       *serviceTrending.TrendingService
+	rankingService        *serviceRanking.RankingService
 }
 
 func (s *Server) Shutdown(ctx context.Context) error {
 	log.Info().Msg("Shutting down server")
-	return s.httpServer.Shutdown(ctx)
+	// background services are stopped and snapshot is saved even if connections are not closed in time
+	httpErr := s.httpServer.Shutdown(ctx)
+	if httpErr != nil {
+		log.Error().Err(httpErr).Msg(httpErr.Error())
+	}
+
+	if s.recommendationService != nil {
+		s.recommendationService.Stop()
+	}
+
+	if s.rankingService != nil {
+		s.rankingService.Stop()
+	}
+
+	// counted views are saved before final snapshot
+	if s.trendingService != nil {
+		s.trendingService.Stop()
+	}
+
+	if s.backupService != nil {
+		log.Info().Msg("Saving snapshot")
+		if err := s.backupService.Stop(log.Logger.WithContext(ctx)); err != nil {
+			return errors.Join(httpErr, err)
+		}
+	}
+	return httpErr
+}
+
+// reserveReviewAuthorIDs makes user repository assign ids above ids of all review authors
+func reserveReviewAuthorIDs(ctx context.Context, movieRepo *repoMovie.MovieRepository, userRepo *repoUsers.UserRepository) error {
+	movies, err := movieRepo.GetStoredMovies(ctx)
+	if err != nil {
+		return err
+	}
+
+	lastID := 0
+	for _, movie := range movies {
+		for _, review := range movie.Reviews {
+			lastID = max(lastID, review.User.ID)
+		}
+	}
+	userRepo.ReserveIDs(lastID)
+	return nil
 }
 
 func New(cfg *config.Config) *Server {
@@ -57,33 +138,224 @@ func (s *Server) Run() error {
 	sessionService := serviceAuth.NewSessionService(config.WrapCookieContext(context.Background(), &s.Config.Cookie), sessionRepo)
 
 	userRepo := repoUsers.NewUserRepository()
-	userService := serviceUsers.NewUserService(userRepo)
+	userService := serviceUsers.NewUserService(userRepo, s.Config.Admin.Logins())
 	userHandler := deliveryUsers.NewUserHandler(config.WrapCookieContext(context.Background(), &s.Config.Cookie), userService, sessionService)
 
 	authHandler := deliveryAuth.NewAuthHandler(config.WrapCookieContext(context.Background(), &s.Config.Cookie), userService, sessionService)
 
 	staffPersonRepo := repoStaff.NewStaffPersonRepository(&mocks.ExistingActors)
-	staffPersonService := serviceStaff.NewStaffPersonService(staffPersonRepo)
+	genreRepo := repoGenre.NewGenreRepository(&mocks.ExistingGenres)
+
+	movieRepo := repoMovie.NewMovieRepository(&mocks.ExistingMovies)
+	ratingRepo := repoRating.NewRatingRepository()
+	watchlistRepo := repoWatchlist.NewWatchlistRepository()
+	viewRepo := repoTrending.NewViewRepository()
+
+	trendingCfg := s.Config.Trending
+	trendingService := serviceTrending.NewTrendingService(movieRepo, ratingRepo, watchlistRepo, viewRepo, models.TrendingSettings{
+		Weights: models.TrendingWeights{
+			View:      trendingCfg.ViewWeight,
+			Rating:    trendingCfg.RatingWeight,
+			Review:    trendingCfg.ReviewWeight,
+			Watchlist: trendingCfg.WatchlistWeight,
+		},
+		CacheTTL:          trendingCfg.CacheTTL,
+		ViewBuffer:        trendingCfg.ViewBuffer,
+		ViewFlushInterval: trendingCfg.ViewFlushInterval,
+	})
+	trendingHandler := deliveryTrending.NewTrendingHandler(trendingService)
+
+	movieService := serviceMovie.NewMovieService(movieRepo, genreRepo, staffPersonRepo, trendingService)
+
+	staffPersonService := serviceStaff.NewStaffPersonService(staffPersonRepo, movieRepo)
 	staffPersonHandler := deliveryStaff.NewStaffPersonHandler(staffPersonService)
 
 	collectionRepo := repoCollection.NewCollectionRepository(&mocks.MainPageCollections)
-	collectionService := serviceCollection.NewCollectionService(collectionRepo)
-	collectionHandler := deliveryCollection.NewCollectionHandler(collectionService)
+	userListRepo := repoCollection.NewUserListRepository()
+	collectionService := serviceCollection.NewCollectionService(collectionRepo, userListRepo, movieRepo, userRepo, movieService)
 
-	movieRepo := repoMovie.NewMovieRepository(&mocks.ExistingMovies)
-	movieService := serviceMovie.NewMovieService(movieRepo)
-	movieHandler := deliveryMovie.NewMovieHandler(movieService)
+	rankingCfg := s.Config.Ranking
+	rankingRepo := repoRanking.NewRankingRepository()
+	rankingService := serviceRanking.NewRankingService(movieRepo, ratingRepo, rankingRepo, models.RankingSettings{
+		Size:              rankingCfg.Size,
+		MinVotes:          rankingCfg.MinVotes,
+		RecomputeInterval: rankingCfg.RecomputeInterval,
+		MovementPeriod:    rankingCfg.MovementPeriod,
+		HistoryRetention:  rankingCfg.HistoryRetention,
+	})
+	collectionService.RegisterBuiltin(models.BuiltinTop250, rankingService)
+
+	ratingService := serviceRating.NewRatingService(ratingRepo, movieRepo, userRepo)
+	ratingHandler := deliveryRating.NewRatingHandler(ratingService)
+
+	watchlistService := serviceWatchlist.NewWatchlistService(watchlistRepo, movieRepo, userRepo)
+	watchlistHandler := deliveryWatchlist.NewWatchlistHandler(watchlistService)
+
+	collectionHandler := deliveryCollection.NewCollectionHandler(collectionService, watchlistService)
+
+	premiereCfg := s.Config.Premieres
+	premiereService := servicePremiere.NewPremiereService(movieRepo, watchlistService, models.PremiereSettings{
+		DefaultDays:       premiereCfg.DefaultDays,
+		MaxDays:           premiereCfg.MaxDays,
+		ComingSoonDays:    premiereCfg.ComingSoonDays,
+		ComingSoonCountry: premiereCfg.ComingSoonCountry,
+	})
+	if err := premiereService.Reindex(log.Logger.WithContext(context.Background())); err != nil {
+		return err
+	}
+	movieRepo.Subscribe(premiereService)
+	collectionService.RegisterBuiltin(models.BuiltinComingSoon, premiereService)
+	premiereHandler := deliveryPremiere.NewPremiereHandler(premiereService)
+
+	diaryRepo := repoDiary.NewDiaryRepository()
+	diaryService := serviceDiary.NewDiaryService(diaryRepo, ratingRepo, movieRepo, userRepo)
+	diaryHandler := deliveryDiary.NewDiaryHandler(diaryService)
+
+	movieHandler := deliveryMovie.NewMovieHandler(movieService, ratingService, watchlistService, trendingService)
+
+	reviewService := serviceReview.NewReviewService(movieRepo, userRepo)
+	reviewHandler := deliveryReview.NewReviewHandler(reviewService)
+
+	genreService := serviceGenre.NewGenreService(genreRepo, movieRepo)
+	genreHandler := deliveryGenre.NewGenreHandler(genreService)
+
+	searchService := serviceSearch.NewSearchService(movieRepo)
+	if err := searchService.Reindex(log.Logger.WithContext(context.Background())); err != nil {
+		return err
+	}
+	movieRepo.Subscribe(searchService)
+	movieRepo.Subscribe(collectionService)
+	searchHandler := deliverySearch.NewSearchHandler(searchService)
+
+	similarCfg, personalCfg := s.Config.Recommendations.Similar, s.Config.Recommendations.Personal
+	recommendationService := serviceRecommendation.NewRecommendationService(movieRepo, ratingRepo, userRepo, models.RecommendationSettings{
+		Similar: models.SimilarityWeights{
+			Genre:   similarCfg.GenreWeight,
+			Staff:   similarCfg.StaffWeight,
+			Era:     similarCfg.EraWeight,
+			Country: similarCfg.CountryWeight,
+			EraSpan: similarCfg.EraSpan,
+		},
+		RecomputeInterval: personalCfg.RecomputeInterval,
+		ColdStartRatings:  personalCfg.ColdStartRatings,
+	})
+	if err := recommendationService.Reindex(log.Logger.WithContext(context.Background())); err != nil {
+		return err
+	}
+	movieRepo.Subscribe(recommendationService)
+	recommendationHandler := deliveryRecommendation.NewRecommendationHandler(recommendationService)
+
+	catalogImporter := importer.New(genreRepo, movieRepo, staffPersonRepo, collectionRepo)
+	if s.Config.Catalog.FixturesDir != "" {
+		log.Info().Str("dir", s.Config.Catalog.FixturesDir).Msg("Importing catalog fixtures")
+
+		catalog, err := importer.Load(s.Config.Catalog.FixturesDir)
+		if err != nil {
+			return err
+		}
+
+		if _, err = catalogImporter.Import(log.Logger.WithContext(context.Background()), catalog, false); err != nil {
+			return err
+		}
+	}
+
+	if genCfg := s.Config.Catalog.Generator; genCfg.Enabled {
+		log.Info().Int64("seed", genCfg.Seed).Msg("Seeding synthetic dataset")
+
+		opts := generator.DefaultOptions()
+		opts.Seed, opts.Movies, opts.Persons, opts.Users = genCfg.Seed, genCfg.Movies, genCfg.Persons, genCfg.Users
+
+		dataset := generator.Generate(opts)
+		if err := dataset.Seed(log.Logger.WithContext(context.Background()), catalogImporter, movieRepo, userRepo); err != nil {
+			return err
+		}
+	}
+
+	backupService := serviceBackup.NewBackupService(s.Config.Snapshot.Path, s.Config.Snapshot.Interval)
+	backupService.Register("users", userRepo)
+	backupService.Register("sessions", sessionRepo)
+	backupService.Register("genres", genreRepo)
+	backupService.Register("persons", staffPersonRepo)
+	backupService.Register("movies", movieRepo)
+	backupService.Register("collections", collectionRepo)
+	backupService.Register("user_lists", userListRepo)
+	backupService.Register("ratings", ratingRepo)
+	backupService.Register("watchlists", watchlistRepo)
+	backupService.Register("diaries", diaryRepo)
+	backupService.Register("views", viewRepo)
+	backupService.Register("rankings", rankingRepo)
+	backupHandler := deliveryBackup.NewBackupHandler(backupService)
+
+	if s.Config.Snapshot.RestoreOnStart {
+		if err := backupService.RestoreIfExists(log.Logger.WithContext(context.Background())); err != nil {
+			return err
+		}
+	}
+	backupService.Start(log.Logger.WithContext(context.Background()))
+	s.backupService = backupService
+
+	// built-in reviews are written by authors without accounts, new users must not take their ids
+	if err := reserveReviewAuthorIDs(log.Logger.WithContext(context.Background()), movieRepo, userRepo); err != nil {
+		return err
+	}
+
+	// admin accounts are created after restore, so registered users can not take their logins
+	for _, account := range s.Config.Admin.Accounts {
+		if err := userService.EnsureAdmin(log.Logger.WithContext(context.Background()), account.Login, account.PasswordHash); err != nil {
+			return err
+		}
+	}
+
+	// ratings are restored from snapshot, so recommendations are computed after restore
+	recommendationService.Start(log.Logger.WithContext(context.Background()))
+	s.recommendationService = recommendationService
+
+	trendingService.Start(log.Logger.WithContext(context.Background()))
+	s.trendingService = trendingService
+
+	// collections are restored from snapshot, so built-in ones are added after restore
+	if _, err := collectionService.EnsureBuiltin(log.Logger.WithContext(context.Background()), models.Collection{
+		Slug:        "top-250",
+		Name:        "Топ-250",
+		Description: "Лучшие фильмы по оценкам зрителей",
+		Builtin:     models.BuiltinTop250,
+	}); err != nil {
+		return err
+	}
+	if _, err := collectionService.EnsureBuiltin(log.Logger.WithContext(context.Background()), models.Collection{
+		Slug:        "coming-soon",
+		Name:        "Скоро в кино",
+		Description: "Премьеры ближайших месяцев",
+		Builtin:     models.BuiltinComingSoon,
+	}); err != nil {
+		return err
+	}
+	rankingService.Start(log.Logger.WithContext(context.Background()))
+	s.rankingService = rankingService
+
+	authMiddleware := middleware.NewAuthMiddleware(s.Config.Cookie.SessionName, sessionService)
+	adminMiddleware := middleware.NewAdminMiddleware(s.Config.Cookie.SessionName, sessionService, s.Config.Admin.Logins())
 
 	mx := router.NewRouter()
 
 	log.Info().Msg("Configuring routes")
 
-	router.ApplyMiddlewares(mx)
+	router.ApplyMiddlewares(mx, authMiddleware)
 	router.SetupAuth(mx, authHandler)
-	router.SetupCollections(mx, collectionHandler)
-	router.SetupStaffPersonHandlers(mx, staffPersonHandler)
+	router.SetupCollections(mx, collectionHandler, adminMiddleware)
+	router.SetupStaffPersonHandlers(mx, staffPersonHandler, adminMiddleware)
 	router.SetupUserHandlers(mx, userHandler)
-	router.SetupMovieHandlers(mx, movieHandler)
+	router.SetupMovieHandlers(mx, movieHandler, adminMiddleware)
+	router.SetupReviewHandlers(mx, reviewHandler)
+	router.SetupRatingHandlers(mx, ratingHandler)
+	router.SetupWatchlistHandlers(mx, watchlistHandler)
+	router.SetupDiaryHandlers(mx, diaryHandler)
+	router.SetupGenreHandlers(mx, genreHandler)
+	router.SetupSearchHandlers(mx, searchHandler)
+	router.SetupRecommendationHandlers(mx, recommendationHandler)
+	router.SetupTrendingHandlers(mx, trendingHandler)
+	router.SetupPremiereHandlers(mx, premiereHandler)
+	router.SetupBackupHandlers(mx, backupHandler, adminMiddleware)
 
 	log.Info().Msg("Routes configured successfully")
 
@@ -0,0 +1,211 @@
+package delivery
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/ds"
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/messages"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/staff_person/delivery/dto"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/validation/person"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/jsonutil"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// getPersonID parses person id from path, error response is sent if it is invalid
+func getPersonID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	logger := log.Ctx(r.Context())
+
+	personID, err := strconv.Atoi(mux.Vars(r)["person_id"])
+	if err != nil {
+		errMsg := errors.Wrap(err, "person action: bad person_id")
+		logger.Error().Err(errMsg).Msg(errMsg.Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, errs.ErrBadPayload)
+		return 0, false
+	}
+
+	return personID, true
+}
+
+// parseDate parses ISO-8601 date, empty string means date is unknown
+func parseDate(date string) (*time.Time, error) {
+	if date == "" {
+		return nil, nil
+	}
+	res, err := time.Parse(l10n.DateLayout, date)
+	if err != nil {
+		return nil, errors.Wrapf(err, "date %q", date)
+	}
+	return &res, nil
+}
+
+// parsePerson validates request and converts it to person
+func parsePerson(req dto.PersonRequest) (models.Person, error) {
+	res := models.Person{
+		FullName:   strings.TrimSpace(req.FullName),
+		EnFullName: strings.TrimSpace(req.EnFullName),
+		Photo:      strings.TrimSpace(req.Photo),
+		About:      strings.TrimSpace(req.About),
+		Sex:        strings.TrimSpace(req.Sex),
+		Growth:     req.Growth,
+	}
+
+	var err error
+	if res.Birthday, err = parseDate(req.Birthday); err != nil {
+		return res, err
+	}
+	if res.Death, err = parseDate(req.Death); err != nil {
+		return res, err
+	}
+
+	return res, person.IsValidPerson(res)
+}
+
+// readPerson reads and validates person from request body, error response is sent if it is invalid
+func readPerson(w http.ResponseWriter, r *http.Request) (models.Person, bool) {
+	logger := log.Ctx(r.Context())
+
+	var req dto.PersonRequest
+	if err := jsonutil.ReadJSON(r, &req); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrParseJSON)).Msg(errors.Wrap(err, errs.ErrParseJSON).Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errors.Wrap(err, errs.ErrParseJSONShort).Error(), errs.ErrBadPayload)
+		return models.Person{}, false
+	}
+
+	res, err := parsePerson(req)
+	if err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrInvalidPerson)).Msg(errors.Wrap(err, errs.ErrInvalidPerson).Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errors.Wrap(err, errs.ErrInvalidPersonShort).Error(),
+			errors.Wrap(err, errs.ErrInvalidPerson).Error())
+		return res, false
+	}
+
+	return res, true
+}
+
+func sendPersonError(ctx context.Context, w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, errs.ErrPersonNotFound):
+		jsonutil.SendError(ctx, w, http.StatusNotFound, errs.ErrNotFoundShort, err.Error())
+	case errors.Is(err, errs.ErrMergeSamePerson):
+		jsonutil.SendError(ctx, w, http.StatusBadRequest, errs.ErrInvalidPersonShort, err.Error())
+	default:
+		jsonutil.SendError(ctx, w, http.StatusInternalServerError, errs.ErrSomethingWentWrong, errs.ErrSomethingWentWrong)
+	}
+}
+
+// sendPerson responds with saved person, it is saved already, so career is left empty if it fails
+func (h *StaffPersonHandler) sendPerson(w http.ResponseWriter, r *http.Request, res *models.Person) {
+	logger := log.Ctx(r.Context())
+
+	career, err := h.staffPersonService.GetCareer(r.Context(), res.ID)
+	if err != nil {
+		logger.Warn().Err(err).Msg("person action: failed to compute career")
+		career = &models.Career{}
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, dto.NewPersonJSON(*res, *career, l10n.ParseLocale(r.URL.Query().Get(localeParam)), time.Now())); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+	}
+}
+
+func (h *StaffPersonHandler) CreatePerson(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	req, ok := readPerson(w, r)
+	if !ok {
+		return
+	}
+
+	logger.Info().Msgf("creating person %q", req.FullName)
+	res, err := h.staffPersonService.CreatePerson(r.Context(), req)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendPersonError(r.Context(), w, err)
+		return
+	}
+
+	h.sendPerson(w, r, res)
+}
+
+// UpdatePerson replaces all fields of person, movies show new name and photo in credits
+func (h *StaffPersonHandler) UpdatePerson(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	personID, ok := getPersonID(w, r)
+	if !ok {
+		return
+	}
+	req, ok := readPerson(w, r)
+	if !ok {
+		return
+	}
+	req.ID = personID
+
+	logger.Info().Msgf("updating person %d", personID)
+	res, err := h.staffPersonService.UpdatePerson(r.Context(), req)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendPersonError(r.Context(), w, err)
+		return
+	}
+
+	h.sendPerson(w, r, res)
+}
+
+// MergePerson moves credits of duplicate person to person from path and deletes duplicate
+func (h *StaffPersonHandler) MergePerson(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	personID, ok := getPersonID(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.MergeRequest
+	if err := jsonutil.ReadJSON(r, &req); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrParseJSON)).Msg(errors.Wrap(err, errs.ErrParseJSON).Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errors.Wrap(err, errs.ErrParseJSONShort).Error(), errs.ErrBadPayload)
+		return
+	}
+
+	logger.Info().Msgf("merging person %d into %d", req.DuplicateID, personID)
+	res, err := h.staffPersonService.MergePerson(r.Context(), personID, req.DuplicateID)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendPersonError(r.Context(), w, err)
+		return
+	}
+
+	h.sendPerson(w, r, res)
+}
+
+// DeletePerson deletes person together with its credits in movies
+func (h *StaffPersonHandler) DeletePerson(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	personID, ok := getPersonID(w, r)
+	if !ok {
+		return
+	}
+
+	logger.Info().Msgf("deleting person %d", personID)
+	if err := h.staffPersonService.DeletePerson(r.Context(), personID); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		sendPersonError(r.Context(), w, err)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, ds.Response{Message: messages.SuccessfulPersonDelete}); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
@@ -0,0 +1,135 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	movieDTO "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/delivery/dto"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
+)
+
+// PersonJSON delivery layer staff person info
+type PersonJSON struct {
+	ID         int    `json:"id"`
+	FullName   string `json:"full_name"`
+	EnFullName string `json:"en_full_name,omitempty"`
+	Photo      string `json:"photo"`
+	About      string `json:"about"`
+	Sex        string `json:"sex,omitempty"`
+	// Growth in centimeters
+	Growth int `json:"growth,omitempty"`
+
+	// dates are ISO-8601, display strings are filled only when locale is requested
+	Birthday        string `json:"birthday,omitempty"`
+	BirthdayDisplay string `json:"birthday_display,omitempty"`
+	Death           string `json:"death,omitempty"`
+	DeathDisplay    string `json:"death_display,omitempty"`
+	Age             int    `json:"age,omitempty"`
+	AgeAtDeath      int    `json:"age_at_death,omitempty"`
+
+	// career facts are computed from movies person is credited in
+	Career      []string             `json:"career"`
+	Genres      []movieDTO.GenreJSON `json:"genres"`
+	TotalFilms  int                  `json:"total_films"`
+	CareerStart int                  `json:"career_start,omitempty"`
+	CareerEnd   int                  `json:"career_end,omitempty"`
+}
+
+func NewPersonJSON(person models.Person, career models.Career, locale l10n.Locale, now time.Time) PersonJSON {
+	res := PersonJSON{
+		ID:          person.ID,
+		FullName:    person.FullName,
+		EnFullName:  person.EnFullName,
+		Photo:       person.Photo,
+		About:       person.About,
+		Sex:         person.Sex,
+		Growth:      person.Growth,
+		Career:      make([]string, 0, len(career.Roles)),
+		Genres:      make([]movieDTO.GenreJSON, 0, len(career.Genres)),
+		TotalFilms:  career.TotalFilms,
+		CareerStart: career.FirstYear,
+		CareerEnd:   career.LastYear,
+	}
+	for _, role := range career.Roles {
+		res.Career = append(res.Career, string(role))
+	}
+	for _, genre := range career.Genres {
+		res.Genres = append(res.Genres, movieDTO.NewGenreJSON(genre, locale))
+	}
+
+	if person.Birthday != nil {
+		res.Birthday = person.Birthday.Format(l10n.DateLayout)
+		res.BirthdayDisplay = l10n.FormatDate(*person.Birthday, locale)
+	}
+	if person.Death != nil {
+		res.Death = person.Death.Format(l10n.DateLayout)
+		res.DeathDisplay = l10n.FormatDate(*person.Death, locale)
+	}
+
+	if age, ok := person.Age(now); ok {
+		if person.Death != nil {
+			res.AgeAtDeath = age
+		} else {
+			res.Age = age
+		}
+	}
+
+	return res
+}
+
+// PersonRequest person edited by admin, dates are ISO-8601
+type PersonRequest struct {
+	FullName   string `json:"full_name"`
+	EnFullName string `json:"en_full_name"`
+	Photo      string `json:"photo"`
+	About      string `json:"about"`
+	Sex        string `json:"sex"`
+	// Growth in centimeters
+	Growth   int    `json:"growth"`
+	Birthday string `json:"birthday"`
+	Death    string `json:"death"`
+}
+
+// MergeRequest duplicate person whose credits are moved to person from path
+type MergeRequest struct {
+	DuplicateID int `json:"duplicate_id"`
+}
+
+// FilmographyItemJSON movie person is credited in, character is set only for actors
+type FilmographyItemJSON struct {
+	Movie     movieDTO.MovieShortJSON `json:"movie"`
+	Character string                  `json:"character,omitempty"`
+}
+
+// FilmographyGroupJSON page of movies where person has the same role
+type FilmographyGroupJSON struct {
+	Role       string                `json:"role"`
+	Items      []FilmographyItemJSON `json:"items"`
+	Total      int                   `json:"total"`
+	NextCursor string                `json:"next_cursor,omitempty"`
+}
+
+// FilmographyJSON movies of person grouped by role
+type FilmographyJSON struct {
+	Groups []FilmographyGroupJSON `json:"groups"`
+}
+
+func NewFilmographyJSON(groups []models.FilmographyGroup, locale l10n.Locale) FilmographyJSON {
+	res := FilmographyJSON{Groups: make([]FilmographyGroupJSON, 0, len(groups))}
+	for _, group := range groups {
+		groupJSON := FilmographyGroupJSON{
+			Role:       string(group.Role),
+			Items:      make([]FilmographyItemJSON, 0, len(group.Items)),
+			Total:      group.Total,
+			NextCursor: group.NextCursor,
+		}
+		for _, item := range group.Items {
+			groupJSON.Items = append(groupJSON.Items, FilmographyItemJSON{
+				Movie:     movieDTO.NewMovieShortJSON(item.Movie, locale),
+				Character: item.Character,
+			})
+		}
+		res.Groups = append(res.Groups, groupJSON)
+	}
+	return res
+}
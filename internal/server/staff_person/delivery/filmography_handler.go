@@ -0,0 +1,94 @@
+package delivery
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/staff_person/delivery/dto"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/jsonutil"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+
+	orderAsc  = "asc"
+	orderDesc = "desc"
+)
+
+// parseFilmographyRequest reads role, sorting and pagination from query string, the latest movies go first by default
+func parseFilmographyRequest(query url.Values) (models.FilmographyRequest, error) {
+	req := models.FilmographyRequest{
+		Role:   models.StaffRole(query.Get("role")),
+		Sort:   models.FilmographySort(query.Get("sort")),
+		Limit:  defaultLimit,
+		Cursor: query.Get("cursor"),
+	}
+	if req.Sort == "" {
+		req.Sort = models.FilmographySortByYear
+	}
+
+	switch query.Get("order") {
+	case "", orderDesc:
+		req.Desc = true
+	case orderAsc:
+		req.Desc = false
+	default:
+		return req, errors.Errorf("unknown order %q", query.Get("order"))
+	}
+
+	if val := query.Get("limit"); val != "" {
+		var err error
+		if req.Limit, err = strconv.Atoi(val); err != nil {
+			return req, errors.Wrap(err, "parameter limit")
+		}
+	}
+	if req.Limit <= 0 || req.Limit > maxLimit {
+		return req, errors.Errorf("limit must be in 1-%d", maxLimit)
+	}
+
+	return req, nil
+}
+
+// GetFilmography handles GET request to list movies of person grouped by role
+func (h *StaffPersonHandler) GetFilmography(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	personID, ok := getPersonID(w, r)
+	if !ok {
+		return
+	}
+
+	req, err := parseFilmographyRequest(r.URL.Query())
+	if err != nil {
+		errMsg := errors.Wrap(err, "getFilmography action: bad request")
+		logger.Error().Err(errMsg).Msg(errMsg.Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, err.Error())
+		return
+	}
+
+	groups, err := h.staffPersonService.GetFilmography(r.Context(), personID, req)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		switch {
+		case errors.Is(err, errs.ErrInvalidCursor) || errors.Is(err, errs.ErrInvalidFilmographyRequest):
+			jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, err.Error())
+		case errors.Is(err, errs.ErrPersonNotFound):
+			jsonutil.SendError(r.Context(), w, http.StatusNotFound, errs.ErrNotFoundShort, err.Error())
+		default:
+			jsonutil.SendError(r.Context(), w, http.StatusInternalServerError, errs.ErrSomethingWentWrong, errs.ErrSomethingWentWrong)
+		}
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, dto.NewFilmographyJSON(groups, l10n.ParseLocale(r.URL.Query().Get(localeParam)))); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
@@ -4,4 +4,10 @@ import "net/http"
 
 type StaffPersonHandlerInterface interface {
 	GetPerson(w http.ResponseWriter, r *http.Request)
+	GetFilmography(w http.ResponseWriter, r *http.Request)
+
+	CreatePerson(w http.ResponseWriter, r *http.Request)
+	UpdatePerson(w http.ResponseWriter, r *http.Request)
+	MergePerson(w http.ResponseWriter, r *http.Request)
+	DeletePerson(w http.ResponseWriter, r *http.Request)
 }
@@ -4,18 +4,31 @@ import (
 	"context"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/jsonutil"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 
 	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
-	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/staff_person/delivery/dto"
 )
 
+// localeParam query parameter requesting localized display strings
+const localeParam = "locale"
+
 type StaffPersonServiceInterface interface {
-	GetPersonByID(ctx context.Context, personID int) (*mocks.PersonJSON, error)
+	GetPersonByID(ctx context.Context, personID int) (*models.Person, error)
+	GetCareer(ctx context.Context, personID int) (*models.Career, error)
+	GetFilmography(ctx context.Context, personID int, req models.FilmographyRequest) ([]models.FilmographyGroup, error)
+
+	CreatePerson(ctx context.Context, person models.Person) (*models.Person, error)
+	UpdatePerson(ctx context.Context, person models.Person) (*models.Person, error)
+	MergePerson(ctx context.Context, personID, duplicateID int) (*models.Person, error)
+	DeletePerson(ctx context.Context, personID int) error
 }
 
 // StaffPersonHandler handles requests to staff person: actor, director, etc
@@ -43,7 +56,7 @@ func (h *StaffPersonHandler) GetPerson(w http.ResponseWriter, r *http.Request) {
 	}
 
 	logger.Info().Msgf("getting person by id: %d", personID)
-	personJSON, err := h.staffPersonService.GetPersonByID(r.Context(), personID)
+	person, err := h.staffPersonService.GetPersonByID(r.Context(), personID)
 	if err != nil {
 		logger.Error().Err(err).Msg(err.Error())
 		if errors.Is(err, errs.ErrPersonNotFound) {
@@ -53,9 +66,15 @@ func (h *StaffPersonHandler) GetPerson(w http.ResponseWriter, r *http.Request) {
 		jsonutil.SendError(r.Context(), w, http.StatusInternalServerError, errs.ErrSomethingWentWrong, errs.ErrSomethingWentWrong)
 		return
 	}
+	career, err := h.staffPersonService.GetCareer(r.Context(), personID)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		jsonutil.SendError(r.Context(), w, http.StatusInternalServerError, errs.ErrSomethingWentWrong, errs.ErrSomethingWentWrong)
+		return
+	}
 	logger.Info().Msgf("successfully got person data by id: %d", personID)
 
-	if err := jsonutil.SendJSON(r.Context(), w, personJSON); err != nil {
+	if err := jsonutil.SendJSON(r.Context(), w, dto.NewPersonJSON(*person, *career, l10n.ParseLocale(r.URL.Query().Get(localeParam)), time.Now())); err != nil {
 		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
 		return
 	}
@@ -2,14 +2,18 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"sync"
 
 	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
 	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
 	"github.com/rs/zerolog/log"
 )
 
 // StaffPersonRepository collect and process data of staff person
 type StaffPersonRepository struct {
+	mu sync.RWMutex
 	db *mocks.Persons
 }
 
@@ -19,9 +23,12 @@ func NewStaffPersonRepository(staffRepo *mocks.Persons) *StaffPersonRepository {
 }
 
 // GetPersonFromRepoByID obtains person info from repo by id
-func (r *StaffPersonRepository) GetPersonFromRepoByID(ctx context.Context, personID int) (*mocks.PersonJSON, error) {
+func (r *StaffPersonRepository) GetPersonFromRepoByID(ctx context.Context, personID int) (*models.Person, error) {
 	logger := log.Ctx(ctx)
 
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	for _, val := range *r.db {
 		if val.ID == personID {
 			return &val, nil
@@ -31,3 +38,83 @@ func (r *StaffPersonRepository) GetPersonFromRepoByID(ctx context.Context, perso
 	logger.Err(errs.ErrPersonNotFound).Msg(errs.ErrPersonNotFound.Error())
 	return nil, errs.ErrPersonNotFound
 }
+
+// UpsertPerson creates person or replaces existing one with the same id
+func (r *StaffPersonRepository) UpsertPerson(ctx context.Context, person models.Person) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	(*r.db)[person.ID] = person
+	return nil
+}
+
+// CreatePerson saves person under new id
+func (r *StaffPersonRepository) CreatePerson(ctx context.Context, person models.Person) (*models.Person, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	person.ID = 0
+	for id := range *r.db {
+		person.ID = max(person.ID, id)
+	}
+	person.ID++
+	(*r.db)[person.ID] = person
+	return &person, nil
+}
+
+// UpdatePerson replaces existing person
+func (r *StaffPersonRepository) UpdatePerson(ctx context.Context, person models.Person) error {
+	logger := log.Ctx(ctx)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := (*r.db)[person.ID]; !exists {
+		logger.Err(errs.ErrPersonNotFound).Msg(errs.ErrPersonNotFound.Error())
+		return errs.ErrPersonNotFound
+	}
+	(*r.db)[person.ID] = person
+	return nil
+}
+
+func (r *StaffPersonRepository) DeletePerson(ctx context.Context, personID int) error {
+	logger := log.Ctx(ctx)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := (*r.db)[personID]; !exists {
+		logger.Err(errs.ErrPersonNotFound).Msg(errs.ErrPersonNotFound.Error())
+		return errs.ErrPersonNotFound
+	}
+	delete(*r.db, personID)
+	return nil
+}
+
+// Snapshot serializes all persons
+func (r *StaffPersonRepository) Snapshot(ctx context.Context) ([]byte, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return json.Marshal(*r.db)
+}
+
+// Restore replaces all persons with ones from snapshot
+func (r *StaffPersonRepository) Restore(ctx context.Context, data []byte) error {
+	var persons mocks.Persons
+	if err := json.Unmarshal(data, &persons); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id := range *r.db {
+		delete(*r.db, id)
+	}
+	for id, person := range persons {
+		(*r.db)[id] = person
+	}
+
+	return nil
+}
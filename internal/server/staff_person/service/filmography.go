@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/cursor"
+	"github.com/rs/zerolog/log"
+)
+
+// maxCareerGenres most frequent genres shown in person career
+const maxCareerGenres = 3
+
+// movieCredit movie person is credited in with one role
+type movieCredit struct {
+	movie  models.Movie
+	member models.StaffMember
+}
+
+// credits returns every credit of person in catalog
+func (s *StaffPersonService) credits(ctx context.Context, personID int) ([]movieCredit, error) {
+	movies, err := s.movieRepo.GetAllMovies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []movieCredit
+	for _, movie := range movies {
+		for _, member := range movie.Staff {
+			if member.Person.ID == personID {
+				res = append(res, movieCredit{movie: movie, member: member})
+			}
+		}
+	}
+	return res, nil
+}
+
+// GetCareer computes roles, genres and years of person career from movie credits
+func (s *StaffPersonService) GetCareer(ctx context.Context, personID int) (*models.Career, error) {
+	logger := log.Ctx(ctx)
+
+	credits, err := s.credits(ctx, personID)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	roles := make(map[models.StaffRole]bool)
+	movies := make(map[int]bool)
+	genreCount := make(map[int]int)
+	genres := make(map[int]models.Genre)
+	res := &models.Career{}
+	for _, c := range credits {
+		roles[c.member.Role] = true
+		if movies[c.movie.ID] {
+			continue
+		}
+		movies[c.movie.ID] = true
+
+		for _, genre := range c.movie.Genres {
+			genreCount[genre.ID]++
+			genres[genre.ID] = genre
+		}
+		if year := c.movie.ReleaseYear; year > 0 {
+			if res.FirstYear == 0 || year < res.FirstYear {
+				res.FirstYear = year
+			}
+			res.LastYear = max(res.LastYear, year)
+		}
+	}
+	res.TotalFilms = len(movies)
+
+	for _, role := range models.StaffRoles {
+		if roles[role] {
+			res.Roles = append(res.Roles, role)
+		}
+	}
+
+	for _, genre := range genres {
+		res.Genres = append(res.Genres, genre)
+	}
+	sort.Slice(res.Genres, func(i, j int) bool {
+		a, b := res.Genres[i].ID, res.Genres[j].ID
+		if genreCount[a] != genreCount[b] {
+			return genreCount[a] > genreCount[b]
+		}
+		return a < b
+	})
+	if len(res.Genres) > maxCareerGenres {
+		res.Genres = res.Genres[:maxCareerGenres]
+	}
+
+	return res, nil
+}
+
+func filmographyKey(item models.FilmographyItem, sortBy models.FilmographySort) cursor.Key {
+	key := cursor.Key{ID: item.Movie.ID}
+	switch sortBy {
+	case models.FilmographySortByYear:
+		key.Num = float64(item.Movie.ReleaseYear)
+	case models.FilmographySortByRating:
+		key.Num = item.Movie.Rating
+	}
+	return key
+}
+
+func isKnownFilmographySort(sortBy models.FilmographySort) bool {
+	switch sortBy {
+	case models.FilmographySortByYear, models.FilmographySortByRating:
+		return true
+	default:
+		return false
+	}
+}
+
+// GetFilmography returns movies person is credited in grouped by role in StaffRoles order.
+// Every role gets its own page, so it is continued by requesting this role with its cursor
+func (s *StaffPersonService) GetFilmography(ctx context.Context, personID int, req models.FilmographyRequest) ([]models.FilmographyGroup, error) {
+	logger := log.Ctx(ctx)
+
+	if !isKnownFilmographySort(req.Sort) || req.Limit <= 0 || req.Role != "" && !req.Role.IsKnown() {
+		logger.Error().Str("sort", string(req.Sort)).Str("role", string(req.Role)).Int("limit", req.Limit).Msg(errs.ErrBadPayload)
+		return nil, errs.ErrInvalidFilmographyRequest
+	}
+
+	if _, err := s.staffPersonRepo.GetPersonFromRepoByID(ctx, personID); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	credits, err := s.credits(ctx, personID)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	byRole := make(map[models.StaffRole][]models.FilmographyItem)
+	for _, c := range credits {
+		if req.Role != "" && c.member.Role != req.Role {
+			continue
+		}
+		byRole[c.member.Role] = append(byRole[c.member.Role], models.FilmographyItem{Movie: c.movie, Character: c.member.Character})
+	}
+
+	res := make([]models.FilmographyGroup, 0, len(byRole))
+	for _, role := range models.StaffRoles {
+		items, ok := byRole[role]
+		if !ok {
+			continue
+		}
+
+		// cursor continues page of its role only, so it is rejected when all roles are requested
+		page, err := cursor.Paginate(items, func(item models.FilmographyItem) cursor.Key {
+			return filmographyKey(item, req.Sort)
+		}, cursor.Request{Scope: cursor.Scope(role, req.Sort), Desc: req.Desc, Limit: req.Limit, Cursor: req.Cursor})
+		if err != nil {
+			logger.Error().Err(err).Str("cursor", req.Cursor).Msg(err.Error())
+			return nil, err
+		}
+
+		res = append(res, models.FilmographyGroup{Role: role, Items: page.Items, Total: page.Total, NextCursor: page.NextCursor})
+	}
+
+	return res, nil
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	drama    = models.Genre{ID: 1, Name: "драма"}
+	thriller = models.Genre{ID: 2, Name: "триллер"}
+	crime    = models.Genre{ID: 3, Name: "криминал"}
+	comedy   = models.Genre{ID: 4, Name: "комедия"}
+)
+
+func filmographyMovies() mocks.Movies {
+	director := models.StaffMember{Person: fincher.Short(), Role: models.RoleDirector}
+	return mocks.Movies{
+		1: {ID: 1, Name: "Бойцовский клуб", ReleaseYear: 1999, Rating: 8.8, Genres: []models.Genre{drama, thriller},
+			Staff: []models.StaffMember{director, {Person: fincher.Short(), Role: models.RoleProducer}}},
+		2: {ID: 2, Name: "Семь", ReleaseYear: 1995, Rating: 8.3, Genres: []models.Genre{crime, thriller}, Staff: []models.StaffMember{director}},
+		3: {ID: 3, Name: "Зодиак", ReleaseYear: 2007, Rating: 7.7, Genres: []models.Genre{crime, drama, thriller}, Staff: []models.StaffMember{director}},
+		4: {ID: 4, Name: "Игра", ReleaseYear: 1997, Rating: 8.3, Genres: []models.Genre{comedy, thriller}, Staff: []models.StaffMember{director}},
+		5: {ID: 5, Name: "Амели", ReleaseYear: 2001, Rating: 8.0, Genres: []models.Genre{comedy}},
+	}
+}
+
+func groupIDs(group models.FilmographyGroup) []int {
+	ids := make([]int, 0, len(group.Items))
+	for _, item := range group.Items {
+		ids = append(ids, item.Movie.ID)
+	}
+	return ids
+}
+
+func TestStaffPersonService_GetFilmography(t *testing.T) {
+	ctx := context.Background()
+	movies := filmographyMovies()
+	s, _ := newStaffPersonService(&movies)
+
+	groups, err := s.GetFilmography(ctx, 1, models.FilmographyRequest{Sort: models.FilmographySortByYear, Desc: true, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+	assert.Equal(t, models.RoleDirector, groups[0].Role)
+	assert.Equal(t, 4, groups[0].Total)
+	assert.Equal(t, []int{3, 1}, groupIDs(groups[0]))
+	assert.Equal(t, models.RoleProducer, groups[1].Role)
+	assert.Equal(t, []int{1}, groupIDs(groups[1]))
+	assert.Empty(t, groups[1].NextCursor)
+
+	// cursor belongs to role it was returned with
+	_, err = s.GetFilmography(ctx, 1, models.FilmographyRequest{Sort: models.FilmographySortByYear, Desc: true, Limit: 2, Cursor: groups[0].NextCursor})
+	require.ErrorIs(t, err, errs.ErrInvalidCursor)
+
+	next, err := s.GetFilmography(ctx, 1, models.FilmographyRequest{
+		Role: models.RoleDirector, Sort: models.FilmographySortByYear, Desc: true, Limit: 2, Cursor: groups[0].NextCursor,
+	})
+	require.NoError(t, err)
+	require.Len(t, next, 1)
+	assert.Equal(t, []int{4, 2}, groupIDs(next[0]))
+	assert.Empty(t, next[0].NextCursor)
+
+	// equal ratings are ordered by movie id
+	byRating, err := s.GetFilmography(ctx, 1, models.FilmographyRequest{Role: models.RoleDirector, Sort: models.FilmographySortByRating, Desc: true, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, byRating, 1)
+	assert.Equal(t, []int{1, 2, 4, 3}, groupIDs(byRating[0]))
+
+	_, err = s.GetFilmography(ctx, 1, models.FilmographyRequest{Role: "stuntman", Sort: models.FilmographySortByYear, Limit: 10})
+	require.ErrorIs(t, err, errs.ErrInvalidFilmographyRequest)
+	_, err = s.GetFilmography(ctx, 42, models.FilmographyRequest{Sort: models.FilmographySortByYear, Limit: 10})
+	require.ErrorIs(t, err, errs.ErrPersonNotFound)
+
+	empty, err := s.GetFilmography(ctx, 2, models.FilmographyRequest{Sort: models.FilmographySortByYear, Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func TestStaffPersonService_GetCareer(t *testing.T) {
+	ctx := context.Background()
+	movies := filmographyMovies()
+	s, _ := newStaffPersonService(&movies)
+
+	career, err := s.GetCareer(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, &models.Career{
+		Roles:      []models.StaffRole{models.RoleDirector, models.RoleProducer},
+		Genres:     []models.Genre{thriller, drama, crime},
+		TotalFilms: 4,
+		FirstYear:  1995,
+		LastYear:   2007,
+	}, career)
+
+	career, err = s.GetCareer(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, &models.Career{}, career)
+}
@@ -3,35 +3,192 @@ package service
 import (
 	"context"
 
-	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 )
 
 type StaffPersonRepositoryInterface interface {
-	GetPersonFromRepoByID(ctx context.Context, personID int) (*mocks.PersonJSON, error)
+	GetPersonFromRepoByID(ctx context.Context, personID int) (*models.Person, error)
+	CreatePerson(ctx context.Context, person models.Person) (*models.Person, error)
+	UpdatePerson(ctx context.Context, person models.Person) error
+	DeletePerson(ctx context.Context, personID int) error
+}
+
+type MovieRepositoryInterface interface {
+	GetAllMovies(ctx context.Context) ([]models.Movie, error)
+	GetStoredMovies(ctx context.Context) ([]models.Movie, error)
+	UpdateStoredMovie(ctx context.Context, movieID int, fn func(movie *models.Movie) error) (*models.Movie, error)
 }
 
 // StaffPersonService collect and process data of staff person
 type StaffPersonService struct {
 	staffPersonRepo StaffPersonRepositoryInterface
+	movieRepo       MovieRepositoryInterface
 }
 
 // NewStaffPersonService returns new instance of StaffPersonService
-func NewStaffPersonService(staffPersonRepo StaffPersonRepositoryInterface) *StaffPersonService {
+func NewStaffPersonService(staffPersonRepo StaffPersonRepositoryInterface, movieRepo MovieRepositoryInterface) *StaffPersonService {
 	return &StaffPersonService{
 		staffPersonRepo: staffPersonRepo,
+		movieRepo:       movieRepo,
 	}
 }
 
 // GetPersonByID obtains and formats person info gotten by id
-func (s *StaffPersonService) GetPersonByID(ctx context.Context, personID int) (*mocks.PersonJSON, error) {
+func (s *StaffPersonService) GetPersonByID(ctx context.Context, personID int) (*models.Person, error) {
+	logger := log.Ctx(ctx)
+
+	person, err := s.staffPersonRepo.GetPersonFromRepoByID(ctx, personID)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return person, nil
+}
+
+// updateCredits replaces staff of every movie person is credited in with result of fn, fn must not modify staff in place.
+// Movies keep short copy of person, so it has to be updated together with person. Deleted movies are updated too,
+// otherwise they would refer to merged or deleted persons after being restored
+func (s *StaffPersonService) updateCredits(ctx context.Context, personID int, fn func(staff []models.StaffMember) []models.StaffMember) error {
+	movies, err := s.movieRepo.GetStoredMovies(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, movie := range movies {
+		if !movie.HasPerson(personID) {
+			continue
+		}
+		_, err = s.movieRepo.UpdateStoredMovie(ctx, movie.ID, func(stored *models.Movie) error {
+			stored.Staff = fn(stored.Staff)
+			return nil
+		})
+		if err != nil && !errors.Is(err, errs.ErrMovieNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreatePerson adds person, it is credited in movies by movie editing
+func (s *StaffPersonService) CreatePerson(ctx context.Context, person models.Person) (*models.Person, error) {
 	logger := log.Ctx(ctx)
 
-	personJSON, err := s.staffPersonRepo.GetPersonFromRepoByID(ctx, personID)
+	res, err := s.staffPersonRepo.CreatePerson(ctx, person)
 	if err != nil {
 		logger.Error().Err(err).Msg(err.Error())
 		return nil, err
 	}
 
-	return personJSON, nil
+	return res, nil
+}
+
+// UpdatePerson replaces person and refreshes name and photo shown in movie credits
+func (s *StaffPersonService) UpdatePerson(ctx context.Context, person models.Person) (*models.Person, error) {
+	logger := log.Ctx(ctx)
+
+	if err := s.staffPersonRepo.UpdatePerson(ctx, person); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	err := s.updateCredits(ctx, person.ID, func(staff []models.StaffMember) []models.StaffMember {
+		res := make([]models.StaffMember, 0, len(staff))
+		for _, member := range staff {
+			if member.Person.ID == person.ID {
+				member.Person = person.Short()
+			}
+			res = append(res, member)
+		}
+		return res
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return &person, nil
+}
+
+// MergePerson moves credits of duplicate to person and deletes duplicate.
+// Person keeps its own info, credit in role person already has is dropped, actor credits differ by character too
+func (s *StaffPersonService) MergePerson(ctx context.Context, personID, duplicateID int) (*models.Person, error) {
+	logger := log.Ctx(ctx)
+
+	if personID == duplicateID {
+		logger.Error().Int("person_id", personID).Msg(errs.ErrMergeSamePerson.Error())
+		return nil, errs.ErrMergeSamePerson
+	}
+
+	person, err := s.staffPersonRepo.GetPersonFromRepoByID(ctx, personID)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+	if _, err = s.staffPersonRepo.GetPersonFromRepoByID(ctx, duplicateID); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	err = s.updateCredits(ctx, duplicateID, func(staff []models.StaffMember) []models.StaffMember {
+		seen := make(map[models.StaffCredit]struct{}, len(staff))
+		res := make([]models.StaffMember, 0, len(staff))
+		for _, member := range staff {
+			if member.Person.ID == duplicateID {
+				member.Person = person.Short()
+			}
+			key := member.Credit()
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			res = append(res, member)
+		}
+		return res
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	if err = s.staffPersonRepo.DeletePerson(ctx, duplicateID); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return person, nil
+}
+
+// DeletePerson deletes person and removes all its credits from movies
+func (s *StaffPersonService) DeletePerson(ctx context.Context, personID int) error {
+	logger := log.Ctx(ctx)
+
+	if _, err := s.staffPersonRepo.GetPersonFromRepoByID(ctx, personID); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return err
+	}
+
+	err := s.updateCredits(ctx, personID, func(staff []models.StaffMember) []models.StaffMember {
+		res := make([]models.StaffMember, 0, len(staff))
+		for _, member := range staff {
+			if member.Person.ID != personID {
+				res = append(res, member)
+			}
+		}
+		return res
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return err
+	}
+
+	if err = s.staffPersonRepo.DeletePerson(ctx, personID); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return err
+	}
+
+	return nil
 }
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	repoMovie "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/repository"
+	repoStaff "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/staff_person/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	fincher = models.Person{ID: 1, FullName: "Дэвид Финчер", About: "Режиссёр"}
+	pitt    = models.Person{ID: 2, FullName: "Брэд Питт"}
+	// pittDuplicate is the same person imported twice
+	pittDuplicate = models.Person{ID: 3, FullName: "Брэд Питт", EnFullName: "Brad Pitt"}
+
+	deletedAt = time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func staffMovies() mocks.Movies {
+	return mocks.Movies{
+		1: {ID: 1, Name: "Бойцовский клуб", Staff: []models.StaffMember{
+			{Person: fincher.Short(), Role: models.RoleDirector},
+			{Person: pitt.Short(), Role: models.RoleActor, Character: "Тайлер Дёрден"},
+			{Person: pittDuplicate.Short(), Role: models.RoleActor, Character: "Тайлер Дёрден"},
+		}},
+		2: {ID: 2, Name: "Семь", Staff: []models.StaffMember{
+			{Person: fincher.Short(), Role: models.RoleDirector},
+			{Person: pittDuplicate.Short(), Role: models.RoleActor, Character: "Дэвид Миллс"},
+		}},
+		3: {ID: 3, Name: "Амели"},
+		4: {ID: 4, Name: "Бесславные ублюдки", Staff: []models.StaffMember{
+			{Person: pitt.Short(), Role: models.RoleActor, Character: "Альдо Рейн"},
+			{Person: pittDuplicate.Short(), Role: models.RoleActor, Character: "Энцо Горломи"},
+		}},
+		5: {ID: 5, Name: "Удалённый фильм", DeletedAt: &deletedAt, Staff: []models.StaffMember{
+			{Person: pittDuplicate.Short(), Role: models.RoleActor},
+		}},
+	}
+}
+
+func newStaffPersonService(movies *mocks.Movies) (*StaffPersonService, *repoMovie.MovieRepository) {
+	persons := mocks.Persons{1: fincher, 2: pitt, 3: pittDuplicate}
+	movieRepo := repoMovie.NewMovieRepository(movies)
+	return NewStaffPersonService(repoStaff.NewStaffPersonRepository(&persons), movieRepo), movieRepo
+}
+
+func TestStaffPersonService_CreatePerson(t *testing.T) {
+	ctx := context.Background()
+	movies := staffMovies()
+	s, _ := newStaffPersonService(&movies)
+
+	created, err := s.CreatePerson(ctx, models.Person{ID: 1, FullName: "Эдвард Нортон"})
+	require.NoError(t, err)
+	assert.Equal(t, 4, created.ID)
+
+	got, err := s.GetPersonByID(ctx, 4)
+	require.NoError(t, err)
+	assert.Equal(t, "Эдвард Нортон", got.FullName)
+
+	got, err = s.GetPersonByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, fincher, *got)
+}
+
+func TestStaffPersonService_UpdatePerson(t *testing.T) {
+	ctx := context.Background()
+	movies := staffMovies()
+	s, movieRepo := newStaffPersonService(&movies)
+
+	updated := fincher
+	updated.FullName = "Дэвид Эндрю Лео Финчер"
+	updated.Photo = "/static/avatars/fincher.webp"
+	_, err := s.UpdatePerson(ctx, updated)
+	require.NoError(t, err)
+
+	for _, movieID := range []int{1, 2} {
+		movie, err := movieRepo.GetMovieFromRepoByID(ctx, movieID)
+		require.NoError(t, err)
+		assert.Equal(t, models.StaffMember{Person: updated.Short(), Role: models.RoleDirector}, movie.Staff[0])
+	}
+
+	_, err = s.UpdatePerson(ctx, models.Person{ID: 42, FullName: "Нет такого"})
+	require.ErrorIs(t, err, errs.ErrPersonNotFound)
+}
+
+func TestStaffPersonService_MergePerson(t *testing.T) {
+	ctx := context.Background()
+	movies := staffMovies()
+	s, movieRepo := newStaffPersonService(&movies)
+
+	_, err := s.MergePerson(ctx, 2, 2)
+	require.ErrorIs(t, err, errs.ErrMergeSamePerson)
+	_, err = s.MergePerson(ctx, 2, 42)
+	require.ErrorIs(t, err, errs.ErrPersonNotFound)
+
+	merged, err := s.MergePerson(ctx, 2, 3)
+	require.NoError(t, err)
+	assert.Equal(t, pitt, *merged)
+
+	// credit in the same role is kept once
+	movie, err := movieRepo.GetMovieFromRepoByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []models.StaffMember{
+		{Person: fincher.Short(), Role: models.RoleDirector},
+		{Person: pitt.Short(), Role: models.RoleActor, Character: "Тайлер Дёрден"},
+	}, movie.Staff)
+
+	movie, err = movieRepo.GetMovieFromRepoByID(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, models.StaffMember{Person: pitt.Short(), Role: models.RoleActor, Character: "Дэвид Миллс"}, movie.Staff[1])
+
+	// actor playing two characters keeps both credits
+	movie, err = movieRepo.GetMovieFromRepoByID(ctx, 4)
+	require.NoError(t, err)
+	assert.Equal(t, []models.StaffMember{
+		{Person: pitt.Short(), Role: models.RoleActor, Character: "Альдо Рейн"},
+		{Person: pitt.Short(), Role: models.RoleActor, Character: "Энцо Горломи"},
+	}, movie.Staff)
+
+	// deleted movie may be restored, so it must not refer to removed duplicate
+	stored, err := movieRepo.GetStoredMovies(ctx)
+	require.NoError(t, err)
+	require.Len(t, stored, 5)
+	assert.Equal(t, []models.StaffMember{{Person: pitt.Short(), Role: models.RoleActor}}, stored[4].Staff)
+	assert.True(t, stored[4].IsDeleted())
+
+	_, err = s.GetPersonByID(ctx, 3)
+	require.ErrorIs(t, err, errs.ErrPersonNotFound)
+}
+
+func TestStaffPersonService_DeletePerson(t *testing.T) {
+	ctx := context.Background()
+	movies := staffMovies()
+	s, movieRepo := newStaffPersonService(&movies)
+
+	require.NoError(t, s.DeletePerson(ctx, 1))
+	require.ErrorIs(t, s.DeletePerson(ctx, 1), errs.ErrPersonNotFound)
+
+	for _, movieID := range []int{1, 2} {
+		movie, err := movieRepo.GetMovieFromRepoByID(ctx, movieID)
+		require.NoError(t, err)
+		assert.False(t, movie.HasPerson(1))
+	}
+
+	movie, err := movieRepo.GetMovieFromRepoByID(ctx, 2)
+	require.NoError(t, err)
+	assert.Len(t, movie.Staff, 1)
+}
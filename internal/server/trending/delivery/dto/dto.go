@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	movieDTO "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/delivery/dto"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
+)
+
+// TrendingMovieJSON movie with score of recent user activity
+type TrendingMovieJSON struct {
+	Movie movieDTO.MovieShortJSON `json:"movie"`
+	Score float64                 `json:"score"`
+}
+
+// TrendingJSON "Популярно сейчас" list, the most active first
+type TrendingJSON struct {
+	Period string              `json:"period"`
+	Movies []TrendingMovieJSON `json:"movies"`
+}
+
+func NewTrendingJSON(period models.TrendingPeriod, movies []models.TrendingMovie, locale l10n.Locale) TrendingJSON {
+	res := TrendingJSON{
+		Period: string(period),
+		Movies: make([]TrendingMovieJSON, 0, len(movies)),
+	}
+	for _, movie := range movies {
+		res.Movies = append(res.Movies, TrendingMovieJSON{
+			Movie: movieDTO.NewMovieShortJSON(movie.Movie, locale),
+			Score: movie.Score,
+		})
+	}
+	return res
+}
@@ -0,0 +1,7 @@
+package delivery
+
+import "net/http"
+
+type TrendingHandlerInterface interface {
+	GetTrending(w http.ResponseWriter, r *http.Request)
+}
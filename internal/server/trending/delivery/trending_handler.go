@@ -0,0 +1,91 @@
+package delivery
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/trending/delivery/dto"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/jsonutil"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+
+	// localeParam query parameter requesting localized genre names
+	localeParam = "locale"
+)
+
+type TrendingServiceInterface interface {
+	GetTrending(ctx context.Context, period models.TrendingPeriod, limit int) ([]models.TrendingMovie, error)
+}
+
+// TrendingHandler handles requests for movies with the most recent user activity
+type TrendingHandler struct {
+	trendingService TrendingServiceInterface
+}
+
+func NewTrendingHandler(trendingService TrendingServiceInterface) *TrendingHandler {
+	return &TrendingHandler{
+		trendingService: trendingService,
+	}
+}
+
+// parseTrendingRequest reads period and number of movies from query string, week is default period
+func parseTrendingRequest(r *http.Request) (models.TrendingPeriod, int, error) {
+	period := models.TrendingPeriod(r.URL.Query().Get("period"))
+	if period == "" {
+		period = models.TrendingWeek
+	}
+	if _, ok := period.Window(); !ok {
+		return period, 0, errors.Errorf("unknown period %q", period)
+	}
+
+	limit := defaultLimit
+	if val := r.URL.Query().Get("limit"); val != "" {
+		var err error
+		if limit, err = strconv.Atoi(val); err != nil {
+			return period, 0, errors.Wrap(err, "parameter limit")
+		}
+	}
+	if limit <= 0 || limit > maxLimit {
+		return period, 0, errors.Errorf("limit must be in 1-%d", maxLimit)
+	}
+
+	return period, limit, nil
+}
+
+// GetTrending handles GET request for "Популярно сейчас" list
+func (h *TrendingHandler) GetTrending(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	period, limit, err := parseTrendingRequest(r)
+	if err != nil {
+		errMsg := errors.Wrap(err, "getTrending action: bad request")
+		logger.Error().Err(errMsg).Msg(errMsg.Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, err.Error())
+		return
+	}
+
+	movies, err := h.trendingService.GetTrending(r.Context(), period, limit)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		if errors.Is(err, errs.ErrInvalidTrendingPeriod) {
+			jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, err.Error())
+			return
+		}
+		jsonutil.SendError(r.Context(), w, http.StatusInternalServerError, errs.ErrSomethingWentWrong, errs.ErrSomethingWentWrong)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, dto.NewTrendingJSON(period, movies, l10n.ParseLocale(r.URL.Query().Get(localeParam)))); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+)
+
+// ViewRepository keeps number of movie page views by movie id and unix time of hour
+type ViewRepository struct {
+	mu sync.RWMutex
+	db map[int]map[int64]int
+}
+
+func NewViewRepository() *ViewRepository {
+	return &ViewRepository{
+		db: make(map[int]map[int64]int),
+	}
+}
+
+// AddViews adds views of movies by movie id to hour of given time
+func (r *ViewRepository) AddViews(ctx context.Context, counts map[int]int, at time.Time) error {
+	hour := at.Truncate(time.Hour).Unix()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for movieID, count := range counts {
+		if r.db[movieID] == nil {
+			r.db[movieID] = make(map[int64]int)
+		}
+		r.db[movieID][hour] += count
+	}
+
+	return nil
+}
+
+// GetViews returns views of hours which end after since ordered by movie id and hour
+func (r *ViewRepository) GetViews(ctx context.Context, since time.Time) ([]models.MovieViews, error) {
+	from := since.Truncate(time.Hour).Unix()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var res []models.MovieViews
+	for movieID, hours := range r.db {
+		for hour, count := range hours {
+			if hour >= from {
+				res = append(res, models.MovieViews{MovieID: movieID, Hour: time.Unix(hour, 0).UTC(), Count: count})
+			}
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].MovieID != res[j].MovieID {
+			return res[i].MovieID < res[j].MovieID
+		}
+		return res[i].Hour.Before(res[j].Hour)
+	})
+
+	return res, nil
+}
+
+// Prune forgets views of hours which ended before given time
+func (r *ViewRepository) Prune(ctx context.Context, before time.Time) error {
+	from := before.Truncate(time.Hour).Unix()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for movieID, hours := range r.db {
+		for hour := range hours {
+			if hour < from {
+				delete(hours, hour)
+			}
+		}
+		if len(hours) == 0 {
+			delete(r.db, movieID)
+		}
+	}
+
+	return nil
+}
+
+// Snapshot serializes views of all movies
+func (r *ViewRepository) Snapshot(ctx context.Context) ([]byte, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	records := make([]models.MovieViews, 0)
+	for movieID, hours := range r.db {
+		for hour, count := range hours {
+			records = append(records, models.MovieViews{MovieID: movieID, Hour: time.Unix(hour, 0).UTC(), Count: count})
+		}
+	}
+
+	return json.Marshal(records)
+}
+
+// Restore replaces all views with ones from snapshot
+func (r *ViewRepository) Restore(ctx context.Context, data []byte) error {
+	var records []models.MovieViews
+	if err := json.Unmarshal(data, &records); err != nil {
+		return err
+	}
+
+	db := make(map[int]map[int64]int)
+	for _, views := range records {
+		if db[views.MovieID] == nil {
+			db[views.MovieID] = make(map[int64]int)
+		}
+		db[views.MovieID][views.Hour.Truncate(time.Hour).Unix()] += views.Count
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.db = db
+
+	return nil
+}
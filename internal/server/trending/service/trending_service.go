@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/rs/zerolog/log"
+)
+
+type MovieRepositoryInterface interface {
+	GetAllMovies(ctx context.Context) ([]models.Movie, error)
+}
+
+type RatingRepositoryInterface interface {
+	GetAllRatings(ctx context.Context) ([]models.Rating, error)
+}
+
+type WatchlistRepositoryInterface interface {
+	GetAdditions(ctx context.Context, since time.Time) ([]models.WatchlistEntry, error)
+}
+
+type ViewRepositoryInterface interface {
+	AddViews(ctx context.Context, counts map[int]int, at time.Time) error
+	GetViews(ctx context.Context, since time.Time) ([]models.MovieViews, error)
+	Prune(ctx context.Context, before time.Time) error
+}
+
+// TrendingService ranks movies by recent activity of users: page views, ratings, reviews and watchlist additions
+type TrendingService struct {
+	movieRepo     MovieRepositoryInterface
+	ratingRepo    RatingRepositoryInterface
+	watchlistRepo WatchlistRepositoryInterface
+	viewRepo      ViewRepositoryInterface
+	settings      models.TrendingSettings
+
+	mu    sync.Mutex
+	cache map[models.TrendingPeriod]*models.TrendingScores
+
+	// views movie ids of viewed pages waiting to be counted by background job
+	views chan int
+	stop  chan struct{}
+	done  chan struct{}
+}
+
+func NewTrendingService(movieRepo MovieRepositoryInterface, ratingRepo RatingRepositoryInterface, watchlistRepo WatchlistRepositoryInterface,
+	viewRepo ViewRepositoryInterface, settings models.TrendingSettings) *TrendingService {
+	return &TrendingService{
+		movieRepo:     movieRepo,
+		ratingRepo:    ratingRepo,
+		watchlistRepo: watchlistRepo,
+		viewRepo:      viewRepo,
+		settings:      settings,
+		cache:         make(map[models.TrendingPeriod]*models.TrendingScores),
+		views:         make(chan int, max(settings.ViewBuffer, 0)),
+	}
+}
+
+// decay weight of action done at given time, it halves every half-life of period
+func decay(at, now time.Time, halfLife time.Duration) float64 {
+	age := now.Sub(at)
+	if age < 0 {
+		age = 0
+	}
+	return math.Exp2(-float64(age) / float64(halfLife))
+}
+
+// computeScores sums decayed weights of user actions done within period before now by movie id,
+// actions on movies which are not in catalog are skipped
+func (s *TrendingService) computeScores(ctx context.Context, period models.TrendingPeriod, now time.Time) (map[int]float64, error) {
+	window, ok := period.Window()
+	if !ok {
+		return nil, errs.ErrInvalidTrendingPeriod
+	}
+	since, halfLife := now.Add(-window), period.HalfLife()
+	weights := s.settings.Weights
+
+	movies, err := s.movieRepo.GetAllMovies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int]float64, len(movies))
+	inCatalog := make(map[int]bool, len(movies))
+	for _, movie := range movies {
+		inCatalog[movie.ID] = true
+		for _, review := range movie.Reviews {
+			if !review.CreatedAt.Before(since) {
+				res[movie.ID] += weights.Review * decay(review.CreatedAt, now, halfLife)
+			}
+		}
+	}
+
+	ratings, err := s.ratingRepo.GetAllRatings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, rating := range ratings {
+		if inCatalog[rating.MovieID] && !rating.UpdatedAt.Before(since) {
+			res[rating.MovieID] += weights.Rating * decay(rating.UpdatedAt, now, halfLife)
+		}
+	}
+
+	additions, err := s.watchlistRepo.GetAdditions(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range additions {
+		if inCatalog[entry.MovieID] {
+			res[entry.MovieID] += weights.Watchlist * decay(entry.AddedAt, now, halfLife)
+		}
+	}
+
+	views, err := s.viewRepo.GetViews(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	for _, hour := range views {
+		if inCatalog[hour.MovieID] {
+			// views of hour are taken as made in its middle
+			res[hour.MovieID] += weights.View * float64(hour.Count) * decay(hour.Hour.Add(time.Hour/2), now, halfLife)
+		}
+	}
+
+	for movieID, score := range res {
+		if score <= 0 {
+			delete(res, movieID)
+		}
+	}
+	return res, nil
+}
+
+// scores returns scores of movies for period, they are recomputed when cached ones are older than cache TTL
+func (s *TrendingService) scores(ctx context.Context, period models.TrendingPeriod, now time.Time) (*models.TrendingScores, error) {
+	s.mu.Lock()
+	cached, ok := s.cache[period]
+	s.mu.Unlock()
+	if ok && now.Sub(cached.ComputedAt) < s.settings.CacheTTL {
+		return cached, nil
+	}
+
+	scores, err := s.computeScores(ctx, period, now)
+	if err != nil {
+		return nil, err
+	}
+	res := &models.TrendingScores{Scores: scores, ComputedAt: now}
+
+	s.mu.Lock()
+	s.cache[period] = res
+	s.mu.Unlock()
+
+	return res, nil
+}
+
+// GetTrendingScores returns scores of recent activity by movie id and time they were computed at
+func (s *TrendingService) GetTrendingScores(ctx context.Context, period models.TrendingPeriod) (*models.TrendingScores, error) {
+	logger := log.Ctx(ctx)
+
+	res, err := s.scores(ctx, period, time.Now().UTC())
+	if err != nil {
+		logger.Error().Err(err).Str("period", string(period)).Msg(err.Error())
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetTrending returns at most limit movies with the most recent activity, the most active first
+func (s *TrendingService) GetTrending(ctx context.Context, period models.TrendingPeriod, limit int) ([]models.TrendingMovie, error) {
+	logger := log.Ctx(ctx)
+
+	scores, err := s.GetTrendingScores(ctx, period)
+	if err != nil {
+		return nil, err
+	}
+
+	movies, err := s.movieRepo.GetAllMovies(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	res := make([]models.TrendingMovie, 0, len(scores.Scores))
+	for _, movie := range movies {
+		if score, ok := scores.Scores[movie.ID]; ok {
+			res = append(res, models.TrendingMovie{Movie: movie, Score: score})
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Score != res[j].Score {
+			return res[i].Score > res[j].Score
+		}
+		return res[i].Movie.ID < res[j].Movie.ID
+	})
+
+	return res[:min(len(res), limit)], nil
+}
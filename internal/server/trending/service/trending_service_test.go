@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	repoMovie "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/repository"
+	repoRating "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/rating/repository"
+	repoTrending "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/trending/repository"
+	repoWatchlist "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/watchlist/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	now     = time.Date(2025, time.April, 10, 12, 0, 0, 0, time.UTC)
+	weights = models.TrendingWeights{View: 1, Rating: 3, Review: 5, Watchlist: 4}
+)
+
+type trendingRepos struct {
+	ratings   *repoRating.RatingRepository
+	watchlist *repoWatchlist.WatchlistRepository
+	views     *repoTrending.ViewRepository
+}
+
+func newTrendingService(movies *mocks.Movies, settings models.TrendingSettings) (*TrendingService, trendingRepos) {
+	repos := trendingRepos{
+		ratings:   repoRating.NewRatingRepository(),
+		watchlist: repoWatchlist.NewWatchlistRepository(),
+		views:     repoTrending.NewViewRepository(),
+	}
+	return NewTrendingService(repoMovie.NewMovieRepository(movies), repos.ratings, repos.watchlist, repos.views, settings), repos
+}
+
+func TestTrendingService_ComputeScores(t *testing.T) {
+	ctx := context.Background()
+	movies := mocks.Movies{
+		1: {ID: 1, Name: "Бойцовский клуб", Reviews: []models.Review{{ID: 1, CreatedAt: now.Add(-time.Hour * 6)}}},
+		2: {ID: 2, Name: "Амели"},
+		3: {ID: 3, Name: "Семь", Reviews: []models.Review{{ID: 2, CreatedAt: now.Add(-time.Hour * 48)}}},
+		4: {ID: 4, Name: "Джокер"},
+	}
+	s, repos := newTrendingService(&movies, models.TrendingSettings{Weights: weights})
+
+	require.NoError(t, repos.ratings.SetRating(ctx, models.Rating{UserID: 1, MovieID: 2, Score: 8, UpdatedAt: now}))
+	require.NoError(t, repos.ratings.SetRating(ctx, models.Rating{UserID: 1, MovieID: 3, Score: 8, UpdatedAt: now.Add(-time.Hour * 30)}))
+	_, err := repos.watchlist.AddMovies(ctx, 1, []int{2}, now.Add(-time.Hour*12))
+	require.NoError(t, err)
+	require.NoError(t, repos.views.AddViews(ctx, map[int]int{4: 10}, now.Add(-time.Minute*450)))
+	// views of deleted movies are not ranked
+	require.NoError(t, repos.views.AddViews(ctx, map[int]int{42: 100}, now))
+
+	scores, err := s.computeScores(ctx, models.TrendingDay, now)
+	require.NoError(t, err)
+	// a day has half-life of 6 hours, views of hour are taken as made in its middle
+	assert.InDelta(t, 5*0.5, scores[1], 1e-9)
+	assert.InDelta(t, 3+4*0.25, scores[2], 1e-9)
+	assert.InDelta(t, 10*math.Exp2(-7.5/6), scores[4], 1e-9)
+	assert.NotContains(t, scores, 3, "activity older than a day")
+	assert.NotContains(t, scores, 42)
+
+	scores, err = s.computeScores(ctx, models.TrendingWeek, now)
+	require.NoError(t, err)
+	assert.Contains(t, scores, 3)
+	assert.Greater(t, scores[2], scores[3])
+
+	_, err = s.computeScores(ctx, "year", now)
+	require.ErrorIs(t, err, errs.ErrInvalidTrendingPeriod)
+}
+
+func TestTrendingService_GetTrending(t *testing.T) {
+	ctx := context.Background()
+	movies := mocks.Movies{
+		1: {ID: 1, Name: "Бойцовский клуб"},
+		2: {ID: 2, Name: "Амели"},
+		3: {ID: 3, Name: "Семь"},
+	}
+	s, repos := newTrendingService(&movies, models.TrendingSettings{Weights: weights, CacheTTL: time.Hour})
+
+	recent := time.Now().UTC()
+	require.NoError(t, repos.ratings.SetRating(ctx, models.Rating{UserID: 1, MovieID: 3, Score: 9, UpdatedAt: recent}))
+	_, err := repos.watchlist.AddMovies(ctx, 1, []int{1, 3}, recent)
+	require.NoError(t, err)
+
+	trending, err := s.GetTrending(ctx, models.TrendingWeek, 10)
+	require.NoError(t, err)
+	require.Len(t, trending, 2)
+	assert.Equal(t, 3, trending[0].Movie.ID)
+	assert.Equal(t, 1, trending[1].Movie.ID)
+
+	// scores are cached, new activity is seen after cache TTL
+	_, err = repos.watchlist.AddMovies(ctx, 2, []int{2}, recent)
+	require.NoError(t, err)
+	trending, err = s.GetTrending(ctx, models.TrendingWeek, 1)
+	require.NoError(t, err)
+	require.Len(t, trending, 1)
+	assert.Equal(t, 3, trending[0].Movie.ID)
+
+	trending, err = s.GetTrending(ctx, models.TrendingMonth, 10)
+	require.NoError(t, err)
+	assert.Len(t, trending, 3)
+
+	_, err = s.GetTrending(ctx, "", 10)
+	require.ErrorIs(t, err, errs.ErrInvalidTrendingPeriod)
+}
+
+func TestTrendingService_CountView(t *testing.T) {
+	ctx := context.Background()
+	movies := mocks.Movies{1: {ID: 1, Name: "Бойцовский клуб"}}
+	s, repos := newTrendingService(&movies, models.TrendingSettings{Weights: weights, ViewBuffer: 2, ViewFlushInterval: time.Hour})
+
+	// nothing reads views before start, so views beyond buffer are dropped instead of blocking
+	for i := 0; i < 5; i++ {
+		s.CountView(1)
+	}
+
+	s.Start(ctx)
+	s.Stop()
+
+	views, err := repos.views.GetViews(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Len(t, views, 1)
+	assert.Equal(t, 1, views[0].MovieID)
+	assert.Equal(t, 2, views[0].Count)
+}
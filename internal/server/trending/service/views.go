@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/rs/zerolog/log"
+)
+
+// CountView registers view of movie page, it never blocks request, view is dropped when buffer is full
+func (s *TrendingService) CountView(movieID int) {
+	select {
+	case s.views <- movieID:
+	default:
+	}
+}
+
+// flushViews saves counted views and forgets ones too old for any period
+func (s *TrendingService) flushViews(ctx context.Context, counts map[int]int, now time.Time) {
+	if len(counts) > 0 {
+		if err := s.viewRepo.AddViews(ctx, counts, now); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg(err.Error())
+			return
+		}
+		clear(counts)
+	}
+
+	window, _ := models.TrendingMonth.Window()
+	if err := s.viewRepo.Prune(ctx, now.Add(-window)); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg(err.Error())
+	}
+}
+
+// Start counts viewed pages in background and saves them periodically until Stop is called
+func (s *TrendingService) Start(ctx context.Context) {
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+
+	interval := s.settings.ViewFlushInterval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		counts := make(map[int]int)
+		for {
+			select {
+			case movieID := <-s.views:
+				counts[movieID]++
+			case <-ticker.C:
+				s.flushViews(ctx, counts, time.Now().UTC())
+			case <-s.stop:
+				// views sent before stop are counted too
+				for len(s.views) > 0 {
+					counts[<-s.views]++
+				}
+				s.flushViews(ctx, counts, time.Now().UTC())
+				return
+			}
+		}
+	}()
+}
+
+// Stop saves counted views and stops background job
+func (s *TrendingService) Stop() {
+	if s.stop != nil {
+		close(s.stop)
+		<-s.done
+		s.stop = nil
+	}
+}
@@ -102,6 +102,7 @@ func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	newUser := &models.User{
+		ID:             user.ID,
 		Username:       userReq.Username,
 		HashedPassword: string(hashedPass),
 		Avatar:         userReq.Avatar,
@@ -16,7 +16,22 @@ func (r *UserRepository) CreateUser(ctx context.Context, user *models.User) erro
 		return errors.New(errs.ErrAlreadyExists)
 	}
 
+	// user keeps its id when it is recreated on profile update
+	if user.ID == 0 {
+		user.ID = r.nextID
+	}
+	r.nextID = max(r.nextID, user.ID+1)
+
 	r.rdb[user.Username] = user
 
 	return nil
 }
+
+// ReserveIDs makes repository assign new users ids greater than lastID,
+// e.g. ids of review authors who have no accounts
+func (r *UserRepository) ReserveIDs(lastID int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID = max(r.nextID, lastID+1)
+}
@@ -19,3 +19,17 @@ func (r *UserRepository) GetUser(ctx context.Context, login string) (*models.Use
 
 	return user, nil
 }
+
+// GetUserByID returns user by id, it stays the same when user changes login
+func (r *UserRepository) GetUserByID(ctx context.Context, userID int) (*models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, user := range r.rdb {
+		if user.ID == userID {
+			return user, nil
+		}
+	}
+
+	return nil, errors.New(errs.ErrUserIDNotFound)
+}
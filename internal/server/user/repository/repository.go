@@ -7,12 +7,14 @@ import (
 )
 
 type UserRepository struct {
-	mu  sync.RWMutex
-	rdb map[string]*models.User
+	mu     sync.RWMutex
+	rdb    map[string]*models.User
+	nextID int
 }
 
 func NewUserRepository() *UserRepository {
 	return &UserRepository{
-		rdb: make(map[string]*models.User),
+		rdb:    make(map[string]*models.User),
+		nextID: 1,
 	}
 }
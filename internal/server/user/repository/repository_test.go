@@ -116,6 +116,22 @@ func TestUserRepository_DeleteUser(t *testing.T) {
 	}
 }
 
+func TestUserRepository_ReserveIDs(t *testing.T) {
+	ctx := context.Background()
+
+	r := NewUserRepository()
+	r.ReserveIDs(310)
+	user := &models.User{Username: "user"}
+	assert.NoError(t, r.CreateUser(ctx, user))
+	assert.Equal(t, 311, user.ID)
+
+	// reserving lower ids does not reuse assigned ones
+	r.ReserveIDs(5)
+	user = &models.User{Username: "other"}
+	assert.NoError(t, r.CreateUser(ctx, user))
+	assert.Equal(t, 312, user.ID)
+}
+
 func TestUserRepository_GetUser(t *testing.T) {
 	ctx := context.Background()
 
@@ -137,6 +153,7 @@ func TestUserRepository_GetUser(t *testing.T) {
 			},
 			login: "user",
 			expectedUser: &models.User{
+				ID:             1,
 				Username:       "user",
 				HashedPassword: "password",
 				Avatar:         "avatar/url.png",
@@ -172,3 +189,98 @@ func TestUserRepository_GetUser(t *testing.T) {
 		})
 	}
 }
+
+func TestUserRepository_GetUserByID(t *testing.T) {
+	ctx := context.Background()
+
+	r := NewUserRepository()
+	assert.NoError(t, r.CreateUser(ctx, &models.User{Username: "user"}))
+	assert.NoError(t, r.CreateUser(ctx, &models.User{Username: "other"}))
+
+	// renamed user is recreated with the same id
+	assert.NoError(t, r.DeleteUser(ctx, "user"))
+	assert.NoError(t, r.CreateUser(ctx, &models.User{ID: 1, Username: "renamed"}))
+
+	user, err := r.GetUserByID(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "renamed", user.Username)
+
+	_, err = r.GetUserByID(ctx, 3)
+	assert.EqualError(t, err, errs.ErrUserIDNotFound)
+}
+
+func TestUserRepository_SnapshotRestore(t *testing.T) {
+	ctx := context.Background()
+
+	r := NewUserRepository()
+	user := &models.User{
+		Username:       "user",
+		HashedPassword: "password",
+		Avatar:         "avatar/url.png",
+	}
+	assert.NoError(t, r.CreateUser(ctx, user))
+
+	data, err := r.Snapshot(ctx)
+	assert.NoError(t, err)
+
+	restored := NewUserRepository()
+	assert.NoError(t, restored.Restore(ctx, data))
+
+	got, err := restored.GetUser(ctx, "user")
+	assert.NoError(t, err)
+	assert.Equal(t, user.HashedPassword, got.HashedPassword)
+	assert.Equal(t, user.Avatar, got.Avatar)
+	assert.Equal(t, user.ID, got.ID)
+
+	next := &models.User{Username: "next"}
+	assert.NoError(t, restored.CreateUser(ctx, next))
+	assert.Equal(t, user.ID+1, next.ID)
+
+	assert.Error(t, restored.Restore(ctx, []byte("not json")))
+}
+
+func TestUserRepository_RestoreWithoutIDs(t *testing.T) {
+	ctx := context.Background()
+
+	// users of old snapshots have no ids
+	data := []byte(`[
+		{"username": "late", "created_at": "2025-03-02T00:00:00Z"},
+		{"id": 4, "username": "known", "created_at": "2025-03-03T00:00:00Z"},
+		{"username": "early", "created_at": "2025-03-01T00:00:00Z"}
+	]`)
+
+	r := NewUserRepository()
+	assert.NoError(t, r.Restore(ctx, data))
+
+	for username, id := range map[string]int{"known": 4, "early": 5, "late": 6} {
+		got, err := r.GetUser(ctx, username)
+		assert.NoError(t, err)
+		assert.Equal(t, id, got.ID, username)
+	}
+
+	next := &models.User{Username: "next"}
+	assert.NoError(t, r.CreateUser(ctx, next))
+	assert.Equal(t, 7, next.ID)
+}
+
+func TestUserRepository_CreateUserID(t *testing.T) {
+	ctx := context.Background()
+	r := NewUserRepository()
+
+	first := &models.User{Username: "first"}
+	assert.NoError(t, r.CreateUser(ctx, first))
+	assert.Equal(t, 1, first.ID)
+
+	// recreated user keeps its id
+	assert.NoError(t, r.DeleteUser(ctx, "first"))
+	renamed := &models.User{ID: first.ID, Username: "renamed"}
+	assert.NoError(t, r.CreateUser(ctx, renamed))
+	assert.Equal(t, 1, renamed.ID)
+
+	imported := &models.User{ID: 10, Username: "imported"}
+	assert.NoError(t, r.CreateUser(ctx, imported))
+
+	second := &models.User{Username: "second"}
+	assert.NoError(t, r.CreateUser(ctx, second))
+	assert.Equal(t, 11, second.ID)
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+)
+
+// userRecord keeps fields hidden from json in models.User, e.g. password hash
+type userRecord struct {
+	ID             int       `json:"id"`
+	Username       string    `json:"username"`
+	HashedPassword string    `json:"hashed_password"`
+	Avatar         string    `json:"avatar"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// Snapshot serializes all users
+func (r *UserRepository) Snapshot(ctx context.Context) ([]byte, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	records := make([]userRecord, 0, len(r.rdb))
+	for _, user := range r.rdb {
+		records = append(records, userRecord{
+			ID:             user.ID,
+			Username:       user.Username,
+			HashedPassword: user.HashedPassword,
+			Avatar:         user.Avatar,
+			CreatedAt:      user.CreatedAt,
+			UpdatedAt:      user.UpdatedAt,
+		})
+	}
+
+	return json.Marshal(records)
+}
+
+// Restore replaces all users with ones from snapshot
+func (r *UserRepository) Restore(ctx context.Context, data []byte) error {
+	var records []userRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return err
+	}
+
+	nextID := 1
+	for _, record := range records {
+		nextID = max(nextID, record.ID+1)
+	}
+
+	// snapshots made before users had ids keep none, such users get new ids in order of registration
+	sort.SliceStable(records, func(i, j int) bool {
+		if !records[i].CreatedAt.Equal(records[j].CreatedAt) {
+			return records[i].CreatedAt.Before(records[j].CreatedAt)
+		}
+		return records[i].Username < records[j].Username
+	})
+	for i := range records {
+		if records[i].ID == 0 {
+			records[i].ID = nextID
+			nextID++
+		}
+	}
+
+	rdb := make(map[string]*models.User, len(records))
+	for _, record := range records {
+		rdb[record.Username] = &models.User{
+			ID:             record.ID,
+			Username:       record.Username,
+			HashedPassword: record.HashedPassword,
+			Avatar:         record.Avatar,
+			CreatedAt:      record.CreatedAt,
+			UpdatedAt:      record.UpdatedAt,
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rdb = rdb
+	r.nextID = nextID
+
+	return nil
+}
@@ -3,11 +3,19 @@ package service
 import (
 	"context"
 
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
 	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 )
 
+// CreateUser registers user, logins of admin accounts are taken by them
 func (s *UserService) CreateUser(ctx context.Context, user *models.User) error {
+	if s.isAdminLogin(user.Username) {
+		log.Error().Str("username", user.Username).Msg(errs.ErrAlreadyExists)
+		return errors.New(errs.ErrAlreadyExists)
+	}
+
 	err := s.repo.CreateUser(ctx, user)
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
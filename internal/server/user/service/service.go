@@ -15,10 +15,23 @@ type UserRepositoryInterface interface {
 
 type UserService struct {
 	repo UserRepositoryInterface
+	// adminLogins logins of admin accounts, users can not register or rename to them
+	adminLogins map[string]struct{}
 }
 
-func NewUserService(repo UserRepositoryInterface) *UserService {
+func NewUserService(repo UserRepositoryInterface, adminLogins []string) *UserService {
+	reserved := make(map[string]struct{}, len(adminLogins))
+	for _, login := range adminLogins {
+		reserved[login] = struct{}{}
+	}
+
 	return &UserService{
-		repo: repo,
+		repo:        repo,
+		adminLogins: reserved,
 	}
 }
+
+func (s *UserService) isAdminLogin(login string) bool {
+	_, ok := s.adminLogins[login]
+	return ok
+}
@@ -18,7 +18,7 @@ func TestNewUserService(t *testing.T) {
 	defer ctrl.Finish()
 
 	r := mockRepo.NewMockUserRepositoryInterface(ctrl)
-	s := NewUserService(r)
+	s := NewUserService(r, nil)
 
 	assert.NotNil(t, s)
 }
@@ -77,7 +77,7 @@ func TestUserService_CreateUser(t *testing.T) {
 				tt.mockSetupFunc(t, r)
 			}
 
-			s := NewUserService(r)
+			s := NewUserService(r, nil)
 			err := s.CreateUser(context.Background(), tt.user)
 
 			if tt.expectedError != nil {
@@ -128,7 +128,7 @@ func TestUserService_DeleteUser(t *testing.T) {
 				tt.mockSetupFunc(t, r)
 			}
 
-			s := NewUserService(r)
+			s := NewUserService(r, nil)
 			err := s.DeleteUser(context.Background(), tt.username)
 
 			if tt.expectedError != nil {
@@ -190,7 +190,7 @@ func TestUserService_GetUser(t *testing.T) {
 				tt.mockSetupFunc(t, r)
 			}
 
-			s := NewUserService(r)
+			s := NewUserService(r, nil)
 			user, err := s.GetUser(context.Background(), tt.username)
 
 			assert.Equal(t, tt.expectedUser, user)
@@ -271,7 +271,7 @@ func TestUserService_Login(t *testing.T) {
 				tt.mockSetupFunc(t, r)
 			}
 
-			s := NewUserService(r)
+			s := NewUserService(r, nil)
 			err := s.Login(context.Background(), tt.loginData)
 
 			if tt.expectedError != nil {
@@ -358,7 +358,7 @@ func TestUserService_UpdateUser(t *testing.T) {
 				tt.mockSetupFunc(t, r)
 			}
 
-			s := NewUserService(r)
+			s := NewUserService(r, nil)
 			err := s.UpdateUser(context.Background(), tt.login, tt.newUser)
 
 			if tt.expectedError != nil {
@@ -370,3 +370,51 @@ func TestUserService_UpdateUser(t *testing.T) {
 		})
 	}
 }
+
+func TestUserService_AdminLogins(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := mockRepo.NewMockUserRepositoryInterface(ctrl)
+	s := NewUserService(r, []string{"admin"})
+
+	// repository is not asked to register or rename user to admin login
+	err := s.CreateUser(ctx, &models.User{Username: "admin"})
+	assert.EqualError(t, err, errs.ErrAlreadyExists)
+	err = s.UpdateUser(ctx, "user", &models.User{Username: "admin"})
+	assert.EqualError(t, err, errs.ErrAlreadyExists)
+
+	// admin keeps own login on profile update
+	r.EXPECT().DeleteUser(gomock.Any(), "admin").Return(nil).Times(1)
+	r.EXPECT().CreateUser(gomock.Any(), &models.User{Username: "admin", Avatar: "admin.png"}).Return(nil).Times(1)
+	assert.NoError(t, s.UpdateUser(ctx, "admin", &models.User{Username: "admin", Avatar: "admin.png"}))
+}
+
+func TestUserService_EnsureAdmin(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret_password"), bcrypt.MinCost)
+	assert.NoError(t, err)
+
+	r := mockRepo.NewMockUserRepositoryInterface(ctrl)
+	s := NewUserService(r, []string{"admin"})
+
+	assert.Error(t, s.EnsureAdmin(ctx, "admin", "not a hash"))
+
+	// password of user who took admin login is replaced, id is kept
+	r.EXPECT().GetUser(gomock.Any(), "admin").Return(&models.User{ID: 7, Username: "admin", HashedPassword: "other"}, nil).Times(1)
+	r.EXPECT().DeleteUser(gomock.Any(), "admin").Return(nil).Times(1)
+	r.EXPECT().CreateUser(gomock.Any(), &models.User{ID: 7, Username: "admin", HashedPassword: string(hash)}).Return(nil).Times(1)
+	assert.NoError(t, s.EnsureAdmin(ctx, "admin", string(hash)))
+
+	r.EXPECT().GetUser(gomock.Any(), "admin").Return(nil, errors.New(errs.ErrIncorrectLogin)).Times(1)
+	r.EXPECT().CreateUser(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, user *models.User) error {
+		assert.Equal(t, "admin", user.Username)
+		assert.Equal(t, string(hash), user.HashedPassword)
+		return nil
+	}).Times(1)
+	assert.NoError(t, s.EnsureAdmin(ctx, "admin", string(hash)))
+}
@@ -2,12 +2,22 @@ package service
 
 import (
 	"context"
+	"time"
 
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
 	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/bcrypt"
 )
 
+// UpdateUser replaces user, it can not be renamed to login of admin account
 func (s *UserService) UpdateUser(ctx context.Context, login string, newUser *models.User) error {
+	if newUser.Username != login && s.isAdminLogin(newUser.Username) {
+		log.Error().Str("username", newUser.Username).Msg(errs.ErrAlreadyExists)
+		return errors.New(errs.ErrAlreadyExists)
+	}
+
 	if err := s.DeleteUser(ctx, login); err != nil {
 		log.Error().Err(err).Msg(err.Error())
 		return err
@@ -20,3 +30,39 @@ func (s *UserService) UpdateUser(ctx context.Context, login string, newUser *mod
 
 	return nil
 }
+
+// EnsureAdmin creates admin account with given bcrypt password hash or resets password of existing one.
+// User who registered the login before it was configured for admin loses it
+func (s *UserService) EnsureAdmin(ctx context.Context, login, passwordHash string) error {
+	logger := log.Ctx(ctx)
+
+	if _, err := bcrypt.Cost([]byte(passwordHash)); err != nil {
+		err = errors.Wrapf(err, "admin %s: bad password hash", login)
+		logger.Error().Err(err).Msg(err.Error())
+		return err
+	}
+
+	admin := &models.User{Username: login, HashedPassword: passwordHash}
+	existing, err := s.repo.GetUser(ctx, login)
+	if err == nil {
+		admin.ID = existing.ID
+		admin.Avatar = existing.Avatar
+		admin.CreatedAt = existing.CreatedAt
+		admin.UpdatedAt = existing.UpdatedAt
+		if err = s.repo.DeleteUser(ctx, login); err != nil {
+			logger.Error().Err(err).Msg(err.Error())
+			return err
+		}
+	} else {
+		admin.CreatedAt = time.Now()
+		admin.UpdatedAt = admin.CreatedAt
+	}
+
+	if err = s.repo.CreateUser(ctx, admin); err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return err
+	}
+
+	logger.Info().Str("username", login).Msg("admin account is ready")
+	return nil
+}
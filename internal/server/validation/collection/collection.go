@@ -0,0 +1,138 @@
+package collection
+
+import (
+	"regexp"
+	"unicode/utf8"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/textsearch"
+	"github.com/pkg/errors"
+)
+
+const (
+	MaxTitleLength       = 100
+	MaxDescriptionLength = 2000
+	MaxNoteLength        = 500
+	MaxEntries           = 500
+	MaxSlugLength        = 100
+)
+
+var (
+	slugRegexp    = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	numericRegexp = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// numericSlugPrefix is added to slug made of number, numeric keys address collections by id
+const numericSlugPrefix = "collection-"
+
+func IsValidTitle(title string) error {
+	if title == "" {
+		return errors.New(errs.ErrEmptyListTitle)
+	}
+	if utf8.RuneCountInString(title) > MaxTitleLength {
+		return errors.New(errs.ErrListTitleTooLong)
+	}
+	return nil
+}
+
+func IsValidDescription(description string) error {
+	if utf8.RuneCountInString(description) > MaxDescriptionLength {
+		return errors.New(errs.ErrListDescriptionTooLong)
+	}
+	return nil
+}
+
+func IsValidVisibility(visibility models.ListVisibility) error {
+	switch visibility {
+	case models.VisibilityPublic, models.VisibilityUnlisted, models.VisibilityPrivate:
+		return nil
+	}
+	return errors.New(errs.ErrInvalidVisibility)
+}
+
+// IsValidEntries checks list entries, every movie may be in list once
+func IsValidEntries(entries []models.UserListEntry) error {
+	if len(entries) > MaxEntries {
+		return errors.New(errs.ErrTooManyListEntries)
+	}
+
+	seen := make(map[int]struct{}, len(entries))
+	for _, entry := range entries {
+		if _, ok := seen[entry.MovieID]; ok {
+			return errors.New(errs.ErrDuplicateListEntry)
+		}
+		seen[entry.MovieID] = struct{}{}
+
+		if utf8.RuneCountInString(entry.Note) > MaxNoteLength {
+			return errors.New(errs.ErrListNoteTooLong)
+		}
+	}
+	return nil
+}
+
+func IsValidName(name string) error {
+	if name == "" {
+		return errors.New(errs.ErrEmptyCollectionName)
+	}
+	if utf8.RuneCountInString(name) > MaxTitleLength {
+		return errors.New(errs.ErrCollectionNameTooLong)
+	}
+	return nil
+}
+
+// IsValidSlug checks collection slug like "luchshie-za-vse-vremya", slug must not be a number
+// as numeric keys address collections by id
+func IsValidSlug(slug string) error {
+	if len(slug) > MaxSlugLength || !slugRegexp.MatchString(slug) || numericRegexp.MatchString(slug) {
+		return errors.New(errs.ErrInvalidSlug)
+	}
+	return nil
+}
+
+// SlugFromName makes slug of collection name, e.g. "Лучшие за всё время" -> "luchshie-za-vse-vremya"
+// and "2024" -> "collection-2024"
+func SlugFromName(name string) string {
+	slug := textsearch.Slug(name)
+	if numericRegexp.MatchString(slug) {
+		return numericSlugPrefix + slug
+	}
+	return slug
+}
+
+// IsValidMovieIDs checks movies of collection, every movie may be in collection once
+func IsValidMovieIDs(movieIDs []int) error {
+	if len(movieIDs) > MaxEntries {
+		return errors.New(errs.ErrTooManyListEntries)
+	}
+
+	seen := make(map[int]struct{}, len(movieIDs))
+	for _, id := range movieIDs {
+		if _, ok := seen[id]; ok {
+			return errors.New(errs.ErrDuplicateListEntry)
+		}
+		seen[id] = struct{}{}
+	}
+	return nil
+}
+
+// IsValidRule checks rule of smart collection, ranges of filter must not be empty
+func IsValidRule(rule models.SmartRule) error {
+	switch rule.Sort {
+	case models.SortByRating, models.SortByYear, models.SortByPopularity, models.SortByTitle:
+	default:
+		return errors.New(errs.ErrInvalidRuleSort)
+	}
+
+	if rule.Limit <= 0 || rule.Limit > MaxEntries {
+		return errors.New(errs.ErrInvalidRuleLimit)
+	}
+
+	f := rule.Filter
+	if f.YearTo > 0 && f.YearFrom > f.YearTo ||
+		f.RatingTo > 0 && f.RatingFrom > f.RatingTo ||
+		f.DurationTo > 0 && f.DurationFrom > f.DurationTo {
+		return errors.New(errs.ErrInvalidRuleRange)
+	}
+	return nil
+}
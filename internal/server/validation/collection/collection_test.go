@@ -0,0 +1,133 @@
+package collection
+
+import (
+	"strings"
+	"testing"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidTitle(t *testing.T) {
+	require.NoError(t, IsValidTitle("Лучшее"))
+	require.NoError(t, IsValidTitle(strings.Repeat("ж", MaxTitleLength)))
+
+	err := IsValidTitle("")
+	require.Error(t, err)
+	require.Equal(t, errs.ErrEmptyListTitle, err.Error())
+
+	err = IsValidTitle(strings.Repeat("ж", MaxTitleLength+1))
+	require.Error(t, err)
+	require.Equal(t, errs.ErrListTitleTooLong, err.Error())
+}
+
+func TestIsValidDescription(t *testing.T) {
+	require.NoError(t, IsValidDescription(""))
+	require.NoError(t, IsValidDescription(strings.Repeat("ж", MaxDescriptionLength)))
+
+	err := IsValidDescription(strings.Repeat("ж", MaxDescriptionLength+1))
+	require.Error(t, err)
+	require.Equal(t, errs.ErrListDescriptionTooLong, err.Error())
+}
+
+func TestIsValidVisibility(t *testing.T) {
+	require.NoError(t, IsValidVisibility(models.VisibilityPublic))
+	require.NoError(t, IsValidVisibility(models.VisibilityUnlisted))
+	require.NoError(t, IsValidVisibility(models.VisibilityPrivate))
+
+	err := IsValidVisibility("friends")
+	require.Error(t, err)
+	require.Equal(t, errs.ErrInvalidVisibility, err.Error())
+}
+
+func TestIsValidEntries(t *testing.T) {
+	require.NoError(t, IsValidEntries(nil))
+	require.NoError(t, IsValidEntries([]models.UserListEntry{{MovieID: 1}, {MovieID: 2, Note: "пересмотреть"}}))
+
+	err := IsValidEntries([]models.UserListEntry{{MovieID: 1}, {MovieID: 2}, {MovieID: 1}})
+	require.Error(t, err)
+	require.Equal(t, errs.ErrDuplicateListEntry, err.Error())
+
+	err = IsValidEntries([]models.UserListEntry{{MovieID: 1, Note: strings.Repeat("ж", MaxNoteLength+1)}})
+	require.Error(t, err)
+	require.Equal(t, errs.ErrListNoteTooLong, err.Error())
+
+	entries := make([]models.UserListEntry, MaxEntries+1)
+	for i := range entries {
+		entries[i].MovieID = i + 1
+	}
+	err = IsValidEntries(entries)
+	require.Error(t, err)
+	require.Equal(t, errs.ErrTooManyListEntries, err.Error())
+}
+
+func TestIsValidName(t *testing.T) {
+	require.NoError(t, IsValidName("Лучшие за всё время"))
+
+	err := IsValidName("")
+	require.Error(t, err)
+	require.Equal(t, errs.ErrEmptyCollectionName, err.Error())
+
+	err = IsValidName(strings.Repeat("ж", MaxTitleLength+1))
+	require.Error(t, err)
+	require.Equal(t, errs.ErrCollectionNameTooLong, err.Error())
+}
+
+func TestIsValidSlug(t *testing.T) {
+	require.NoError(t, IsValidSlug("luchshie-za-vse-vremya"))
+	require.NoError(t, IsValidSlug("top250"))
+	require.NoError(t, IsValidSlug("2024-top"))
+
+	for _, slug := range []string{"", "-top", "top-", "top--250", "Top", "лучшие", "250", strings.Repeat("a", MaxSlugLength+1)} {
+		err := IsValidSlug(slug)
+		require.Error(t, err, slug)
+		require.Equal(t, errs.ErrInvalidSlug, err.Error())
+	}
+}
+
+func TestSlugFromName(t *testing.T) {
+	require.Equal(t, "luchshie-za-vse-vremya", SlugFromName("Лучшие за всё время"))
+	require.Equal(t, "collection-2024", SlugFromName("2024"))
+	require.NoError(t, IsValidSlug(SlugFromName("2024")))
+}
+
+func TestIsValidMovieIDs(t *testing.T) {
+	require.NoError(t, IsValidMovieIDs(nil))
+	require.NoError(t, IsValidMovieIDs([]int{3, 1, 2}))
+
+	err := IsValidMovieIDs([]int{3, 1, 3})
+	require.Error(t, err)
+	require.Equal(t, errs.ErrDuplicateListEntry, err.Error())
+}
+
+func TestIsValidRule(t *testing.T) {
+	rule := models.SmartRule{
+		Filter: models.MovieFilter{GenreIDs: []int{8}, YearTo: 1999, RatingFrom: 8},
+		Sort:   models.SortByRating,
+		Desc:   true,
+		Limit:  50,
+	}
+	require.NoError(t, IsValidRule(rule))
+
+	tests := []struct {
+		name    string
+		modify  func(rule *models.SmartRule)
+		wantErr string
+	}{
+		{name: "sort", modify: func(rule *models.SmartRule) { rule.Sort = "budget" }, wantErr: errs.ErrInvalidRuleSort},
+		{name: "no limit", modify: func(rule *models.SmartRule) { rule.Limit = 0 }, wantErr: errs.ErrInvalidRuleLimit},
+		{name: "big limit", modify: func(rule *models.SmartRule) { rule.Limit = MaxEntries + 1 }, wantErr: errs.ErrInvalidRuleLimit},
+		{name: "years", modify: func(rule *models.SmartRule) { rule.Filter.YearFrom = 2000 }, wantErr: errs.ErrInvalidRuleRange},
+		{name: "rating", modify: func(rule *models.SmartRule) { rule.Filter.RatingTo = 7 }, wantErr: errs.ErrInvalidRuleRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := rule
+			tt.modify(&rule)
+			err := IsValidRule(rule)
+			require.Error(t, err)
+			require.Equal(t, tt.wantErr, err.Error())
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package diary
+
+import (
+	"time"
+	"unicode/utf8"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/pkg/errors"
+)
+
+const (
+	MaxNoteLength = 1000
+)
+
+// IsValidWatchDate allows today in any time zone, so date may be one day ahead of UTC
+func IsValidWatchDate(date time.Time, now time.Time) error {
+	if date.After(now.AddDate(0, 0, 1)) {
+		return errors.New(errs.ErrFutureWatchDate)
+	}
+	return nil
+}
+
+func IsValidNote(note string) error {
+	if utf8.RuneCountInString(note) > MaxNoteLength {
+		return errors.New(errs.ErrDiaryNoteTooLong)
+	}
+	return nil
+}
+
+// IsValidPeriod checks diary filter, zero values mean whole diary
+func IsValidPeriod(year int, month int) error {
+	if month < 0 || month > 12 || month > 0 && year == 0 {
+		return errors.New(errs.ErrInvalidMonth)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package diary
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidWatchDate(t *testing.T) {
+	now := time.Date(2025, 3, 10, 23, 0, 0, 0, time.UTC)
+
+	require.NoError(t, IsValidWatchDate(time.Date(1999, 3, 31, 0, 0, 0, 0, time.UTC), now))
+	require.NoError(t, IsValidWatchDate(time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC), now))
+
+	err := IsValidWatchDate(time.Date(2025, 3, 12, 0, 0, 0, 0, time.UTC), now)
+	require.Error(t, err)
+	require.Equal(t, errs.ErrFutureWatchDate, err.Error())
+}
+
+func TestIsValidNote(t *testing.T) {
+	require.NoError(t, IsValidNote(""))
+	require.NoError(t, IsValidNote(strings.Repeat("ж", MaxNoteLength)))
+
+	err := IsValidNote(strings.Repeat("ж", MaxNoteLength+1))
+	require.Error(t, err)
+	require.Equal(t, errs.ErrDiaryNoteTooLong, err.Error())
+}
+
+func TestIsValidPeriod(t *testing.T) {
+	tests := []struct {
+		year, month int
+		wantErr     bool
+	}{
+		{year: 0, month: 0},
+		{year: 2024, month: 0},
+		{year: 2024, month: 12},
+		{year: 0, month: 5, wantErr: true},
+		{year: 2024, month: 13, wantErr: true},
+		{year: 2024, month: -1, wantErr: true},
+	}
+	for _, tt := range tests {
+		err := IsValidPeriod(tt.year, tt.month)
+		if tt.wantErr {
+			require.Error(t, err)
+			require.Equal(t, errs.ErrInvalidMonth, err.Error())
+		} else {
+			require.NoError(t, err)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package movie
+
+import (
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/pkg/errors"
+)
+
+const (
+	// MinYear year of the first films
+	MinYear = 1888
+	// MaxYearsAhead how far in the future announced movies may be released
+	MaxYearsAhead = 10
+
+	MaxNameLength    = 300
+	MaxAboutLength   = 5000
+	MaxSloganLength  = 300
+	MaxCountryLength = 100
+	MaxURLLength     = 2048
+	// MaxDuration minutes, longest films run for days but catalog does not have them
+	MaxDuration = 1000
+	MaxRating   = 10
+)
+
+func isValidLength(text string, maxLength int) error {
+	if utf8.RuneCountInString(text) > maxLength {
+		return errors.New(errs.ErrMovieTextTooLong)
+	}
+	return nil
+}
+
+func IsValidName(name string) error {
+	if name == "" {
+		return errors.New(errs.ErrEmptyMovieName)
+	}
+	return isValidLength(name, MaxNameLength)
+}
+
+// IsValidYear checks release year, zero means year is unknown yet
+func IsValidYear(year int) error {
+	if year == 0 {
+		return nil
+	}
+	if year < MinYear || year > time.Now().Year()+MaxYearsAhead {
+		return errors.New(errs.ErrInvalidReleaseYear)
+	}
+	return nil
+}
+
+// IsValidPremiere checks premiere country code and that date is in the same range as release year
+func IsValidPremiere(premiere models.Premiere) error {
+	if !models.IsPremiereCountry(premiere.Country) {
+		return errors.New(errs.ErrInvalidPremiereCountry)
+	}
+	if premiere.Date.Year() < MinYear || premiere.Date.Year() > time.Now().Year()+MaxYearsAhead {
+		return errors.New(errs.ErrInvalidPremiereDate)
+	}
+	return nil
+}
+
+// IsValidPremieres checks every premiere, movie premieres once in each country
+func IsValidPremieres(premieres []models.Premiere) error {
+	countries := make(map[string]struct{}, len(premieres))
+	for _, premiere := range premieres {
+		if err := IsValidPremiere(premiere); err != nil {
+			return err
+		}
+		if _, ok := countries[premiere.Country]; ok {
+			return errors.New(errs.ErrDuplicatePremiere)
+		}
+		countries[premiere.Country] = struct{}{}
+	}
+	return nil
+}
+
+// IsValidDuration checks duration in minutes, zero means it is unknown
+func IsValidDuration(duration int) error {
+	if duration < 0 || duration > MaxDuration {
+		return errors.New(errs.ErrInvalidDuration)
+	}
+	return nil
+}
+
+func IsValidRating(rating float64) error {
+	if rating < 0 || rating > MaxRating {
+		return errors.New(errs.ErrInvalidMovieRating)
+	}
+	return nil
+}
+
+// IsValidMoney checks amount is not negative, currency is required only for non-zero amount
+func IsValidMoney(money models.Money) error {
+	if money.Amount < 0 {
+		return errors.New(errs.ErrInvalidMoney)
+	}
+	if money.IsZero() {
+		return nil
+	}
+	switch money.Currency {
+	case models.USD, models.EUR, models.RUB:
+		return nil
+	}
+	return errors.New(errs.ErrInvalidMoney)
+}
+
+// IsValidURL checks link to image, it is either absolute http(s) URL or path on our static server
+func IsValidURL(link string) error {
+	if link == "" {
+		return nil
+	}
+	if len(link) > MaxURLLength {
+		return errors.New(errs.ErrInvalidURL)
+	}
+	if strings.HasPrefix(link, "/") && !strings.HasPrefix(link, "//") {
+		return nil
+	}
+
+	u, err := url.Parse(link)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New(errs.ErrInvalidURL)
+	}
+	return nil
+}
+
+// IsValidIDs checks that every genre is linked once
+func IsValidIDs(ids []int) error {
+	seen := make(map[int]struct{}, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			return errors.New(errs.ErrDuplicateID)
+		}
+		seen[id] = struct{}{}
+	}
+	return nil
+}
+
+// IsValidStaff checks credits of movie, person may have several roles but each of them once,
+// actor may play several characters but each of them once
+func IsValidStaff(staff []models.StaffLink) error {
+	seen := make(map[models.StaffCredit]struct{}, len(staff))
+	for _, link := range staff {
+		if !link.Role.IsKnown() {
+			return errors.New(errs.ErrInvalidStaffRole)
+		}
+		if link.Character != "" && link.Role != models.RoleActor {
+			return errors.New(errs.ErrCharacterNotActor)
+		}
+		if err := isValidLength(link.Character, MaxNameLength); err != nil {
+			return err
+		}
+
+		key := link.Credit()
+		if _, ok := seen[key]; ok {
+			return errors.New(errs.ErrDuplicateCredit)
+		}
+		seen[key] = struct{}{}
+	}
+	return nil
+}
+
+// IsValidMovie checks all fields of movie edited by admin, genres and staff are checked by their ids
+func IsValidMovie(movie models.Movie) error {
+	if err := IsValidName(movie.Name); err != nil {
+		return err
+	}
+	for _, field := range []struct {
+		text      string
+		maxLength int
+	}{
+		{movie.OriginalName, MaxNameLength},
+		{movie.About, MaxAboutLength},
+		{movie.Slogan, MaxSloganLength},
+		{movie.Country, MaxCountryLength},
+	} {
+		if err := isValidLength(field.text, field.maxLength); err != nil {
+			return err
+		}
+	}
+
+	if err := IsValidURL(movie.Poster); err != nil {
+		return err
+	}
+	if err := IsValidYear(movie.ReleaseYear); err != nil {
+		return err
+	}
+	if err := IsValidPremieres(movie.Premieres); err != nil {
+		return err
+	}
+	if err := IsValidDuration(movie.Duration); err != nil {
+		return err
+	}
+	if err := IsValidRating(movie.Rating); err != nil {
+		return err
+	}
+
+	for _, money := range []models.Money{movie.Budget, movie.BoxOfficeUS, movie.BoxOfficeGlobal, movie.BoxOfficeRussia} {
+		if err := IsValidMoney(money); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package movie
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidName(t *testing.T) {
+	require.NoError(t, IsValidName("Интерстеллар"))
+	require.NoError(t, IsValidName(strings.Repeat("ж", MaxNameLength)))
+
+	err := IsValidName("")
+	require.Error(t, err)
+	require.Equal(t, errs.ErrEmptyMovieName, err.Error())
+
+	err = IsValidName(strings.Repeat("ж", MaxNameLength+1))
+	require.Error(t, err)
+	require.Equal(t, errs.ErrMovieTextTooLong, err.Error())
+}
+
+func TestIsValidYear(t *testing.T) {
+	require.NoError(t, IsValidYear(0))
+	require.NoError(t, IsValidYear(MinYear))
+	require.NoError(t, IsValidYear(time.Now().Year()+MaxYearsAhead))
+
+	for _, year := range []int{MinYear - 1, time.Now().Year() + MaxYearsAhead + 1, -2014} {
+		err := IsValidYear(year)
+		require.Error(t, err, year)
+		require.Equal(t, errs.ErrInvalidReleaseYear, err.Error())
+	}
+}
+
+func TestIsValidPremiere(t *testing.T) {
+	date := time.Date(2014, time.November, 6, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, IsValidPremiere(models.Premiere{Country: models.PremiereWorld, Date: date}))
+	require.NoError(t, IsValidPremiere(models.Premiere{Country: models.PremiereRussia, Date: date}))
+
+	err := IsValidPremiere(models.Premiere{Country: models.PremiereWorld, Date: time.Date(1700, time.January, 1, 0, 0, 0, 0, time.UTC)})
+	require.Error(t, err)
+	require.Equal(t, errs.ErrInvalidPremiereDate, err.Error())
+
+	for _, country := range []string{"", "ru", "RUS", "Россия"} {
+		err = IsValidPremiere(models.Premiere{Country: country, Date: date})
+		require.Error(t, err, country)
+		require.Equal(t, errs.ErrInvalidPremiereCountry, err.Error())
+	}
+
+	require.NoError(t, IsValidPremieres(nil))
+	err = IsValidPremieres([]models.Premiere{{Country: "RU", Date: date}, {Country: "RU", Date: date.AddDate(0, 0, 7)}})
+	require.Error(t, err)
+	require.Equal(t, errs.ErrDuplicatePremiere, err.Error())
+}
+
+func TestIsValidDuration(t *testing.T) {
+	require.NoError(t, IsValidDuration(0))
+	require.NoError(t, IsValidDuration(169))
+	require.NoError(t, IsValidDuration(MaxDuration))
+
+	for _, duration := range []int{-1, MaxDuration + 1} {
+		err := IsValidDuration(duration)
+		require.Error(t, err, duration)
+		require.Equal(t, errs.ErrInvalidDuration, err.Error())
+	}
+}
+
+func TestIsValidRating(t *testing.T) {
+	require.NoError(t, IsValidRating(0))
+	require.NoError(t, IsValidRating(8.6))
+	require.NoError(t, IsValidRating(MaxRating))
+
+	for _, rating := range []float64{-0.1, 10.1} {
+		err := IsValidRating(rating)
+		require.Error(t, err, rating)
+		require.Equal(t, errs.ErrInvalidMovieRating, err.Error())
+	}
+}
+
+func TestIsValidMoney(t *testing.T) {
+	require.NoError(t, IsValidMoney(models.Money{}))
+	require.NoError(t, IsValidMoney(models.Money{Amount: 165000000, Currency: models.USD}))
+	require.NoError(t, IsValidMoney(models.Money{Amount: 1, Currency: models.RUB}))
+
+	for _, money := range []models.Money{
+		{Amount: -1, Currency: models.USD},
+		{Amount: 100},
+		{Amount: 100, Currency: "GBP"},
+	} {
+		err := IsValidMoney(money)
+		require.Error(t, err, money)
+		require.Equal(t, errs.ErrInvalidMoney, err.Error())
+	}
+}
+
+func TestIsValidURL(t *testing.T) {
+	for _, link := range []string{"", "/static/posters/1.jpg", "https://example.com/poster.jpg", "http://example.com/a?b=c"} {
+		require.NoError(t, IsValidURL(link), link)
+	}
+
+	for _, link := range []string{"poster.jpg", "//example.com/poster.jpg", "ftp://example.com/poster.jpg", "javascript:alert(1)",
+		"https://", "/" + strings.Repeat("a", MaxURLLength)} {
+		err := IsValidURL(link)
+		require.Error(t, err, link)
+		require.Equal(t, errs.ErrInvalidURL, err.Error())
+	}
+}
+
+func TestIsValidIDs(t *testing.T) {
+	require.NoError(t, IsValidIDs(nil))
+	require.NoError(t, IsValidIDs([]int{1, 2, 3}))
+
+	err := IsValidIDs([]int{1, 2, 1})
+	require.Error(t, err)
+	require.Equal(t, errs.ErrDuplicateID, err.Error())
+}
+
+func TestIsValidMovie(t *testing.T) {
+	premiere := time.Date(2014, time.November, 6, 0, 0, 0, 0, time.UTC)
+	movie := models.Movie{
+		Name:         "Интерстеллар",
+		OriginalName: "Interstellar",
+		Poster:       "/static/posters/interstellar.jpg",
+		ReleaseYear:  2014,
+		Duration:     169,
+		Rating:       8.6,
+		Budget:       models.Money{Amount: 165000000, Currency: models.USD},
+		Premieres:    []models.Premiere{{Country: models.PremiereRussia, Date: premiere}},
+	}
+	require.NoError(t, IsValidMovie(movie))
+
+	invalid := movie
+	invalid.Slogan = strings.Repeat("ж", MaxSloganLength+1)
+	err := IsValidMovie(invalid)
+	require.Error(t, err)
+	require.Equal(t, errs.ErrMovieTextTooLong, err.Error())
+
+	invalid = movie
+	invalid.BoxOfficeRussia = models.Money{Amount: -5, Currency: models.RUB}
+	err = IsValidMovie(invalid)
+	require.Error(t, err)
+	require.Equal(t, errs.ErrInvalidMoney, err.Error())
+
+	invalid = movie
+	invalid.Poster = "poster.jpg"
+	err = IsValidMovie(invalid)
+	require.Error(t, err)
+	require.Equal(t, errs.ErrInvalidURL, err.Error())
+}
+
+func TestIsValidStaff(t *testing.T) {
+	require.NoError(t, IsValidStaff(nil))
+	require.NoError(t, IsValidStaff([]models.StaffLink{
+		{PersonID: 1, Role: models.RoleActor, Character: "Тайлер Дёрден"},
+		{PersonID: 1, Role: models.RoleProducer},
+		{PersonID: 2, Role: models.RoleDirector},
+	}))
+	// actor may play several characters
+	require.NoError(t, IsValidStaff([]models.StaffLink{
+		{PersonID: 1, Role: models.RoleActor, Character: "Альдо Рейн"},
+		{PersonID: 1, Role: models.RoleActor, Character: "Энцо Горломи"},
+	}))
+
+	tests := []struct {
+		staff []models.StaffLink
+		err   string
+	}{
+		{[]models.StaffLink{{PersonID: 1, Role: "stuntman"}}, errs.ErrInvalidStaffRole},
+		{[]models.StaffLink{{PersonID: 1}}, errs.ErrInvalidStaffRole},
+		{[]models.StaffLink{{PersonID: 1, Role: models.RoleDirector, Character: "Рассказчик"}}, errs.ErrCharacterNotActor},
+		{[]models.StaffLink{{PersonID: 1, Role: models.RoleActor, Character: strings.Repeat("ж", MaxNameLength+1)}}, errs.ErrMovieTextTooLong},
+		{[]models.StaffLink{{PersonID: 1, Role: models.RoleWriter}, {PersonID: 1, Role: models.RoleWriter}}, errs.ErrDuplicateCredit},
+		{[]models.StaffLink{{PersonID: 1, Role: models.RoleActor, Character: "Альдо Рейн"}, {PersonID: 1, Role: models.RoleActor, Character: "Альдо Рейн"}}, errs.ErrDuplicateCredit},
+	}
+	for _, tt := range tests {
+		err := IsValidStaff(tt.staff)
+		require.Error(t, err, tt.staff)
+		require.Equal(t, tt.err, err.Error())
+	}
+}
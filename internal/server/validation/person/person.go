@@ -0,0 +1,79 @@
+package person
+
+import (
+	"time"
+	"unicode/utf8"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/validation/movie"
+	"github.com/pkg/errors"
+)
+
+const (
+	MaxNameLength  = 300
+	MaxAboutLength = 5000
+	MaxTextLength  = 300
+	// MaxGrowth centimeters
+	MaxGrowth = 300
+)
+
+func isValidLength(text string, maxLength int) error {
+	if utf8.RuneCountInString(text) > maxLength {
+		return errors.New(errs.ErrPersonTextTooLong)
+	}
+	return nil
+}
+
+func IsValidName(name string) error {
+	if name == "" {
+		return errors.New(errs.ErrEmptyPersonName)
+	}
+	return isValidLength(name, MaxNameLength)
+}
+
+// IsValidGrowth checks growth in centimeters, zero means it is unknown
+func IsValidGrowth(growth int) error {
+	if growth < 0 || growth > MaxGrowth {
+		return errors.New(errs.ErrInvalidGrowth)
+	}
+	return nil
+}
+
+// IsValidDates checks birthday and death are not in the future and person did not die before birth, nil date is unknown
+func IsValidDates(birthday, death *time.Time, now time.Time) error {
+	if birthday != nil && birthday.After(now) {
+		return errors.New(errs.ErrInvalidBirthday)
+	}
+	if death != nil && (death.After(now) || birthday != nil && death.Before(*birthday)) {
+		return errors.New(errs.ErrInvalidDeathDate)
+	}
+	return nil
+}
+
+// IsValidPerson checks all fields of person edited by admin
+func IsValidPerson(person models.Person) error {
+	if err := IsValidName(person.FullName); err != nil {
+		return err
+	}
+	for _, field := range []struct {
+		text      string
+		maxLength int
+	}{
+		{person.EnFullName, MaxNameLength},
+		{person.About, MaxAboutLength},
+		{person.Sex, MaxTextLength},
+	} {
+		if err := isValidLength(field.text, field.maxLength); err != nil {
+			return err
+		}
+	}
+
+	if err := movie.IsValidURL(person.Photo); err != nil {
+		return err
+	}
+	if err := IsValidGrowth(person.Growth); err != nil {
+		return err
+	}
+	return IsValidDates(person.Birthday, person.Death, time.Now())
+}
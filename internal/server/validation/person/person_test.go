@@ -0,0 +1,84 @@
+package person
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) *time.Time {
+	res := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return &res
+}
+
+func TestIsValidName(t *testing.T) {
+	require.NoError(t, IsValidName("Киану Ривз"))
+	require.NoError(t, IsValidName(strings.Repeat("ж", MaxNameLength)))
+
+	err := IsValidName("")
+	require.Error(t, err)
+	require.Equal(t, errs.ErrEmptyPersonName, err.Error())
+
+	err = IsValidName(strings.Repeat("ж", MaxNameLength+1))
+	require.Error(t, err)
+	require.Equal(t, errs.ErrPersonTextTooLong, err.Error())
+}
+
+func TestIsValidGrowth(t *testing.T) {
+	require.NoError(t, IsValidGrowth(0))
+	require.NoError(t, IsValidGrowth(186))
+
+	for _, growth := range []int{-1, MaxGrowth + 1} {
+		err := IsValidGrowth(growth)
+		require.Error(t, err, growth)
+		require.Equal(t, errs.ErrInvalidGrowth, err.Error())
+	}
+}
+
+func TestIsValidDates(t *testing.T) {
+	now := time.Date(2025, time.May, 1, 0, 0, 0, 0, time.UTC)
+
+	require.NoError(t, IsValidDates(nil, nil, now))
+	require.NoError(t, IsValidDates(date(1964, time.September, 2), nil, now))
+	require.NoError(t, IsValidDates(date(1930, time.August, 25), date(2020, time.October, 31), now))
+	require.NoError(t, IsValidDates(nil, date(2020, time.October, 31), now))
+
+	err := IsValidDates(date(2030, time.January, 1), nil, now)
+	require.Error(t, err)
+	require.Equal(t, errs.ErrInvalidBirthday, err.Error())
+
+	err = IsValidDates(date(1969, time.August, 18), date(1960, time.January, 1), now)
+	require.Error(t, err)
+	require.Equal(t, errs.ErrInvalidDeathDate, err.Error())
+
+	err = IsValidDates(nil, date(2030, time.January, 1), now)
+	require.Error(t, err)
+	require.Equal(t, errs.ErrInvalidDeathDate, err.Error())
+}
+
+func TestIsValidPerson(t *testing.T) {
+	person := models.Person{
+		FullName:   "Киану Ривз",
+		EnFullName: "Keanu Reeves",
+		Photo:      "https://example.com/keanu.jpg",
+		Growth:     186,
+		Birthday:   date(1964, time.September, 2),
+	}
+	require.NoError(t, IsValidPerson(person))
+
+	invalid := person
+	invalid.Photo = "keanu.jpg"
+	err := IsValidPerson(invalid)
+	require.Error(t, err)
+	require.Equal(t, errs.ErrInvalidURL, err.Error())
+
+	invalid = person
+	invalid.Sex = strings.Repeat("ж", MaxTextLength+1)
+	err = IsValidPerson(invalid)
+	require.Error(t, err)
+	require.Equal(t, errs.ErrPersonTextTooLong, err.Error())
+}
@@ -0,0 +1,38 @@
+package review
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/pkg/errors"
+)
+
+const (
+	MinScore      = 1
+	MaxScore      = 10
+	MinTextLength = 10
+	MaxTextLength = 5000
+)
+
+func IsValidScore(score int) error {
+	if score < MinScore || score > MaxScore {
+		return errors.New(errs.ErrInvalidScore)
+	}
+	return nil
+}
+
+func IsValidText(text string) error {
+	if text = strings.TrimSpace(text); text == "" {
+		return errors.New(errs.ErrEmptyReviewText)
+	}
+
+	cnt := utf8.RuneCountInString(text)
+	if cnt < MinTextLength {
+		return errors.New(errs.ErrReviewTextTooShort)
+	}
+	if cnt > MaxTextLength {
+		return errors.New(errs.ErrReviewTextTooLong)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package review
+
+import (
+	"strings"
+	"testing"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidScore(t *testing.T) {
+	tests := []struct {
+		score   int
+		wantErr bool
+	}{
+		{score: 0, wantErr: true},
+		{score: 1},
+		{score: 7},
+		{score: 10},
+		{score: 11, wantErr: true},
+		{score: -3, wantErr: true},
+	}
+	for _, tt := range tests {
+		err := IsValidScore(tt.score)
+		if tt.wantErr {
+			require.Error(t, err)
+			require.Equal(t, errs.ErrInvalidScore, err.Error())
+		} else {
+			require.NoError(t, err)
+		}
+	}
+}
+
+func TestIsValidText(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		wantErr string
+	}{
+		{name: "ok", text: "Отличный фильм, рекомендую"},
+		{name: "cyrillic runes", text: strings.Repeat("я", MaxTextLength)},
+		{name: "empty", text: "   \n ", wantErr: errs.ErrEmptyReviewText},
+		{name: "short", text: "  круто  ", wantErr: errs.ErrReviewTextTooShort},
+		{name: "long", text: strings.Repeat("a", MaxTextLength+1), wantErr: errs.ErrReviewTextTooLong},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := IsValidText(tt.text)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				require.Equal(t, tt.wantErr, err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	movieDTO "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/delivery/dto"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
+)
+
+// WatchlistChangeRequest movies to add or remove, several movies may be selected at once
+type WatchlistChangeRequest struct {
+	MovieIDs []int `json:"movie_ids"`
+}
+
+// WatchlistChangeJSON ids of movies which were actually added or removed
+type WatchlistChangeJSON struct {
+	MovieIDs []int `json:"movie_ids"`
+}
+
+func NewWatchlistChangeJSON(movieIDs []int) WatchlistChangeJSON {
+	if movieIDs == nil {
+		movieIDs = []int{}
+	}
+	return WatchlistChangeJSON{MovieIDs: movieIDs}
+}
+
+type WatchlistItemJSON struct {
+	Movie   movieDTO.MovieShortJSON `json:"movie"`
+	AddedAt time.Time               `json:"added_at"`
+}
+
+// WatchlistPageJSON page of watchlist
+type WatchlistPageJSON struct {
+	Items      []WatchlistItemJSON `json:"items"`
+	Total      int                 `json:"total"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+}
+
+func NewWatchlistPageJSON(page models.WatchlistPage, locale l10n.Locale) WatchlistPageJSON {
+	res := WatchlistPageJSON{
+		Items:      make([]WatchlistItemJSON, 0, len(page.Items)),
+		Total:      page.Total,
+		NextCursor: page.NextCursor,
+	}
+	for _, item := range page.Items {
+		res.Items = append(res.Items, WatchlistItemJSON{
+			Movie:   movieDTO.NewMovieShortJSON(item.Movie, locale),
+			AddedAt: item.AddedAt,
+		})
+	}
+	return res
+}
@@ -0,0 +1,9 @@
+package delivery
+
+import "net/http"
+
+type WatchlistHandlerInterface interface {
+	GetWatchlist(w http.ResponseWriter, r *http.Request)
+	AddMovies(w http.ResponseWriter, r *http.Request)
+	RemoveMovies(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,191 @@
+package delivery
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/middleware"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/watchlist/delivery/dto"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/jsonutil"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/l10n"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+	// maxBulkSize most movies added or removed by one request
+	maxBulkSize = 100
+
+	orderAsc  = "asc"
+	orderDesc = "desc"
+
+	// localeParam query parameter requesting localized genre names
+	localeParam = "locale"
+)
+
+type WatchlistServiceInterface interface {
+	AddMovies(ctx context.Context, username string, movieIDs []int) ([]int, error)
+	RemoveMovies(ctx context.Context, username string, movieIDs []int) ([]int, error)
+	ListWatchlist(ctx context.Context, username string, req models.WatchlistRequest) (*models.WatchlistPage, error)
+}
+
+type WatchlistHandler struct {
+	watchlistService WatchlistServiceInterface
+}
+
+func NewWatchlistHandler(watchlistService WatchlistServiceInterface) *WatchlistHandler {
+	return &WatchlistHandler{
+		watchlistService: watchlistService,
+	}
+}
+
+// readMovieIDs reads selected movies from request body, error response is sent if they are invalid
+func readMovieIDs(w http.ResponseWriter, r *http.Request) ([]int, bool) {
+	logger := log.Ctx(r.Context())
+
+	var req dto.WatchlistChangeRequest
+	if err := jsonutil.ReadJSON(r, &req); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrParseJSON)).Msg(errors.Wrap(err, errs.ErrParseJSON).Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errors.Wrap(err, errs.ErrParseJSONShort).Error(), errs.ErrBadPayload)
+		return nil, false
+	}
+
+	if len(req.MovieIDs) == 0 || len(req.MovieIDs) > maxBulkSize {
+		err := errors.Errorf("movie_ids must contain 1-%d movies", maxBulkSize)
+		logger.Error().Err(err).Msg(err.Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, err.Error())
+		return nil, false
+	}
+
+	return req.MovieIDs, true
+}
+
+// parseListRequest reads sorting and pagination from query string, recently added movies go first by default
+func parseListRequest(query url.Values) (models.WatchlistRequest, error) {
+	req := models.WatchlistRequest{
+		Sort:   models.WatchlistSort(query.Get("sort")),
+		Limit:  defaultLimit,
+		Cursor: query.Get("cursor"),
+	}
+	if req.Sort == "" {
+		req.Sort = models.WatchlistSortByAdded
+	}
+
+	switch query.Get("order") {
+	case "":
+		req.Desc = req.Sort != models.WatchlistSortByTitle
+	case orderAsc:
+		req.Desc = false
+	case orderDesc:
+		req.Desc = true
+	default:
+		return req, errors.Errorf("unknown order %q", query.Get("order"))
+	}
+
+	if val := query.Get("limit"); val != "" {
+		var err error
+		if req.Limit, err = strconv.Atoi(val); err != nil {
+			return req, errors.Wrap(err, "parameter limit")
+		}
+	}
+	if req.Limit <= 0 || req.Limit > maxLimit {
+		return req, errors.Errorf("limit must be in 1-%d", maxLimit)
+	}
+
+	return req, nil
+}
+
+func (h *WatchlistHandler) GetWatchlist(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	username, ok := middleware.RequireUsername(w, r)
+	if !ok {
+		return
+	}
+
+	req, err := parseListRequest(r.URL.Query())
+	if err != nil {
+		errMsg := errors.Wrap(err, "getWatchlist action: bad request")
+		logger.Error().Err(errMsg).Msg(errMsg.Error())
+		jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, err.Error())
+		return
+	}
+
+	page, err := h.watchlistService.ListWatchlist(r.Context(), username, req)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		if errors.Is(err, errs.ErrInvalidCursor) || errors.Is(err, errs.ErrInvalidWatchlistRequest) {
+			jsonutil.SendError(r.Context(), w, http.StatusBadRequest, errs.ErrBadPayload, err.Error())
+			return
+		}
+		jsonutil.SendError(r.Context(), w, http.StatusInternalServerError, errs.ErrSomethingWentWrong, errs.ErrSomethingWentWrong)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, dto.NewWatchlistPageJSON(*page, l10n.ParseLocale(r.URL.Query().Get(localeParam)))); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
+
+func (h *WatchlistHandler) AddMovies(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	username, ok := middleware.RequireUsername(w, r)
+	if !ok {
+		return
+	}
+	movieIDs, ok := readMovieIDs(w, r)
+	if !ok {
+		return
+	}
+
+	logger.Info().Msgf("adding %d movies to watchlist of %s", len(movieIDs), username)
+	added, err := h.watchlistService.AddMovies(r.Context(), username, movieIDs)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		if errors.Is(err, errs.ErrMovieNotFound) {
+			jsonutil.SendError(r.Context(), w, http.StatusNotFound, errs.ErrNotFoundShort, err.Error())
+			return
+		}
+		jsonutil.SendError(r.Context(), w, http.StatusInternalServerError, errs.ErrSomethingWentWrong, errs.ErrSomethingWentWrong)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, dto.NewWatchlistChangeJSON(added)); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
+
+func (h *WatchlistHandler) RemoveMovies(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	username, ok := middleware.RequireUsername(w, r)
+	if !ok {
+		return
+	}
+	movieIDs, ok := readMovieIDs(w, r)
+	if !ok {
+		return
+	}
+
+	logger.Info().Msgf("removing %d movies from watchlist of %s", len(movieIDs), username)
+	removed, err := h.watchlistService.RemoveMovies(r.Context(), username, movieIDs)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		jsonutil.SendError(r.Context(), w, http.StatusInternalServerError, errs.ErrSomethingWentWrong, errs.ErrSomethingWentWrong)
+		return
+	}
+
+	if err := jsonutil.SendJSON(r.Context(), w, dto.NewWatchlistChangeJSON(removed)); err != nil {
+		logger.Error().Err(errors.Wrap(err, errs.ErrSendJSON)).Msg(errors.Wrap(err, errs.ErrSomethingWentWrong).Error())
+		return
+	}
+}
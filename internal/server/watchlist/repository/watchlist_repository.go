@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+)
+
+// WatchlistRepository keeps time movies were added by user id and movie id
+type WatchlistRepository struct {
+	mu sync.RWMutex
+	db map[int]map[int]time.Time
+}
+
+func NewWatchlistRepository() *WatchlistRepository {
+	return &WatchlistRepository{
+		db: make(map[int]map[int]time.Time),
+	}
+}
+
+// AddMovies adds movies to watchlist of user, movies which are already there keep their time.
+// It returns ids of actually added movies
+func (r *WatchlistRepository) AddMovies(ctx context.Context, userID int, movieIDs []int, addedAt time.Time) ([]int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.db[userID] == nil {
+		r.db[userID] = make(map[int]time.Time)
+	}
+
+	var added []int
+	for _, movieID := range movieIDs {
+		if _, ok := r.db[userID][movieID]; !ok {
+			r.db[userID][movieID] = addedAt
+			added = append(added, movieID)
+		}
+	}
+
+	return added, nil
+}
+
+// RemoveMovies removes movies from watchlist of user and returns ids of actually removed movies
+func (r *WatchlistRepository) RemoveMovies(ctx context.Context, userID int, movieIDs []int) ([]int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var removed []int
+	for _, movieID := range movieIDs {
+		if _, ok := r.db[userID][movieID]; ok {
+			delete(r.db[userID], movieID)
+			removed = append(removed, movieID)
+		}
+	}
+	if len(r.db[userID]) == 0 {
+		delete(r.db, userID)
+	}
+
+	return removed, nil
+}
+
+// GetWatchlist returns all movies saved by user ordered by movie id
+func (r *WatchlistRepository) GetWatchlist(ctx context.Context, userID int) ([]models.WatchlistEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	res := make([]models.WatchlistEntry, 0, len(r.db[userID]))
+	for movieID, addedAt := range r.db[userID] {
+		res = append(res, models.WatchlistEntry{MovieID: movieID, AddedAt: addedAt})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].MovieID < res[j].MovieID
+	})
+
+	return res, nil
+}
+
+// GetAdditions returns movies added to watchlists of all users since given time ordered by time
+func (r *WatchlistRepository) GetAdditions(ctx context.Context, since time.Time) ([]models.WatchlistEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var res []models.WatchlistEntry
+	for _, movies := range r.db {
+		for movieID, addedAt := range movies {
+			if !addedAt.Before(since) {
+				res = append(res, models.WatchlistEntry{MovieID: movieID, AddedAt: addedAt})
+			}
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if !res[i].AddedAt.Equal(res[j].AddedAt) {
+			return res[i].AddedAt.Before(res[j].AddedAt)
+		}
+		return res[i].MovieID < res[j].MovieID
+	})
+
+	return res, nil
+}
+
+// Contains reports whether movie is in watchlist of user
+func (r *WatchlistRepository) Contains(ctx context.Context, userID int, movieID int) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.db[userID][movieID]
+	return ok, nil
+}
+
+// Snapshot serializes watchlists of all users
+func (r *WatchlistRepository) Snapshot(ctx context.Context) ([]byte, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	records := make(map[int][]models.WatchlistEntry, len(r.db))
+	for userID, movies := range r.db {
+		for movieID, addedAt := range movies {
+			records[userID] = append(records[userID], models.WatchlistEntry{MovieID: movieID, AddedAt: addedAt})
+		}
+	}
+
+	return json.Marshal(records)
+}
+
+// Restore replaces all watchlists with ones from snapshot
+func (r *WatchlistRepository) Restore(ctx context.Context, data []byte) error {
+	var records map[int][]models.WatchlistEntry
+	if err := json.Unmarshal(data, &records); err != nil {
+		return err
+	}
+
+	db := make(map[int]map[int]time.Time, len(records))
+	for userID, entries := range records {
+		db[userID] = make(map[int]time.Time, len(entries))
+		for _, entry := range entries {
+			db[userID][entry.MovieID] = entry.AddedAt
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.db = db
+
+	return nil
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/pkg/cursor"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type WatchlistRepositoryInterface interface {
+	AddMovies(ctx context.Context, userID int, movieIDs []int, addedAt time.Time) ([]int, error)
+	RemoveMovies(ctx context.Context, userID int, movieIDs []int) ([]int, error)
+	GetWatchlist(ctx context.Context, userID int) ([]models.WatchlistEntry, error)
+	Contains(ctx context.Context, userID int, movieID int) (bool, error)
+}
+
+type MovieRepositoryInterface interface {
+	GetMovieFromRepoByID(ctx context.Context, movieID int) (*models.Movie, error)
+}
+
+type UserRepositoryInterface interface {
+	GetUser(ctx context.Context, login string) (*models.User, error)
+}
+
+// WatchlistService movies users saved to watch later
+type WatchlistService struct {
+	watchlistRepo WatchlistRepositoryInterface
+	movieRepo     MovieRepositoryInterface
+	userRepo      UserRepositoryInterface
+}
+
+func NewWatchlistService(watchlistRepo WatchlistRepositoryInterface, movieRepo MovieRepositoryInterface, userRepo UserRepositoryInterface) *WatchlistService {
+	return &WatchlistService{
+		watchlistRepo: watchlistRepo,
+		movieRepo:     movieRepo,
+		userRepo:      userRepo,
+	}
+}
+
+// AddMovies adds movies to watchlist, nothing is added if any of movies does not exist.
+// It returns ids of movies which were not in watchlist before
+func (s *WatchlistService) AddMovies(ctx context.Context, username string, movieIDs []int) ([]int, error) {
+	logger := log.Ctx(ctx)
+
+	user, err := s.userRepo.GetUser(ctx, username)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	for _, movieID := range movieIDs {
+		if _, err = s.movieRepo.GetMovieFromRepoByID(ctx, movieID); err != nil {
+			logger.Error().Err(err).Int("movie_id", movieID).Msg(err.Error())
+			return nil, errors.Wrapf(err, "movie %d", movieID)
+		}
+	}
+
+	added, err := s.watchlistRepo.AddMovies(ctx, user.ID, movieIDs, time.Now().UTC())
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return added, nil
+}
+
+// RemoveMovies removes movies from watchlist and returns ids of movies which were there
+func (s *WatchlistService) RemoveMovies(ctx context.Context, username string, movieIDs []int) ([]int, error) {
+	logger := log.Ctx(ctx)
+
+	user, err := s.userRepo.GetUser(ctx, username)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	removed, err := s.watchlistRepo.RemoveMovies(ctx, user.ID, movieIDs)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return removed, nil
+}
+
+// IsInWatchlist reports whether user saved movie
+func (s *WatchlistService) IsInWatchlist(ctx context.Context, username string, movieID int) (bool, error) {
+	user, err := s.userRepo.GetUser(ctx, username)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg(err.Error())
+		return false, err
+	}
+
+	return s.watchlistRepo.Contains(ctx, user.ID, movieID)
+}
+
+// WatchlistedMovies returns which of movies user saved, movies not in watchlist are absent from result
+func (s *WatchlistService) WatchlistedMovies(ctx context.Context, username string, movieIDs []int) (map[int]bool, error) {
+	logger := log.Ctx(ctx)
+
+	user, err := s.userRepo.GetUser(ctx, username)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	res := make(map[int]bool)
+	for _, movieID := range movieIDs {
+		ok, err := s.watchlistRepo.Contains(ctx, user.ID, movieID)
+		if err != nil {
+			logger.Error().Err(err).Msg(err.Error())
+			return nil, err
+		}
+		if ok {
+			res[movieID] = true
+		}
+	}
+
+	return res, nil
+}
+
+func watchlistKey(item models.WatchlistItem, sortBy models.WatchlistSort) cursor.Key {
+	key := cursor.Key{ID: item.Movie.ID}
+	switch sortBy {
+	case models.WatchlistSortByAdded:
+		key.Num = float64(item.AddedAt.UnixMilli())
+	case models.WatchlistSortByRating:
+		key.Num = item.Movie.Rating
+	case models.WatchlistSortByTitle:
+		key.Text = strings.ToLower(item.Movie.Name)
+	}
+	return key
+}
+
+func isKnownSort(sortBy models.WatchlistSort) bool {
+	switch sortBy {
+	case models.WatchlistSortByAdded, models.WatchlistSortByTitle, models.WatchlistSortByRating:
+		return true
+	default:
+		return false
+	}
+}
+
+// ListWatchlist returns page of user watchlist sorted as requested
+func (s *WatchlistService) ListWatchlist(ctx context.Context, username string, req models.WatchlistRequest) (*models.WatchlistPage, error) {
+	logger := log.Ctx(ctx)
+
+	if !isKnownSort(req.Sort) || req.Limit <= 0 {
+		logger.Error().Str("sort", string(req.Sort)).Int("limit", req.Limit).Msg(errs.ErrBadPayload)
+		return nil, errs.ErrInvalidWatchlistRequest
+	}
+
+	user, err := s.userRepo.GetUser(ctx, username)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	entries, err := s.watchlistRepo.GetWatchlist(ctx, user.ID)
+	if err != nil {
+		logger.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	items := make([]models.WatchlistItem, 0, len(entries))
+	for _, e := range entries {
+		movie, err := s.movieRepo.GetMovieFromRepoByID(ctx, e.MovieID)
+		if errors.Is(err, errs.ErrMovieNotFound) {
+			// movie was deleted from catalog after it was saved
+			continue
+		}
+		if err != nil {
+			logger.Error().Err(err).Msg(err.Error())
+			return nil, err
+		}
+
+		items = append(items, models.WatchlistItem{Movie: *movie, AddedAt: e.AddedAt})
+	}
+
+	page, err := cursor.Paginate(items, func(item models.WatchlistItem) cursor.Key {
+		return watchlistKey(item, req.Sort)
+	}, cursor.Request{Scope: cursor.Scope(req.Sort), Desc: req.Desc, Limit: req.Limit, Cursor: req.Cursor})
+	if err != nil {
+		logger.Error().Err(err).Str("cursor", req.Cursor).Msg(err.Error())
+		return nil, err
+	}
+
+	return &models.WatchlistPage{Items: page.Items, Total: page.Total, NextCursor: page.NextCursor}, nil
+}
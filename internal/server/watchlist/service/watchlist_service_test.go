@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/mocks"
+	"github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/models"
+	repoMovie "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/movie/repository"
+	repoUser "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/user/repository"
+	repoWatchlist "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/server/watchlist/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func itemIDs(items []models.WatchlistItem) []int {
+	ids := make([]int, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.Movie.ID)
+	}
+	return ids
+}
+
+func TestWatchlistService(t *testing.T) {
+	ctx := context.Background()
+
+	movies := mocks.Movies{
+		1: {ID: 1, Name: "Матрица", Rating: 8.5},
+		2: {ID: 2, Name: "Бойцовский клуб", Rating: 8.7},
+		3: {ID: 3, Name: "Чужой", Rating: 7.9},
+	}
+	movieRepo := repoMovie.NewMovieRepository(&movies)
+	userRepo := repoUser.NewUserRepository()
+	require.NoError(t, userRepo.CreateUser(ctx, &models.User{Username: "neo"}))
+	require.NoError(t, userRepo.CreateUser(ctx, &models.User{Username: "trinity"}))
+
+	watchlistRepo := repoWatchlist.NewWatchlistRepository()
+	s := NewWatchlistService(watchlistRepo, movieRepo, userRepo)
+
+	added, err := s.AddMovies(ctx, "neo", []int{1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, added)
+
+	// already saved movie is not added twice
+	added, err = s.AddMovies(ctx, "neo", []int{2, 3})
+	require.NoError(t, err)
+	assert.Equal(t, []int{3}, added)
+
+	// nothing is added when one of movies does not exist
+	_, err = s.AddMovies(ctx, "trinity", []int{1, 42})
+	assert.ErrorIs(t, err, errs.ErrMovieNotFound)
+	inWatchlist, err := s.IsInWatchlist(ctx, "trinity", 1)
+	require.NoError(t, err)
+	assert.False(t, inWatchlist)
+
+	inWatchlist, err = s.IsInWatchlist(ctx, "neo", 1)
+	require.NoError(t, err)
+	assert.True(t, inWatchlist)
+
+	watchlisted, err := s.WatchlistedMovies(ctx, "neo", []int{3, 42, 1})
+	require.NoError(t, err)
+	assert.Equal(t, map[int]bool{1: true, 3: true}, watchlisted)
+
+	page, err := s.ListWatchlist(ctx, "neo", models.WatchlistRequest{Sort: models.WatchlistSortByTitle, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 1, 3}, itemIDs(page.Items))
+	assert.Equal(t, 3, page.Total)
+
+	var got []int
+	req := models.WatchlistRequest{Sort: models.WatchlistSortByRating, Desc: true, Limit: 2}
+	for {
+		page, err = s.ListWatchlist(ctx, "neo", req)
+		require.NoError(t, err)
+		got = append(got, itemIDs(page.Items)...)
+		if page.NextCursor == "" {
+			break
+		}
+		req.Cursor = page.NextCursor
+	}
+	assert.Equal(t, []int{2, 1, 3}, got)
+
+	_, err = s.ListWatchlist(ctx, "neo", models.WatchlistRequest{Sort: "views", Limit: 10})
+	assert.ErrorIs(t, err, errs.ErrInvalidWatchlistRequest)
+
+	removed, err := s.RemoveMovies(ctx, "neo", []int{1, 3, 42})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 3}, removed)
+
+	page, err = s.ListWatchlist(ctx, "neo", models.WatchlistRequest{Sort: models.WatchlistSortByAdded, Desc: true, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []int{2}, itemIDs(page.Items))
+
+	// watchlists survive backup
+	data, err := watchlistRepo.Snapshot(ctx)
+	require.NoError(t, err)
+	restored := repoWatchlist.NewWatchlistRepository()
+	require.NoError(t, restored.Restore(ctx, data))
+	entries, err := restored.GetWatchlist(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, 2, entries[0].MovieID)
+}
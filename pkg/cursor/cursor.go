@@ -0,0 +1,29 @@
+package cursor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+)
+
+// Encode packs pagination position into opaque url-safe token
+func Encode(position interface{}) (string, error) {
+	data, err := json.Marshal(position)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// Decode unpacks token made by Encode into position
+func Decode(token string, position interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return errs.ErrInvalidCursor
+	}
+	if err = json.Unmarshal(data, position); err != nil {
+		return errs.ErrInvalidCursor
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package cursor
+
+import (
+	"testing"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type position struct {
+	Key float64 `json:"k"`
+	ID  int     `json:"id"`
+}
+
+func TestEncodeDecode(t *testing.T) {
+	token, err := Encode(position{Key: 8.5, ID: 42})
+	require.NoError(t, err)
+	assert.NotContains(t, token, "=")
+
+	var pos position
+	require.NoError(t, Decode(token, &pos))
+	assert.Equal(t, position{Key: 8.5, ID: 42}, pos)
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	var pos position
+	assert.ErrorIs(t, Decode("not base64!", &pos), errs.ErrInvalidCursor)
+	assert.ErrorIs(t, Decode("bm90IGpzb24", &pos), errs.ErrInvalidCursor)
+}
+
+type item struct {
+	id    int
+	score float64
+}
+
+func itemKey(it item) Key {
+	return Key{Num: it.score, ID: it.id}
+}
+
+func TestPaginate(t *testing.T) {
+	items := []item{{id: 1, score: 5}, {id: 2, score: 7}, {id: 3, score: 5}, {id: 4, score: 9}, {id: 5, score: 5}}
+
+	ids := func(page *Page[item]) []int {
+		res := make([]int, 0, len(page.Items))
+		for _, it := range page.Items {
+			res = append(res, it.id)
+		}
+		return res
+	}
+
+	for desc, want := range map[bool][]int{false: {1, 3, 5, 2, 4}, true: {4, 2, 1, 3, 5}} {
+		var got []int
+		req := Request{Scope: Scope("score"), Desc: desc, Limit: 2}
+		for {
+			page, err := Paginate(items, itemKey, req)
+			require.NoError(t, err)
+			assert.Equal(t, len(items), page.Total)
+			got = append(got, ids(page)...)
+			if page.NextCursor == "" {
+				break
+			}
+			req.Cursor = page.NextCursor
+		}
+		assert.Equal(t, want, got, "desc %v", desc)
+	}
+
+	// items added before cursor position do not shift next page
+	page, err := Paginate(items, itemKey, Request{Scope: "score", Limit: 2})
+	require.NoError(t, err)
+	page, err = Paginate(append(items, item{id: 0, score: 1}), itemKey, Request{Scope: "score", Limit: 2, Cursor: page.NextCursor})
+	require.NoError(t, err)
+	assert.Equal(t, []int{5, 2}, ids(page))
+}
+
+func TestPaginate_InvalidCursor(t *testing.T) {
+	items := []item{{id: 1, score: 5}, {id: 2, score: 7}}
+
+	page, err := Paginate(items, itemKey, Request{Scope: Scope("score", 1), Limit: 1})
+	require.NoError(t, err)
+	require.NotEmpty(t, page.NextCursor)
+
+	for _, req := range []Request{
+		{Scope: Scope("score", 2), Limit: 1, Cursor: page.NextCursor},
+		{Scope: Scope("score", 1), Desc: true, Limit: 1, Cursor: page.NextCursor},
+		{Scope: Scope("score", 1), Limit: 1, Cursor: "not base64!"},
+	} {
+		_, err = Paginate(items, itemKey, req)
+		assert.ErrorIs(t, err, errs.ErrInvalidCursor)
+	}
+}
@@ -0,0 +1,111 @@
+package cursor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	errs "github.com/go-park-mail-ru/2025_1_sigmaScript/internal/errors"
+)
+
+// Key sort key of listed item. Items are ordered by Num and then by Text, descending listing reverses both.
+// Ties are always broken by ascending ID, so items with equal keys keep the same order in both directions
+type Key struct {
+	Num  float64 `json:"n,omitempty"`
+	Text string  `json:"t,omitempty"`
+	ID   int     `json:"id"`
+}
+
+// Before reports whether item with key k goes before item with other key
+func (k Key) Before(other Key, desc bool) bool {
+	switch {
+	case k.Num != other.Num:
+		return k.Num < other.Num != desc
+	case k.Text != other.Text:
+		return k.Text < other.Text != desc
+	default:
+		return k.ID < other.ID
+	}
+}
+
+// pagePosition keyset position of the last item on page, it stays valid when items are added or removed
+type pagePosition struct {
+	Scope string `json:"sc,omitempty"`
+	Desc  bool   `json:"d,omitempty"`
+	Key
+}
+
+// Request page of listing. Scope names everything order of listing depends on besides direction,
+// e.g. sort field or collection id, cursor made for another scope or direction is rejected
+type Request struct {
+	Scope  string
+	Desc   bool
+	Limit  int
+	Cursor string
+}
+
+// Page items of requested page, Total counts items of the whole listing
+type Page[T any] struct {
+	Items      []T
+	Total      int
+	NextCursor string
+}
+
+// Scope joins values order of listing depends on, e.g. Scope(sort, period)
+func Scope(parts ...interface{}) string {
+	res := make([]string, 0, len(parts))
+	for _, part := range parts {
+		res = append(res, fmt.Sprint(part))
+	}
+	return strings.Join(res, "/")
+}
+
+// Paginate orders items by their keys and returns page which follows cursor of request
+func Paginate[T any](items []T, key func(item T) Key, req Request) (*Page[T], error) {
+	var start *Key
+	if req.Cursor != "" {
+		var pos pagePosition
+		if err := Decode(req.Cursor, &pos); err != nil || pos.Scope != req.Scope || pos.Desc != req.Desc {
+			return nil, errs.ErrInvalidCursor
+		}
+		start = &pos.Key
+	}
+
+	type entry struct {
+		item T
+		key  Key
+	}
+	entries := make([]entry, 0, len(items))
+	for _, item := range items {
+		entries = append(entries, entry{item: item, key: key(item)})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key.Before(entries[j].key, req.Desc)
+	})
+
+	from := 0
+	if start != nil {
+		from = sort.Search(len(entries), func(i int) bool {
+			return start.Before(entries[i].key, req.Desc)
+		})
+	}
+	to := min(from+max(req.Limit, 0), len(entries))
+
+	page := &Page[T]{
+		Items: make([]T, 0, to-from),
+		Total: len(entries),
+	}
+	for _, e := range entries[from:to] {
+		page.Items = append(page.Items, e.item)
+	}
+
+	if to > from && to < len(entries) {
+		token, err := Encode(pagePosition{Scope: req.Scope, Desc: req.Desc, Key: entries[to-1].key})
+		if err != nil {
+			return nil, err
+		}
+		page.NextCursor = token
+	}
+
+	return page, nil
+}
@@ -0,0 +1,168 @@
+package l10n
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Locale language of display strings
+type Locale string
+
+const (
+	// LocaleNone means display strings are not requested
+	LocaleNone Locale = ""
+	LocaleRU   Locale = "ru"
+	LocaleEN   Locale = "en"
+)
+
+// DateLayout ISO-8601 calendar date
+const DateLayout = "2006-01-02"
+
+var ruMonthsGenitive = []string{
+	"января", "февраля", "марта", "апреля", "мая", "июня",
+	"июля", "августа", "сентября", "октября", "ноября", "декабря",
+}
+
+var currencySymbols = map[string]string{
+	"USD": "$",
+	"EUR": "€",
+	"RUB": "₽",
+}
+
+// ParseLocale returns known locale or LocaleNone
+func ParseLocale(locale string) Locale {
+	switch Locale(strings.ToLower(strings.TrimSpace(locale))) {
+	case LocaleRU:
+		return LocaleRU
+	case LocaleEN:
+		return LocaleEN
+	default:
+		return LocaleNone
+	}
+}
+
+// FormatDate returns human readable date, e.g. "2 сентября 1964" or "September 2, 1964"
+func FormatDate(t time.Time, locale Locale) string {
+	switch locale {
+	case LocaleRU:
+		return fmt.Sprintf("%d %s %d", t.Day(), ruMonthsGenitive[t.Month()-1], t.Year())
+	case LocaleEN:
+		return t.Format("January 2, 2006")
+	default:
+		return ""
+	}
+}
+
+// FormatDuration returns human readable duration in minutes, e.g. "2ч 19м" or "2h 19m"
+func FormatDuration(minutes int, locale Locale) string {
+	hours, rest := minutes/60, minutes%60
+
+	var hourUnit, minuteUnit string
+	switch locale {
+	case LocaleRU:
+		hourUnit, minuteUnit = "ч", "м"
+	case LocaleEN:
+		hourUnit, minuteUnit = "h", "m"
+	default:
+		return ""
+	}
+
+	if hours == 0 {
+		return fmt.Sprintf("%d%s", rest, minuteUnit)
+	}
+	return fmt.Sprintf("%d%s %d%s", hours, hourUnit, rest, minuteUnit)
+}
+
+var (
+	hoursRegexp   = regexp.MustCompile(`(\d+)\s*(ч|h)`)
+	minutesRegexp = regexp.MustCompile(`(\d+)\s*(м|m)`)
+)
+
+// ParseDuration parses duration in minutes written by FormatDuration or as plain number, e.g. "2ч 19м" or "139".
+// False when text has no duration
+func ParseDuration(text string) (int, bool) {
+	text = strings.TrimSpace(text)
+	if minutes, err := strconv.Atoi(text); err == nil {
+		return minutes, minutes > 0
+	}
+
+	var minutes int
+	if match := hoursRegexp.FindStringSubmatch(text); match != nil {
+		hours, _ := strconv.Atoi(match[1])
+		minutes += hours * 60
+	}
+	if match := minutesRegexp.FindStringSubmatch(text); match != nil {
+		rest, _ := strconv.Atoi(match[1])
+		minutes += rest
+	}
+	return minutes, minutes > 0
+}
+
+// ParseHeight parses height in centimeters or meters into centimeters, e.g. "186" or "1,86 м".
+// False when text has no height
+func ParseHeight(text string) (int, bool) {
+	fields := strings.Fields(strings.ReplaceAll(text, ",", "."))
+	if len(fields) == 0 {
+		return 0, false
+	}
+
+	height, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil || height <= 0 {
+		return 0, false
+	}
+	if height < 3 {
+		// meters
+		height *= 100
+	}
+	return int(math.Round(height)), true
+}
+
+// FormatMoney returns human readable amount, e.g. "63 000 000 $" or "$63,000,000"
+func FormatMoney(amount int64, currency string, locale Locale) string {
+	symbol, ok := currencySymbols[currency]
+	if !ok {
+		symbol = currency
+	}
+
+	switch locale {
+	case LocaleRU:
+		return groupDigits(amount, " ") + " " + symbol
+	case LocaleEN:
+		if ok {
+			return symbol + groupDigits(amount, ",")
+		}
+		return groupDigits(amount, ",") + " " + symbol
+	default:
+		return ""
+	}
+}
+
+func groupDigits(amount int64, separator string) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := strconv.FormatInt(amount, 10)
+	var sb strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			sb.WriteString(separator)
+		}
+		sb.WriteRune(digit)
+	}
+	return sign + sb.String()
+}
+
+// Pick returns text in requested locale, russian text is the default and the fallback
+func Pick(locale Locale, ru, en string) string {
+	if locale == LocaleEN && en != "" {
+		return en
+	}
+	return ru
+}
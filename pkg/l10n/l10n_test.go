@@ -0,0 +1,72 @@
+package l10n
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLocale(t *testing.T) {
+	assert.Equal(t, LocaleRU, ParseLocale("ru"))
+	assert.Equal(t, LocaleEN, ParseLocale(" EN "))
+	assert.Equal(t, LocaleNone, ParseLocale("de"))
+	assert.Equal(t, LocaleNone, ParseLocale(""))
+}
+
+func TestFormatDate(t *testing.T) {
+	date := time.Date(1964, time.September, 2, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "2 сентября 1964", FormatDate(date, LocaleRU))
+	assert.Equal(t, "September 2, 1964", FormatDate(date, LocaleEN))
+	assert.Empty(t, FormatDate(date, LocaleNone))
+}
+
+func TestFormatDuration(t *testing.T) {
+	assert.Equal(t, "2ч 19м", FormatDuration(139, LocaleRU))
+	assert.Equal(t, "2h 19m", FormatDuration(139, LocaleEN))
+	assert.Equal(t, "45м", FormatDuration(45, LocaleRU))
+	assert.Empty(t, FormatDuration(139, LocaleNone))
+}
+
+func TestParseDuration(t *testing.T) {
+	for text, minutes := range map[string]int{"2ч 19м": 139, "2h 19m": 139, "45м": 45, "3ч": 180, " 90 ": 90} {
+		res, ok := ParseDuration(text)
+		assert.True(t, ok, text)
+		assert.Equal(t, minutes, res, text)
+	}
+
+	for _, text := range []string{"", "долго", "0"} {
+		_, ok := ParseDuration(text)
+		assert.False(t, ok, text)
+	}
+}
+
+func TestParseHeight(t *testing.T) {
+	for text, height := range map[string]int{"186": 186, "1,83 м": 183, "1.9": 190} {
+		res, ok := ParseHeight(text)
+		assert.True(t, ok, text)
+		assert.Equal(t, height, res, text)
+	}
+
+	for _, text := range []string{"", "высокий", "-1"} {
+		_, ok := ParseHeight(text)
+		assert.False(t, ok, text)
+	}
+}
+
+func TestFormatMoney(t *testing.T) {
+	assert.Equal(t, "63 000 000 $", FormatMoney(63000000, "USD", LocaleRU))
+	assert.Equal(t, "$63,000,000", FormatMoney(63000000, "USD", LocaleEN))
+	assert.Equal(t, "1 500 ₽", FormatMoney(1500, "RUB", LocaleRU))
+	assert.Equal(t, "100 CHF", FormatMoney(100, "CHF", LocaleEN))
+	assert.Equal(t, "-999", groupDigits(-999, ","))
+	assert.Empty(t, FormatMoney(100, "USD", LocaleNone))
+}
+
+func TestPick(t *testing.T) {
+	assert.Equal(t, "drama", Pick(LocaleEN, "драма", "drama"))
+	assert.Equal(t, "драма", Pick(LocaleRU, "драма", "drama"))
+	assert.Equal(t, "драма", Pick(LocaleNone, "драма", "drama"))
+	assert.Equal(t, "драма", Pick(LocaleEN, "драма", ""))
+}
@@ -60,3 +60,27 @@ func (sm *SyncCredentialsMap) LoadOrStore(key, value string) (string, bool) {
 	sm.m[key] = value
 	return value, true
 }
+
+// sync copy of all map values
+func (sm *SyncCredentialsMap) Copy() map[string]string {
+	sm.mx.RLock()
+	defer sm.mx.RUnlock()
+
+	res := make(map[string]string, len(sm.m))
+	for key, val := range sm.m {
+		res[key] = val
+	}
+	return res
+}
+
+// sync replace all map values with copy of m
+func (sm *SyncCredentialsMap) Replace(m map[string]string) {
+	res := make(map[string]string, len(m))
+	for key, val := range m {
+		res[key] = val
+	}
+
+	sm.mx.Lock()
+	defer sm.mx.Unlock()
+	sm.m = res
+}
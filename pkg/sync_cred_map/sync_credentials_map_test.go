@@ -71,3 +71,22 @@ func TestMap(t *testing.T) {
 
 	assert.Equal(t, entries, m)
 }
+
+func TestCopyAndReplace(t *testing.T) {
+	scm := synccredmap.NewSyncCredentialsMap()
+	scm.Store("key", "value")
+
+	copied := scm.Copy()
+	assert.Equal(t, map[string]string{"key": "value"}, copied)
+
+	copied["other"] = "value"
+	_, ok := scm.Load("other")
+	assert.False(t, ok)
+
+	scm.Replace(map[string]string{"new": "value"})
+	_, ok = scm.Load("key")
+	assert.False(t, ok)
+	val, ok := scm.Load("new")
+	assert.True(t, ok)
+	assert.Equal(t, "value", val)
+}
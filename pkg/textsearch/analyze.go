@@ -0,0 +1,81 @@
+package textsearch
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Script alphabet of a token
+type Script int
+
+const (
+	ScriptOther Script = iota
+	ScriptCyrillic
+	ScriptLatin
+)
+
+// Tokenize splits text into lower-cased words, "ё" is folded into "е"
+func Tokenize(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = strings.ReplaceAll(word, "ё", "е")
+	}
+	return words
+}
+
+// ScriptOf returns script of the word, words mixing alphabets or containing digits are ScriptOther
+func ScriptOf(word string) Script {
+	script := ScriptOther
+	for _, r := range word {
+		var current Script
+		switch {
+		case unicode.Is(unicode.Cyrillic, r):
+			current = ScriptCyrillic
+		case r < unicode.MaxASCII && unicode.IsLetter(r):
+			current = ScriptLatin
+		default:
+			return ScriptOther
+		}
+
+		if script != ScriptOther && script != current {
+			return ScriptOther
+		}
+		script = current
+	}
+	return script
+}
+
+// Stem returns stem of lower-cased word using stemmer of its script
+func Stem(word string) string {
+	switch ScriptOf(word) {
+	case ScriptCyrillic:
+		return StemRussian(word)
+	case ScriptLatin:
+		return StemEnglish(word)
+	default:
+		return word
+	}
+}
+
+// Analyze splits text into stemmed terms
+func Analyze(text string) []string {
+	words := Tokenize(text)
+	for i, word := range words {
+		words[i] = Stem(word)
+	}
+	return words
+}
+
+// variants returns stems query word may be looking for: the word itself and its transliteration
+func variants(word string) []string {
+	res := []string{Stem(word)}
+	switch ScriptOf(word) {
+	case ScriptLatin:
+		res = append(res, Stem(strings.ReplaceAll(ToCyrillic(word), "ё", "е")))
+	case ScriptCyrillic:
+		res = append(res, Stem(ToLatin(word)))
+	}
+	return res
+}
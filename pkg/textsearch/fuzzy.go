@@ -0,0 +1,55 @@
+package textsearch
+
+// maxEdits typo budget depending on word length: short words must match exactly
+func maxEdits(word []rune) int {
+	switch {
+	case len(word) <= 3:
+		return 0
+	case len(word) <= 6:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Distance returns Damerau-Levenshtein distance (optimal string alignment) between words,
+// or limit+1 when it exceeds limit
+func Distance(a, b []rune, limit int) int {
+	if diff := len(a) - len(b); diff > limit || -diff > limit {
+		return limit + 1
+	}
+
+	prevPrev := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				curr[j] = min(curr[j], prevPrev[j-2]+1)
+			}
+			rowMin = min(rowMin, curr[j])
+		}
+
+		if rowMin > limit {
+			return limit + 1
+		}
+		prevPrev, prev, curr = prev, curr, prevPrev
+	}
+
+	if prev[len(b)] > limit {
+		return limit + 1
+	}
+	return prev[len(b)]
+}
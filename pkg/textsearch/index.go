@@ -0,0 +1,249 @@
+package textsearch
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// match quality multipliers
+const (
+	exactMatch  = 1.0
+	prefixMatch = 0.7
+	fuzzyMatch  = 0.5
+
+	// minPrefixLength query words shorter than this are not used as prefixes
+	minPrefixLength = 3
+)
+
+// Field document text with its relevance weight
+type Field struct {
+	Text   string
+	Weight float64
+}
+
+// Hit found document
+type Hit struct {
+	ID    int
+	Score float64
+}
+
+type posting struct {
+	runes []rune
+	docs  map[int]float64
+}
+
+// Index in-memory inverted index of stemmed terms, safe for concurrent use
+type Index struct {
+	mu       sync.RWMutex
+	postings map[string]*posting
+	docs     map[int][]string
+
+	// terms sorted vocabulary, terms starting with query word form one range of it
+	terms []string
+	// byLength terms by number of runes, typos change length by no more than allowed edits
+	byLength map[int]map[string]*posting
+}
+
+// NewIndex returns new empty Index
+func NewIndex() *Index {
+	return &Index{
+		postings: make(map[string]*posting),
+		docs:     make(map[int][]string),
+		byLength: make(map[int]map[string]*posting),
+	}
+}
+
+// Len returns number of indexed documents
+func (ix *Index) Len() int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	return len(ix.docs)
+}
+
+// Upsert indexes document replacing its previous version
+func (ix *Index) Upsert(id int, fields ...Field) {
+	weights := make(map[string]float64)
+	for _, field := range fields {
+		for _, term := range Analyze(field.Text) {
+			weights[term] += field.Weight
+		}
+	}
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	ix.remove(id)
+
+	terms := make([]string, 0, len(weights))
+	for term, weight := range weights {
+		p, ok := ix.postings[term]
+		if !ok {
+			p = ix.addTerm(term)
+		}
+		p.docs[id] = weight
+		terms = append(terms, term)
+	}
+	ix.docs[id] = terms
+}
+
+// Remove drops document from index
+func (ix *Index) Remove(id int) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	ix.remove(id)
+}
+
+func (ix *Index) remove(id int) {
+	for _, term := range ix.docs[id] {
+		p := ix.postings[term]
+		delete(p.docs, id)
+		if len(p.docs) == 0 {
+			ix.removeTerm(term, p)
+		}
+	}
+	delete(ix.docs, id)
+}
+
+func (ix *Index) addTerm(term string) *posting {
+	p := &posting{runes: []rune(term), docs: make(map[int]float64)}
+	ix.postings[term] = p
+
+	i := sort.SearchStrings(ix.terms, term)
+	ix.terms = append(ix.terms, "")
+	copy(ix.terms[i+1:], ix.terms[i:])
+	ix.terms[i] = term
+
+	bucket, ok := ix.byLength[len(p.runes)]
+	if !ok {
+		bucket = make(map[string]*posting)
+		ix.byLength[len(p.runes)] = bucket
+	}
+	bucket[term] = p
+	return p
+}
+
+func (ix *Index) removeTerm(term string, p *posting) {
+	delete(ix.postings, term)
+
+	if i := sort.SearchStrings(ix.terms, term); i < len(ix.terms) && ix.terms[i] == term {
+		ix.terms = append(ix.terms[:i], ix.terms[i+1:]...)
+	}
+
+	bucket := ix.byLength[len(p.runes)]
+	delete(bucket, term)
+	if len(bucket) == 0 {
+		delete(ix.byLength, len(p.runes))
+	}
+}
+
+// Search returns documents matching query ordered by relevance. Each query word matches terms
+// exactly, by prefix, through transliteration or with typos; documents matching more words rank higher.
+// Non-positive limit means no limit
+func (ix *Index) Search(query string, limit int) []Hit {
+	words := Tokenize(query)
+	if len(words) == 0 {
+		return nil
+	}
+
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	scores := make(map[int]float64)
+	matched := make(map[int]int)
+	for _, word := range words {
+		for id, score := range ix.searchWord(word) {
+			scores[id] += score
+			matched[id]++
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for id, score := range scores {
+		coverage := float64(matched[id]) / float64(len(words))
+		hits = append(hits, Hit{ID: id, Score: score * coverage * coverage})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].ID < hits[j].ID
+	})
+
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits
+}
+
+// searchWord returns best score of every document matching query word
+func (ix *Index) searchWord(word string) map[int]float64 {
+	best := make(map[int]float64)
+	seen := make(map[string]struct{})
+
+	for _, variant := range variants(word) {
+		if _, ok := seen[variant]; ok || variant == "" {
+			continue
+		}
+		seen[variant] = struct{}{}
+
+		runes := []rune(variant)
+		edits := maxEdits(runes)
+		for term, p := range ix.candidates(variant, runes, edits) {
+			quality := matchQuality(variant, runes, edits, term, p.runes)
+			if quality == 0 {
+				continue
+			}
+
+			idf := math.Log(1 + float64(len(ix.docs))/float64(len(p.docs)))
+			for id, weight := range p.docs {
+				if score := weight * idf * quality; score > best[id] {
+					best[id] = score
+				}
+			}
+		}
+	}
+
+	return best
+}
+
+// candidates returns terms which may match variant: the same term, terms starting with it
+// and terms of close length which are compared by edit distance
+func (ix *Index) candidates(variant string, runes []rune, edits int) map[string]*posting {
+	res := make(map[string]*posting)
+	if p, ok := ix.postings[variant]; ok {
+		res[variant] = p
+	}
+
+	if len(runes) >= minPrefixLength {
+		for i := sort.SearchStrings(ix.terms, variant); i < len(ix.terms) && strings.HasPrefix(ix.terms[i], variant); i++ {
+			res[ix.terms[i]] = ix.postings[ix.terms[i]]
+		}
+	}
+
+	for length := len(runes) - edits; edits > 0 && length <= len(runes)+edits; length++ {
+		for term, p := range ix.byLength[length] {
+			res[term] = p
+		}
+	}
+
+	return res
+}
+
+func matchQuality(variant string, runes []rune, edits int, term string, termRunes []rune) float64 {
+	if term == variant {
+		return exactMatch
+	}
+	if len(runes) >= minPrefixLength && strings.HasPrefix(term, variant) {
+		return prefixMatch
+	}
+	if edits > 0 {
+		if d := Distance(runes, termRunes, edits); d <= edits {
+			return fuzzyMatch / float64(d)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,179 @@
+package textsearch
+
+import "strings"
+
+// Russian stemmer follows snowball algorithm
+// http://snowball.tartarus.org/algorithms/russian/stemmer.html
+
+const ruVowels = "аеиоуыэюя"
+
+var (
+	ruPerfectiveGerund1 = []string{"вшись", "вши", "в"}
+	ruPerfectiveGerund2 = []string{"ывшись", "ившись", "ывши", "ивши", "ыв", "ив"}
+
+	ruReflexive = []string{"ся", "сь"}
+
+	ruAdjective = []string{
+		"ими", "ыми", "его", "ого", "ему", "ому",
+		"ее", "ие", "ые", "ое", "ей", "ий", "ый", "ой", "ем", "им", "ым", "ом",
+		"их", "ых", "ую", "юю", "ая", "яя", "ою", "ею",
+	}
+	ruParticiple1 = []string{"ем", "нн", "вш", "ющ", "щ"}
+	ruParticiple2 = []string{"ивш", "ывш", "ующ"}
+
+	ruVerb1 = []string{"ете", "йте", "ешь", "нно", "ла", "на", "ли", "ем", "ло", "но", "ет", "ют", "ны", "ть", "й", "л", "н"}
+	ruVerb2 = []string{
+		"ейте", "уйте", "ила", "ыла", "ена", "ите", "или", "ыли", "ило", "ыло", "ено", "ует", "уют",
+		"ены", "ить", "ыть", "ишь", "ей", "уй", "ил", "ыл", "им", "ым", "ен", "ят", "ит", "ыт", "ую", "ю",
+	}
+
+	ruNoun = []string{
+		"иями", "ями", "ами", "ией", "иям", "ием", "иях",
+		"ев", "ов", "ие", "ье", "еи", "ии", "ей", "ой", "ий", "ям", "ем", "ам", "ом", "ах", "ях", "ию", "ью", "ия", "ья",
+		"а", "е", "и", "й", "о", "у", "ы", "ь", "ю", "я",
+	}
+
+	ruSuperlative   = []string{"ейше", "ейш"}
+	ruDerivational  = []string{"ость", "ост"}
+	ruGroup1Preface = "ая"
+)
+
+func isRuVowel(r rune) bool {
+	return strings.ContainsRune(ruVowels, r)
+}
+
+// ruRegions returns start of RV and R2 regions
+func ruRegions(word []rune) (int, int) {
+	rv, r1, r2 := len(word), len(word), len(word)
+
+	for i, r := range word {
+		if isRuVowel(r) {
+			rv = i + 1
+			break
+		}
+	}
+
+	for i := 1; i < len(word); i++ {
+		if !isRuVowel(word[i]) && isRuVowel(word[i-1]) {
+			r1 = i + 1
+			break
+		}
+	}
+
+	for i := r1 + 1; i < len(word); i++ {
+		if !isRuVowel(word[i]) && isRuVowel(word[i-1]) {
+			r2 = i + 1
+			break
+		}
+	}
+
+	return rv, r2
+}
+
+// cutSuffix removes the longest suffix lying after start position. When preface is set, suffix must
+// follow one of its letters, which itself stays in the word. Reports whether word was changed
+func cutSuffix(word []rune, start int, suffixes []string, preface string) ([]rune, bool) {
+	best := 0
+	for _, suffix := range suffixes {
+		s := []rune(suffix)
+		if len(s) <= best || len(word)-len(s) < start || !hasRuneSuffix(word, s) {
+			continue
+		}
+		if preface != "" {
+			pos := len(word) - len(s) - 1
+			if pos < start || !strings.ContainsRune(preface, word[pos]) {
+				continue
+			}
+		}
+		best = len(s)
+	}
+
+	if best == 0 {
+		return word, false
+	}
+	return word[:len(word)-best], true
+}
+
+func hasRuneSuffix(word, suffix []rune) bool {
+	if len(suffix) > len(word) {
+		return false
+	}
+	for i := range suffix {
+		if word[len(word)-len(suffix)+i] != suffix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// cutGrouped removes the longest ending of either group, first group requires "а" or "я" before it
+func cutGrouped(word []rune, start int, group1, group2 []string) ([]rune, bool) {
+	res1, ok1 := cutSuffix(word, start, group1, ruGroup1Preface)
+	res2, ok2 := cutSuffix(word, start, group2, "")
+
+	switch {
+	case ok1 && ok2:
+		if len(res2) < len(res1) {
+			return res2, true
+		}
+		return res1, true
+	case ok1:
+		return res1, true
+	case ok2:
+		return res2, true
+	default:
+		return word, false
+	}
+}
+
+// StemRussian returns stem of lower-cased russian word
+func StemRussian(word string) string {
+	w := []rune(word)
+	rv, r2 := ruRegions(w)
+
+	// step 1
+	var ok bool
+	if w, ok = cutGrouped(w, rv, ruPerfectiveGerund1, ruPerfectiveGerund2); !ok {
+		w, _ = cutSuffix(w, rv, ruReflexive, "")
+
+		if w, ok = cutSuffix(w, rv, ruAdjective, ""); ok {
+			w, _ = cutGrouped(w, rv, ruParticiple1, ruParticiple2)
+		} else if w, ok = cutGrouped(w, rv, ruVerb1, ruVerb2); !ok {
+			w, _ = cutSuffix(w, rv, ruNoun, "")
+		}
+	}
+
+	// step 2
+	w, _ = cutSuffix(w, rv, []string{"и"}, "")
+
+	// step 3
+	w, _ = cutSuffix(w, r2, ruDerivational, "")
+
+	// step 4
+	if res, ok := cutSuffix(w, rv, []string{"нн"}, ""); ok {
+		return string(res) + "н"
+	}
+	if res, ok := cutSuffix(w, rv, ruSuperlative, ""); ok {
+		w = res
+		if res, ok = cutSuffix(w, rv, []string{"нн"}, ""); ok {
+			w = append(res, 'н')
+		}
+		return string(w)
+	}
+	w, _ = cutSuffix(w, rv, []string{"ь"}, "")
+
+	return string(w)
+}
+
+// StemEnglish strips plural "s" of lower-cased english word
+func StemEnglish(word string) string {
+	if len(word) <= 3 {
+		return word
+	}
+	for _, ending := range []string{"ss", "us", "is"} {
+		if strings.HasSuffix(word, ending) {
+			return word
+		}
+	}
+	return strings.TrimSuffix(word, "s")
+}
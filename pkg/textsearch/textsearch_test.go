@@ -0,0 +1,148 @@
+package textsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStemRussian(t *testing.T) {
+	tests := map[string]string{
+		"бойцовский":  "бойцовск",
+		"бойцовского": "бойцовск",
+		"клуба":       "клуб",
+		"клубы":       "клуб",
+		"матрица":     "матриц",
+		"матрицы":     "матриц",
+		"красивейший": "красив",
+		"вскочившими": "вскоч",
+		"радость":     "радост",
+		"длинный":     "длин",
+		"сила":        "сил",
+	}
+
+	for word, stem := range tests {
+		assert.Equal(t, stem, StemRussian(word), word)
+	}
+}
+
+func TestStemEnglish(t *testing.T) {
+	assert.Equal(t, "club", StemEnglish("clubs"))
+	assert.Equal(t, "matrix", StemEnglish("matrix"))
+	assert.Equal(t, "boss", StemEnglish("boss"))
+	assert.Equal(t, "gas", StemEnglish("gas"))
+}
+
+func TestTransliteration(t *testing.T) {
+	assert.Equal(t, "бойцовский", ToCyrillic("bojcovskij"))
+	assert.Equal(t, "бойцовский", ToCyrillic("boytsovskiy"))
+	assert.Equal(t, "щука", ToCyrillic("shchuka"))
+	assert.Equal(t, "матрица", ToCyrillic("matritsa"))
+	assert.Equal(t, "matritsa", ToLatin("матрица"))
+	assert.Equal(t, "shchuka", ToLatin("щука"))
+}
+
+func TestSlug(t *testing.T) {
+	assert.Equal(t, "luchshie-za-vse-vremya", Slug("Лучшие за всё время"))
+	assert.Equal(t, "ford-protiv-ferrari-2019", Slug("Ford против Ferrari (2019)"))
+	assert.Equal(t, "", Slug("!!!"))
+}
+
+func TestTokenize(t *testing.T) {
+	assert.Equal(t, []string{"бойцовский", "клуб", "fight", "club", "1999"}, Tokenize("Бойцовский клуб (Fight Club, 1999)"))
+	assert.Equal(t, []string{"елки"}, Tokenize("Ёлки"))
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		limit int
+		want  int
+	}{
+		{"клуб", "клуб", 2, 0},
+		{"клуб", "клув", 2, 1},
+		{"клуб", "кулб", 2, 1},
+		{"матрица", "матрца", 2, 1},
+		{"матрица", "бойцовский", 2, 3},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Distance([]rune(tt.a), []rune(tt.b), tt.limit), tt.a+" "+tt.b)
+	}
+}
+
+func testIndex() *Index {
+	ix := NewIndex()
+	ix.Upsert(0,
+		Field{Text: "Бойцовский клуб", Weight: 3},
+		Field{Text: "Fight Club", Weight: 3},
+		Field{Text: "Эдвард Нортон Брэд Питт", Weight: 2},
+	)
+	ix.Upsert(1,
+		Field{Text: "Матрица", Weight: 3},
+		Field{Text: "The Matrix", Weight: 3},
+		Field{Text: "Киану Ривз", Weight: 2},
+		Field{Text: "Хакер Нео узнаёт, что мир вокруг лишь матрица", Weight: 0.5},
+	)
+	ix.Upsert(2,
+		Field{Text: "Клуб первых жён", Weight: 3},
+	)
+	return ix
+}
+
+func hitIDs(hits []Hit) []int {
+	ids := make([]int, 0, len(hits))
+	for _, hit := range hits {
+		ids = append(ids, hit.ID)
+	}
+	return ids
+}
+
+func TestIndex_Search(t *testing.T) {
+	ix := testIndex()
+	require.Equal(t, 3, ix.Len())
+
+	tests := []struct {
+		name  string
+		query string
+		want  []int
+	}{
+		{name: "exact", query: "бойцовский клуб", want: []int{0, 2}},
+		{name: "morphology", query: "бойцовского клуба", want: []int{0, 2}},
+		{name: "transliteration", query: "bojcovskij klub", want: []int{0, 2}},
+		{name: "typo", query: "матрца", want: []int{1}},
+		{name: "prefix", query: "матр", want: []int{1}},
+		{name: "staff", query: "киану ривз", want: []int{1}},
+		{name: "english", query: "fight clubs", want: []int{0}},
+		{name: "nothing", query: "терминатор", want: []int{}},
+		{name: "empty", query: " , ", want: []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, hitIDs(ix.Search(tt.query, 0)))
+		})
+	}
+}
+
+func TestIndex_Updates(t *testing.T) {
+	ix := testIndex()
+
+	ix.Upsert(1, Field{Text: "Терминатор", Weight: 3})
+	assert.Empty(t, ix.Search("матрица", 0))
+	assert.Equal(t, []int{1}, hitIDs(ix.Search("терминатор", 0)))
+
+	ix.Remove(1)
+	assert.Empty(t, ix.Search("терминатор", 0))
+	assert.Equal(t, 2, ix.Len())
+
+	assert.Len(t, ix.Search("клуб", 1), 1)
+
+	// vocabulary used to find candidates follows removed terms
+	ix.Remove(0)
+	ix.Remove(2)
+	assert.Empty(t, ix.Search("клуб", 0))
+	assert.Empty(t, ix.terms)
+	assert.Empty(t, ix.byLength)
+}
@@ -0,0 +1,95 @@
+package textsearch
+
+import (
+	"strings"
+	"unicode"
+)
+
+// latinToCyrillic longest sequences go first, so "shch" is not read as "sh" + "ch"
+var latinToCyrillic = []struct {
+	latin    string
+	cyrillic string
+}{
+	{"shch", "щ"},
+	{"sch", "щ"}, {"zh", "ж"}, {"kh", "х"}, {"ch", "ч"}, {"sh", "ш"}, {"ts", "ц"},
+	{"yu", "ю"}, {"ju", "ю"}, {"ya", "я"}, {"ja", "я"}, {"yo", "ё"}, {"jo", "ё"},
+	{"a", "а"}, {"b", "б"}, {"v", "в"}, {"w", "в"}, {"g", "г"}, {"d", "д"}, {"e", "е"}, {"z", "з"},
+	{"i", "и"}, {"j", "й"}, {"k", "к"}, {"q", "к"}, {"l", "л"}, {"m", "м"}, {"n", "н"}, {"o", "о"},
+	{"p", "п"}, {"r", "р"}, {"s", "с"}, {"t", "т"}, {"u", "у"}, {"f", "ф"}, {"h", "х"}, {"c", "ц"},
+	{"x", "кс"},
+}
+
+var cyrillicToLatin = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh", 'з': "z",
+	'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r",
+	'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+}
+
+const latinVowels = "aeiouy"
+
+// ToCyrillic transliterates lower-cased latin word, e.g. "bojcovskij" -> "бойцовский"
+func ToCyrillic(word string) string {
+	var sb strings.Builder
+	for i := 0; i < len(word); {
+		// "y" after vowel is "й" as in "bojcovskiy", otherwise it is "ы"
+		if word[i] == 'y' && !startsWithAny(word[i:], "yu", "ya", "yo") {
+			if i > 0 && strings.IndexByte(latinVowels, word[i-1]) >= 0 {
+				sb.WriteString("й")
+			} else {
+				sb.WriteString("ы")
+			}
+			i++
+			continue
+		}
+
+		matched := false
+		for _, pair := range latinToCyrillic {
+			if strings.HasPrefix(word[i:], pair.latin) {
+				sb.WriteString(pair.cyrillic)
+				i += len(pair.latin)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			// apostrophes of soft sign and other symbols are dropped
+			i++
+		}
+	}
+	return sb.String()
+}
+
+// ToLatin transliterates lower-cased cyrillic word, e.g. "матрица" -> "matritsa"
+func ToLatin(word string) string {
+	var sb strings.Builder
+	for _, r := range word {
+		if latin, ok := cyrillicToLatin[r]; ok {
+			sb.WriteString(latin)
+		} else if r < unicode.MaxASCII {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// Slug makes url-friendly latin identifier of text, e.g. "Лучшие за всё время" -> "luchshie-za-vse-vremya"
+func Slug(text string) string {
+	words := Tokenize(text)
+	parts := make([]string, 0, len(words))
+	for _, word := range words {
+		if latin := ToLatin(word); latin != "" {
+			parts = append(parts, latin)
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+func startsWithAny(s string, prefixes ...string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}